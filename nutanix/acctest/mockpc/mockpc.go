@@ -0,0 +1,690 @@
+// Package mockpc provides an in-memory fake of the Prism Central v4 REST APIs, to unit test
+// the v2 resources and data sources of the provider without a Nutanix setup.
+//
+// The server stores the entities of the registered collections as plain JSON objects. Creating,
+// updating and deleting an entity of an asynchronous collection returns a prism task, which
+// completes after Server.TaskPolls polls and references the affected entity. GET responses carry
+// an ETag which has to be sent back with If-Match when updating an entity, as Prism Central does.
+package mockpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-uuid"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+)
+
+const (
+	// Username used by the provider configurations returned by the server
+	Username = "admin"
+	// Password used by the provider configurations returned by the server
+	Password = "Nutanix/4u"
+
+	taskPath = "prism/config/tasks"
+)
+
+// Collection describes a v4 collection of entities served by the fake Prism Central
+type Collection struct {
+	// Path of the collection without the /api prefix and the version, e.g. networking/config/subnets
+	Path string
+	// ObjectType of the entities, e.g. networking.v4.config.Subnet
+	ObjectType string
+	// Rel is the relation of the entities in the tasks entities affected, e.g. networking:config:subnet
+	Rel string
+	// Sync collections return the entity on create and update instead of a task
+	Sync bool
+}
+
+// DefaultCollections are the collections registered by NewServer
+var DefaultCollections = []Collection{
+	{Path: "vmm/ahv/config/vms", ObjectType: "vmm.v4.ahv.config.Vm", Rel: "vmm:ahv:config:vm"},
+	{Path: "vmm/content/images", ObjectType: "vmm.v4.content.Image", Rel: "vmm:content:image"},
+	{Path: "vmm/content/templates", ObjectType: "vmm.v4.content.Template", Rel: "vmm:content:template"},
+	{Path: "networking/config/subnets", ObjectType: "networking.v4.config.Subnet", Rel: "networking:config:subnet"},
+	{Path: "networking/config/vpcs", ObjectType: "networking.v4.config.Vpc", Rel: "networking:config:vpc"},
+	{Path: "networking/config/floating-ips", ObjectType: "networking.v4.config.FloatingIp", Rel: "networking:config:floating-ip"},
+	{Path: "clustermgmt/config/clusters", ObjectType: "clustermgmt.v4.config.Cluster", Rel: "clustermgmt:config:cluster"},
+	{Path: "clustermgmt/config/storage-containers", ObjectType: "clustermgmt.v4.config.StorageContainer", Rel: "clustermgmt:config:storage-containers"},
+	{Path: "prism/config/categories", ObjectType: "prism.v4.config.Category", Sync: true},
+	{Path: "iam/authn/users", ObjectType: "iam.v4.authn.User", Sync: true},
+	{Path: "iam/authn/user-groups", ObjectType: "iam.v4.authn.UserGroup", Sync: true},
+	{Path: "iam/authz/roles", ObjectType: "iam.v4.authz.Role", Sync: true},
+}
+
+// Request is a request received by the server
+type Request struct {
+	Method  string
+	Path    string
+	Header  http.Header
+	Body    []byte
+	Queries map[string]string
+}
+
+// Task is an asynchronous operation started by the fake Prism Central
+type Task struct {
+	ExtID            string
+	Operation        string
+	EntitiesAffected []map[string]interface{}
+	// ErrorMessage makes the task fail when set
+	ErrorMessage string
+	// LegacyErrorMessage makes the task fail when set, as tasks of the legacy APIs do
+	LegacyErrorMessage string
+	// SubTasks are the extIds of the tasks spawned by the task
+	SubTasks []string
+	// Canceled is set when the task was canceled through the Tasks API
+	Canceled bool
+	polls    int
+}
+
+type entity struct {
+	object  map[string]interface{}
+	version int
+}
+
+// Server is a fake Prism Central serving the v4 APIs over TLS
+type Server struct {
+	*httptest.Server
+
+	// TaskPolls is the number of times a task is reported RUNNING before completing
+	TaskPolls int
+
+	mu          sync.Mutex
+	collections map[string]*Collection
+	entities    map[string]map[string]*entity
+	tasks       map[string]*Task
+	handlers    map[string]http.HandlerFunc
+	requests    []Request
+	failTask    string
+}
+
+// NewServer starts a fake Prism Central serving the DefaultCollections. It has to be closed by the caller.
+func NewServer() *Server {
+	s := &Server{
+		collections: make(map[string]*Collection),
+		entities:    make(map[string]map[string]*entity),
+		tasks:       make(map[string]*Task),
+		handlers:    make(map[string]http.HandlerFunc),
+	}
+	for _, c := range DefaultCollections {
+		s.Register(c)
+	}
+	s.Server = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Register adds a collection of entities to the server
+func (s *Server) Register(c Collection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c.Path = strings.Trim(c.Path, "/")
+	s.collections[c.Path] = &c
+	if _, ok := s.entities[c.Path]; !ok {
+		s.entities[c.Path] = make(map[string]*entity)
+	}
+}
+
+// Handle overrides the handling of the requests to the given method and versionless path,
+// e.g. Handle(http.MethodPost, "vmm/ahv/config/vms/{extId}/$actions/power-on", ...).
+// Path segments between braces match any value.
+func (s *Server) Handle(method, path string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method+" "+strings.Trim(path, "/")] = handler
+}
+
+// Add stores an entity in a collection, as if it existed on Prism Central, and returns its extId.
+// An extId is generated if the entity has none.
+func (s *Server) Add(path string, object map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(strings.Trim(path, "/"), object)
+}
+
+// Get returns a copy of an entity stored in a collection
+func (s *Server) Get(path, extID string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entities[strings.Trim(path, "/")][extID]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(e.object), true
+}
+
+// List returns a copy of the entities stored in a collection, sorted by extId
+func (s *Server) List(path string) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list(strings.Trim(path, "/"))
+}
+
+// Tasks returns the tasks started so far
+func (s *Server) Tasks() []Task {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tasks := make([]Task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		tasks = append(tasks, *t)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ExtID < tasks[j].ExtID })
+	return tasks
+}
+
+// AddTask stores a task, e.g. to fail a task with sub-tasks, and returns its extId.
+// An extId is generated if the task has none.
+func (s *Server) AddTask(t Task) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if t.ExtID == "" {
+		id, _ := uuid.GenerateUUID()
+		t.ExtID = "ZXJnb24=:" + id
+	}
+	s.tasks[t.ExtID] = &t
+	return t.ExtID
+}
+
+// FailNextTask makes the next started task fail with the given error message
+func (s *Server) FailNextTask(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failTask = message
+}
+
+// Requests returns the requests received so far, version negotiation excluded
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Host returns the host the server listens on
+func (s *Server) Host() string {
+	host, _, _ := net.SplitHostPort(s.Listener.Addr().String())
+	return host
+}
+
+// Port returns the port the server listens on
+func (s *Server) Port() string {
+	_, port, _ := net.SplitHostPort(s.Listener.Addr().String())
+	return port
+}
+
+// Config returns a provider configuration pointing to the server
+func (s *Server) Config() *conns.Config {
+	return &conns.Config{
+		Endpoint: s.Host(),
+		Port:     s.Port(),
+		Username: Username,
+		Password: Password,
+		Insecure: true,
+	}
+}
+
+// Client returns the provider API clients pointing to the server, to be used as meta by
+// the resources and data sources CRUD functions.
+func (s *Server) Client() (*conns.Client, error) {
+	return s.Config().Client()
+}
+
+// ProviderConfig returns the HCL provider block pointing to the server, for resource.UnitTest
+func (s *Server) ProviderConfig() string {
+	return fmt.Sprintf(`
+provider "nutanix" {
+  endpoint = %q
+  port     = %q
+  username = %q
+  password = %q
+  insecure = true
+}
+`, s.Host(), s.Port(), Username, Password)
+}
+
+// UnitTestPreCheck is the PreCheck of the resource.UnitTest tests run against the server. These tests run the
+// provider in-process with a terraform binary, they are skipped when none is available.
+func UnitTestPreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform binary not found, set TF_ACC_TERRAFORM_PATH to run the unit tests using terraform")
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user != Username || pass != Password {
+		writeError(w, http.StatusUnauthorized, "authentication failed")
+		return
+	}
+
+	namespace, path, ok := versionlessPath(r.URL.Path)
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown path "+r.URL.Path)
+		return
+	}
+
+	// version negotiation: advertise the latest version, the SDKs keep their own
+	if r.Method == http.MethodOptions && path == namespace+"/info" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": "v4.2"}, "")
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	queries := make(map[string]string)
+	for k := range r.URL.Query() {
+		queries[k] = r.URL.Query().Get(k)
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Header: r.Header.Clone(), Body: body, Queries: queries})
+	handler := s.handler(r.Method, path)
+	s.mu.Unlock()
+
+	if handler != nil {
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		handler(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.serveEntities(w, r, path, body, queries)
+}
+
+// handler returns the custom handler matching the method and path, if any
+func (s *Server) handler(method, path string) http.HandlerFunc {
+	segments := strings.Split(path, "/")
+	for pattern, handler := range s.handlers {
+		patternMethod, patternPath, _ := strings.Cut(pattern, " ")
+		if patternMethod != method {
+			continue
+		}
+		patternSegments := strings.Split(patternPath, "/")
+		if len(patternSegments) != len(segments) {
+			continue
+		}
+		match := true
+		for i, p := range patternSegments {
+			if !(strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}")) && p != segments[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return handler
+		}
+	}
+	return nil
+}
+
+func (s *Server) serveEntities(w http.ResponseWriter, r *http.Request, path string, body []byte, queries map[string]string) {
+	if strings.HasPrefix(path, taskPath+"/") {
+		s.serveTask(w, r, strings.TrimPrefix(path, taskPath+"/"))
+		return
+	}
+
+	collectionPath, extID, action := s.splitPath(path)
+	c, ok := s.collections[collectionPath]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown path "+r.URL.Path)
+		return
+	}
+
+	switch {
+	case extID == "" && r.Method == http.MethodGet:
+		s.serveList(w, c, queries)
+	case extID == "" && r.Method == http.MethodPost:
+		s.serveCreate(w, c, body)
+	case extID != "" && action != "":
+		s.serveAction(w, r, c, extID, action)
+	case r.Method == http.MethodGet:
+		e, ok := s.entities[c.Path][extID]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", c.ObjectType, extID))
+			return
+		}
+		writeJSON(w, http.StatusOK, envelope(e.object), etag(e))
+	case r.Method == http.MethodPut:
+		s.serveUpdate(w, r, c, extID, body)
+	case r.Method == http.MethodDelete:
+		s.serveDelete(w, r, c, extID)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not supported on "+r.URL.Path)
+	}
+}
+
+// splitPath splits a versionless path into the registered collection path, the extId of
+// the entity and the action invoked on it.
+func (s *Server) splitPath(path string) (collection, extID, action string) {
+	if _, ok := s.collections[path]; ok {
+		return path, "", ""
+	}
+	if i := strings.Index(path, "/$actions/"); i >= 0 {
+		path, action = path[:i], path[i+len("/$actions/"):]
+	}
+	i := strings.LastIndex(path, "/")
+	if i < 0 {
+		return path, "", action
+	}
+	return path[:i], path[i+1:], action
+}
+
+func (s *Server) serveList(w http.ResponseWriter, c *Collection, queries map[string]string) {
+	items := s.list(c.Path)
+	total := len(items)
+
+	limit, err := strconv.Atoi(queries["$limit"])
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+	page, _ := strconv.Atoi(queries["$page"])
+	start := page * limit
+	if start > total {
+		start = total
+	}
+	end := start + limit
+	if end > total {
+		end = total
+	}
+
+	resp := map[string]interface{}{
+		"$reserved": map[string]interface{}{},
+		"metadata": map[string]interface{}{
+			"totalAvailableResults": total,
+			"$objectType":           "common.v1.response.ApiResponseMetadata",
+		},
+	}
+	if end > start {
+		resp["data"] = items[start:end]
+	}
+	writeJSON(w, http.StatusOK, resp, "")
+}
+
+func (s *Server) serveCreate(w http.ResponseWriter, c *Collection, body []byte) {
+	object := make(map[string]interface{})
+	if err := json.Unmarshal(body, &object); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	object["$objectType"] = c.ObjectType
+	delete(object, "extId")
+	extID := s.add(c.Path, object)
+	e := s.entities[c.Path][extID]
+
+	if c.Sync {
+		writeJSON(w, http.StatusCreated, envelope(e.object), etag(e))
+		return
+	}
+	writeJSON(w, http.StatusAccepted, s.startTask("create", c, extID), "")
+}
+
+func (s *Server) serveUpdate(w http.ResponseWriter, r *http.Request, c *Collection, extID string, body []byte) {
+	e, ok := s.entities[c.Path][extID]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", c.ObjectType, extID))
+		return
+	}
+	if r.Header.Get("If-Match") == "" {
+		writeError(w, http.StatusPreconditionRequired, "If-Match header is required")
+		return
+	}
+	if r.Header.Get("If-Match") != etag(e) {
+		writeError(w, http.StatusPreconditionFailed, "entity was modified, ETag mismatch")
+		return
+	}
+
+	object := make(map[string]interface{})
+	if err := json.Unmarshal(body, &object); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	object["extId"] = extID
+	object["$objectType"] = c.ObjectType
+	delete(object, "$reserved")
+	e.object = object
+	e.version++
+
+	if c.Sync {
+		writeJSON(w, http.StatusOK, envelope(e.object), etag(e))
+		return
+	}
+	writeJSON(w, http.StatusAccepted, s.startTask("update", c, extID), "")
+}
+
+func (s *Server) serveDelete(w http.ResponseWriter, r *http.Request, c *Collection, extID string) {
+	e, ok := s.entities[c.Path][extID]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", c.ObjectType, extID))
+		return
+	}
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != etag(e) {
+		writeError(w, http.StatusPreconditionFailed, "entity was modified, ETag mismatch")
+		return
+	}
+	delete(s.entities[c.Path], extID)
+
+	if c.Sync {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, s.startTask("delete", c, extID), "")
+}
+
+// serveAction answers $actions invoked on an entity with a task. Actions changing the entity
+// have to be implemented with Handle.
+func (s *Server) serveAction(w http.ResponseWriter, r *http.Request, c *Collection, extID, action string) {
+	if _, ok := s.entities[c.Path][extID]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", c.ObjectType, extID))
+		return
+	}
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not supported on "+r.URL.Path)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, s.startTask(action, c, extID), "")
+}
+
+// StartTask starts a task affecting the given entity, and returns the task reference response
+// to send to the client. It is meant to be used by the custom handlers.
+func (s *Server) StartTask(operation string, c Collection, extID string) map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.startTask(operation, &c, extID)
+}
+
+func (s *Server) startTask(operation string, c *Collection, extID string) map[string]interface{} {
+	id, _ := uuid.GenerateUUID()
+	t := &Task{
+		ExtID:     "ZXJnb24=:" + id,
+		Operation: operation,
+		EntitiesAffected: []map[string]interface{}{{
+			"extId":       extID,
+			"rel":         c.Rel,
+			"$objectType": "prism.v4.config.EntityReference",
+		}},
+		ErrorMessage: s.failTask,
+	}
+	s.failTask = ""
+	s.tasks[t.ExtID] = t
+
+	return map[string]interface{}{
+		"$reserved": map[string]interface{}{},
+		"data": map[string]interface{}{
+			"extId":       t.ExtID,
+			"$objectType": "prism.v4.config.TaskReference",
+		},
+	}
+}
+
+func (s *Server) serveTask(w http.ResponseWriter, r *http.Request, path string) {
+	extID, action, _ := strings.Cut(path, "/$actions/")
+	t, ok := s.tasks[extID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "task "+extID+" not found")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodPost && action == "cancel":
+		t.Canceled = true
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{"$reserved": map[string]interface{}{}}, "")
+		return
+	case r.Method != http.MethodGet || action != "":
+		writeError(w, http.StatusMethodNotAllowed, r.Method+" not supported on "+r.URL.Path)
+		return
+	}
+
+	task := map[string]interface{}{
+		"extId":            t.ExtID,
+		"operation":        t.Operation,
+		"entitiesAffected": t.EntitiesAffected,
+		"$objectType":      "prism.v4.config.Task",
+	}
+	if len(t.SubTasks) > 0 {
+		subTasks := make([]map[string]interface{}, 0, len(t.SubTasks))
+		for _, sub := range t.SubTasks {
+			subTasks = append(subTasks, map[string]interface{}{
+				"extId":       sub,
+				"$objectType": "prism.v4.config.TaskReferenceInternal",
+			})
+		}
+		task["subTasks"] = subTasks
+	}
+
+	switch {
+	case t.Canceled:
+		task["status"] = "CANCELED"
+		task["progressPercentage"] = 100 * t.polls / (s.TaskPolls + 1)
+	case t.polls < s.TaskPolls:
+		t.polls++
+		task["status"] = "RUNNING"
+		task["progressPercentage"] = 100 * t.polls / (s.TaskPolls + 1)
+	case t.ErrorMessage != "" || t.LegacyErrorMessage != "":
+		task["status"] = "FAILED"
+		task["progressPercentage"] = 100
+		if t.ErrorMessage != "" {
+			task["errorMessages"] = []map[string]interface{}{{
+				"message":     t.ErrorMessage,
+				"$objectType": "prism.v4.error.AppMessage",
+			}}
+		}
+		if t.LegacyErrorMessage != "" {
+			task["legacyErrorMessage"] = t.LegacyErrorMessage
+		}
+	default:
+		task["status"] = "SUCCEEDED"
+		task["progressPercentage"] = 100
+	}
+	writeJSON(w, http.StatusOK, envelope(task), "")
+}
+
+func (s *Server) add(path string, object map[string]interface{}) string {
+	object = copyObject(object)
+	extID, _ := object["extId"].(string)
+	if extID == "" {
+		extID, _ = uuid.GenerateUUID()
+		object["extId"] = extID
+	}
+	if c, ok := s.collections[path]; ok {
+		if _, ok := object["$objectType"]; !ok {
+			object["$objectType"] = c.ObjectType
+		}
+	}
+	if _, ok := s.entities[path]; !ok {
+		s.entities[path] = make(map[string]*entity)
+	}
+	version := 1
+	if e, ok := s.entities[path][extID]; ok {
+		version = e.version + 1
+	}
+	s.entities[path][extID] = &entity{object: object, version: version}
+	return extID
+}
+
+func (s *Server) list(path string) []map[string]interface{} {
+	items := make([]map[string]interface{}, 0, len(s.entities[path]))
+	for _, e := range s.entities[path] {
+		items = append(items, copyObject(e.object))
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return fmt.Sprint(items[i]["extId"]) < fmt.Sprint(items[j]["extId"])
+	})
+	return items
+}
+
+// versionlessPath splits /api/{namespace}/{version}/{path} and returns the namespace and the
+// path prefixed by the namespace, e.g. networking/config/subnets.
+func versionlessPath(path string) (string, string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) < 4 || segments[0] != "api" {
+		return "", "", false
+	}
+	return segments[1], strings.Join(append(segments[1:2], segments[3:]...), "/"), true
+}
+
+func envelope(object map[string]interface{}) map[string]interface{} {
+	data := copyObject(object)
+	data["$reserved"] = map[string]interface{}{}
+	return map[string]interface{}{
+		"$reserved": map[string]interface{}{},
+		"data":      data,
+	}
+}
+
+func etag(e *entity) string {
+	return fmt.Sprintf(`W/"%s-%d"`, e.object["extId"], e.version)
+}
+
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	b, _ := json.Marshal(object)
+	out := make(map[string]interface{})
+	_ = json.Unmarshal(b, &out)
+	return out
+}
+
+// WriteJSON writes a JSON response, to be used by the custom handlers
+func WriteJSON(w http.ResponseWriter, status int, body interface{}) {
+	writeJSON(w, status, body, "")
+}
+
+// WriteError writes an error response the way the v4 APIs do, to be used by the custom handlers
+func WriteError(w http.ResponseWriter, status int, message string) {
+	writeError(w, status, message)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}, etag string) {
+	w.Header().Set("Content-Type", "application/json")
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"data": map[string]interface{}{
+			"error": []map[string]interface{}{{
+				"message":     message,
+				"severity":    "ERROR",
+				"$objectType": "common.v1.config.Message",
+			}},
+			"$objectType": "prism.v4.error.ErrorResponse",
+		},
+	}, "")
+}
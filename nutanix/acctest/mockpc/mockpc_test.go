@@ -0,0 +1,164 @@
+package mockpc
+
+import (
+	"net/http"
+	"testing"
+
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
+	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func TestServer_subnetLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("Client(): %v", err)
+	}
+	subnets := client.NetworkingAPI.SubnetAPIInstance
+
+	subnetType := import1.SUBNETTYPE_VLAN
+	createResp, err := subnets.CreateSubnet(&import1.Subnet{Name: utils.StringPtr("vlan-1"), SubnetType: &subnetType})
+	if err != nil {
+		t.Fatalf("CreateSubnet(): %v", err)
+	}
+	taskRef := createResp.Data.GetValue().(import4.TaskReference)
+
+	taskResp, err := client.PrismAPI.TaskRefAPI.GetTaskById(taskRef.ExtId, nil)
+	if err != nil {
+		t.Fatalf("GetTaskById(): %v", err)
+	}
+	task := taskResp.Data.GetValue().(prismConfig.Task)
+	if task.Status.GetName() != "SUCCEEDED" {
+		t.Fatalf("task status = %s, expected SUCCEEDED", task.Status.GetName())
+	}
+	if len(task.EntitiesAffected) != 1 || utils.StringValue(task.EntitiesAffected[0].Rel) != utils.RelEntityTypeSubnet {
+		t.Fatalf("unexpected entities affected %v", task.EntitiesAffected)
+	}
+	extID := task.EntitiesAffected[0].ExtId
+
+	getResp, err := subnets.GetSubnetById(extID)
+	if err != nil {
+		t.Fatalf("GetSubnetById(): %v", err)
+	}
+	subnet := getResp.Data.GetValue().(import1.Subnet)
+	if utils.StringValue(subnet.Name) != "vlan-1" {
+		t.Errorf("subnet name = %s, expected vlan-1", utils.StringValue(subnet.Name))
+	}
+
+	// updating without the ETag is rejected
+	if _, err := subnets.UpdateSubnetById(extID, &import1.Subnet{Name: utils.StringPtr("vlan-2")}); err == nil {
+		t.Error("expected an error when updating without If-Match")
+	}
+
+	subnet.Name = utils.StringPtr("vlan-2")
+	args := map[string]interface{}{"If-Match": utils.StringPtr(subnets.ApiClient.GetEtag(getResp))}
+	if _, err := subnets.UpdateSubnetById(extID, &subnet, args); err != nil {
+		t.Fatalf("UpdateSubnetById(): %v", err)
+	}
+	if stored, _ := s.Get("networking/config/subnets", *extID); stored["name"] != "vlan-2" {
+		t.Errorf("stored subnet name = %v, expected vlan-2", stored["name"])
+	}
+
+	// the ETag changes with every update
+	if _, err := subnets.UpdateSubnetById(extID, &import1.Subnet{Name: utils.StringPtr("vlan-3")}, args); err == nil {
+		t.Error("expected an error when updating with a stale ETag")
+	}
+
+	if _, err := subnets.DeleteSubnetById(extID); err != nil {
+		t.Fatalf("DeleteSubnetById(): %v", err)
+	}
+	if _, ok := s.Get("networking/config/subnets", *extID); ok {
+		t.Error("subnet not deleted")
+	}
+	if _, err := subnets.GetSubnetById(extID); err == nil {
+		t.Error("expected an error when reading a deleted subnet")
+	}
+}
+
+func TestServer_list(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	for i := 0; i < 5; i++ {
+		s.Add("networking/config/vpcs", map[string]interface{}{"name": "vpc"})
+	}
+
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("Client(): %v", err)
+	}
+
+	resp, err := client.NetworkingAPI.VpcAPIInstance.ListVpcs(utils.IntPtr(1), utils.IntPtr(3), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("ListVpcs(): %v", err)
+	}
+	if total := utils.IntValue(resp.Metadata.TotalAvailableResults); total != 5 {
+		t.Errorf("total available results = %d, expected 5", total)
+	}
+	if vpcs := resp.Data.GetValue().([]import1.Vpc); len(vpcs) != 2 {
+		t.Errorf("got %d vpcs on the second page, expected 2", len(vpcs))
+	}
+}
+
+func TestServer_taskPolling(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	s.TaskPolls = 2
+
+	extID := s.Add("vmm/ahv/config/vms", map[string]interface{}{"name": "vm"})
+	s.FailNextTask("power on failed")
+
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("Client(): %v", err)
+	}
+
+	if _, err := client.VmmAPI.VMAPIInstance.PowerOnVm(utils.StringPtr(extID)); err != nil {
+		t.Fatalf("PowerOnVm(): %v", err)
+	}
+	taskExtID := s.Tasks()[0].ExtID
+
+	for _, expected := range []string{"RUNNING", "RUNNING", "FAILED"} {
+		taskResp, err := client.PrismAPI.TaskRefAPI.GetTaskById(&taskExtID, nil)
+		if err != nil {
+			t.Fatalf("GetTaskById(): %v", err)
+		}
+		task := taskResp.Data.GetValue().(prismConfig.Task)
+		if task.Status.GetName() != expected {
+			t.Errorf("task status = %s, expected %s", task.Status.GetName(), expected)
+		}
+	}
+}
+
+func TestServer_handle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	extID := s.Add("vmm/ahv/config/vms", map[string]interface{}{"name": "vm", "powerState": "OFF"})
+	s.Handle(http.MethodPost, "vmm/ahv/config/vms/{extId}/$actions/power-on", func(w http.ResponseWriter, r *http.Request) {
+		vm, _ := s.Get("vmm/ahv/config/vms", extID)
+		vm["powerState"] = "ON"
+		s.Add("vmm/ahv/config/vms", vm)
+		WriteJSON(w, http.StatusAccepted, s.StartTask("power-on", DefaultCollections[0], extID))
+	})
+
+	client, err := s.Client()
+	if err != nil {
+		t.Fatalf("Client(): %v", err)
+	}
+	if _, err := client.VmmAPI.VMAPIInstance.PowerOnVm(utils.StringPtr(extID)); err != nil {
+		t.Fatalf("PowerOnVm(): %v", err)
+	}
+	if vm, _ := s.Get("vmm/ahv/config/vms", extID); vm["powerState"] != "ON" {
+		t.Errorf("vm power state = %v, expected ON", vm["powerState"])
+	}
+
+	requests := s.Requests()
+	if last := requests[len(requests)-1]; last.Method != http.MethodPost || last.Path != "vmm/ahv/config/vms/"+extID+"/$actions/power-on" {
+		t.Errorf("unexpected last request %s %s", last.Method, last.Path)
+	}
+}
@@ -3,7 +3,6 @@ package client
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -14,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/PaesslerAG/jsonpath"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
@@ -26,6 +26,7 @@ const (
 	httpsPrefix    = "https"
 	// absolutePath   = "api/nutanix/" + libraryVersion
 	// userAgent      = "nutanix/" + libraryVersion
+	apiKeyHeader    = "X-ntnx-api-key"
 	mediaType       = "application/json"
 	formEncodedType = "application/x-www-form-urlencoded"
 	octetStreamType = "application/octet-stream"
@@ -75,6 +76,19 @@ type Credentials struct {
 	NdbEndpoint        string              // Required field for connecting to Era VM APIs.
 	NdbUsername        string
 	NdbPassword        string
+	MaxRetryAttempts   int           // Maximum number of retries for requests failing with a transient error
+	RetryWaitMin       time.Duration // Minimum backoff between two retries
+	RetryWaitMax       time.Duration // Maximum backoff between two retries
+	APIKey             string        // API key sent in X-ntnx-api-key header instead of basic auth
+	CACertFile         string        // PEM file with CA certificates trusted in addition to the system ones
+	CACertPEM          string        // PEM encoded CA certificates trusted in addition to the system ones
+	ClientCertFile     string        // PEM file with the client certificate presented for mTLS
+	ClientKeyFile      string        // PEM file with the private key of the client certificate
+}
+
+// HasAuth returns true if either an api key or username & password are provided
+func (c *Credentials) HasAuth() bool {
+	return c.APIKey != "" || (c.Username != "" && c.Password != "")
 }
 
 // AdditionalFilter specification for client side filters
@@ -107,10 +121,14 @@ func NewClient(credentials *Credentials, userAgent string, absolutePath string,
 			return nil, fmt.Errorf("error parsing proxy url: %s", err)
 		}
 
+		tlsConfig, err := credentials.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
+
 		// override transport config incase of using proxy
 		transCfg := &http.Transport{
-			//nolint:gas
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: credentials.Insecure}, // ignore expired SSL certificates
+			TLSClientConfig: tlsConfig,
 		}
 		transCfg.Proxy = http.ProxyURL(proxy)
 		baseClient.client.Transport = logging.NewTransport("Nutanix", transCfg)
@@ -149,13 +167,16 @@ func NewBaseClient(credentials *Credentials, absolutePath string, isHTTP bool) (
 		return nil, fmt.Errorf("absolutePath argument must be passed")
 	}
 
-	httpClient := http.DefaultClient
+	tlsConfig, err := credentials.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
 
-	transCfg := &http.Transport{
+	// every client gets its own http client as transports differ between endpoints
+	httpClient := &http.Client{}
 
-		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: credentials.Insecure,
-		},
+	transCfg := &http.Transport{
+		TLSClientConfig: tlsConfig,
 	}
 	httpClient.Transport = logging.NewTransport("Nutanix", transCfg)
 
@@ -209,12 +230,21 @@ func (c *Client) NewRequest(ctx context.Context, method, urlStr string, body int
 			req.AddCookie(i)
 		}
 	} else {
-		req.Header.Add("Authorization", "Basic "+
-			base64.StdEncoding.EncodeToString([]byte(c.Credentials.Username+":"+c.Credentials.Password)))
+		c.addAuthHeader(req)
 	}
 	return req, nil
 }
 
+// addAuthHeader sets the api key header if an api key is provided, basic auth header otherwise
+func (c *Client) addAuthHeader(req *http.Request) {
+	if c.Credentials.APIKey != "" {
+		req.Header.Add(apiKeyHeader, c.Credentials.APIKey)
+		return
+	}
+	req.Header.Add("Authorization", "Basic "+
+		base64.StdEncoding.EncodeToString([]byte(c.Credentials.Username+":"+c.Credentials.Password)))
+}
+
 // NewRequest creates a request without authorisation headers
 func (c *Client) NewUnAuthRequest(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	// check if client exists or not
@@ -306,14 +336,52 @@ func (c *Client) NewUploadRequest(ctx context.Context, method, urlStr string, fi
 	}
 	req.ContentLength = fileInfo.Size()
 	req.GetBody = func() (io.ReadCloser, error) {
+		// rewind the file so that the whole content is sent again on retries
+		if _, err := fileReader.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
 		return io.NopCloser(fileReader), nil
 	}
 
 	req.Header.Add("Content-Type", octetStreamType)
 	req.Header.Add("Accept", mediaType)
 	req.Header.Add("User-Agent", c.UserAgent)
-	req.Header.Add("Authorization", "Basic "+
-		base64.StdEncoding.EncodeToString([]byte(c.Credentials.Username+":"+c.Credentials.Password)))
+	c.addAuthHeader(req)
+
+	return req, nil
+}
+
+// NewChunkUploadRequest handles uploads of a file section, for APIs accepting files in chunks
+func (c *Client) NewChunkUploadRequest(ctx context.Context, method, urlStr string, chunk *io.SectionReader) (*http.Request, error) {
+	// check if client exists or not
+	if c.client == nil {
+		return nil, fmt.Errorf("%s", c.ErrorMsg)
+	}
+	rel, errp := url.Parse(c.AbsolutePath + urlStr)
+	if errp != nil {
+		return nil, errp
+	}
+
+	u := c.BaseURL.ResolveReference(rel)
+
+	req, err := http.NewRequest(method, u.String(), io.NopCloser(chunk))
+	if err != nil {
+		return nil, err
+	}
+
+	req.ContentLength = chunk.Size()
+	req.GetBody = func() (io.ReadCloser, error) {
+		// rewind the section so that the whole chunk is sent again on retries
+		if _, err := chunk.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(chunk), nil
+	}
+
+	req.Header.Add("Content-Type", octetStreamType)
+	req.Header.Add("Accept", mediaType)
+	req.Header.Add("User-Agent", c.UserAgent)
+	c.addAuthHeader(req)
 
 	return req, nil
 }
@@ -345,6 +413,10 @@ func (c *Client) NewUnAuthUploadRequest(ctx context.Context, method, urlStr stri
 	}
 	req.ContentLength = fileInfo.Size()
 	req.GetBody = func() (io.ReadCloser, error) {
+		// rewind the file so that the whole content is sent again on retries
+		if _, err := fileReader.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
 		return io.NopCloser(fileReader), nil
 	}
 
@@ -367,7 +439,7 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) error
 	}
 
 	req = req.WithContext(ctx)
-	resp, err := c.client.Do(req)
+	resp, err := c.doWithRetry(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -405,6 +477,31 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) error
 	return err
 }
 
+// DoStream performs the request passed and returns the response without reading its body, for
+// downloads streamed by the caller. The caller has to close the response body.
+func (c *Client) DoStream(ctx context.Context, req *http.Request) (*http.Response, error) {
+	// check if client exists or not
+	if c.client == nil {
+		return nil, fmt.Errorf("%s", c.ErrorMsg)
+	}
+
+	req = req.WithContext(ctx)
+	resp, err := c.doWithRetry(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := CheckResponse(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	if c.onRequestCompleted != nil {
+		c.onRequestCompleted(req, resp, nil)
+	}
+	return resp, nil
+}
+
 func searchSlice(slice []string, key string) bool {
 	for _, v := range slice {
 		if v == key {
@@ -421,7 +518,7 @@ func (c *Client) DoWithFilters(ctx context.Context, req *http.Request, v interfa
 		return fmt.Errorf("%s", c.ErrorMsg)
 	}
 	req = req.WithContext(ctx)
-	resp, err := c.client.Do(req)
+	resp, err := c.doWithRetry(ctx, req)
 	if err != nil {
 		return err
 	}
@@ -574,6 +671,13 @@ func CheckResponse(r *http.Response) error {
 		return fmt.Errorf("unmarshalling error response %s for response body %s", err, string(buf))
 	}
 
+	// v4 APIs return the error messages in data.error
+	if data, ok := res["data"].(map[string]interface{}); ok {
+		if messages, ok := data["error"].([]interface{}); ok && len(messages) > 0 {
+			return v4Error(r.Status, messages)
+		}
+	}
+
 	errRes := &ErrorResponse{}
 	if status, ok := res["status"]; ok {
 		_, sok := status.(string)
@@ -640,6 +744,17 @@ func (r *ErrorResponse) Error() string {
 	return err
 }
 
+// v4Error builds the error of a v4 api response from its error messages
+func v4Error(status string, messages []interface{}) error {
+	msgs := make([]string, 0, len(messages))
+	for _, m := range messages {
+		if message, ok := m.(map[string]interface{}); ok {
+			msgs = append(msgs, fmt.Sprint(message["message"]))
+		}
+	}
+	return fmt.Errorf("error: %s: %s", status, strings.Join(msgs, "; "))
+}
+
 func fillStruct(data map[string]interface{}, result interface{}) error {
 	j, err := json.Marshal(data)
 	if err != nil {
@@ -27,14 +27,14 @@ func setup() (*http.ServeMux, *Client, *httptest.Server) {
 	mux := http.NewServeMux()
 	server := httptest.NewServer(mux)
 
-	client, _ := NewClient(&Credentials{"", "username", "password", "", "", true, false, "", "", "", nil, "", "", ""}, testUserAgent, testAbsolutePath, false)
+	client, _ := NewClient(&Credentials{"", "username", "password", "", "", true, false, "", "", "", nil, "", "", "", 0, 0, 0, "", "", "", "", ""}, testUserAgent, testAbsolutePath, false)
 	client.BaseURL, _ = url.Parse(server.URL)
 
 	return mux, client, server
 }
 
 func TestNewClient(t *testing.T) {
-	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", ""}, testUserAgent, testAbsolutePath, false)
+	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", "", 0, 0, 0, "", "", "", "", ""}, testUserAgent, testAbsolutePath, false)
 	if err != nil {
 		t.Errorf("Unexpected Error: %v", err)
 	}
@@ -51,7 +51,7 @@ func TestNewClient(t *testing.T) {
 }
 
 func TestNewBaseClient(t *testing.T) {
-	c, err := NewBaseClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", ""}, testAbsolutePath, true)
+	c, err := NewBaseClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", "", 0, 0, 0, "", "", "", "", ""}, testAbsolutePath, true)
 	if err != nil {
 		t.Errorf("Unexpected Error: %v", err)
 	}
@@ -68,7 +68,7 @@ func TestNewBaseClient(t *testing.T) {
 }
 
 func TestNewRequest(t *testing.T) {
-	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", ""}, testUserAgent, testAbsolutePath, false)
+	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", "", 0, 0, 0, "", "", "", "", ""}, testUserAgent, testAbsolutePath, false)
 	if err != nil {
 		t.Errorf("Unexpected Error: %v", err)
 	}
@@ -90,8 +90,77 @@ func TestNewRequest(t *testing.T) {
 	}
 }
 
+func TestNewRequest_authHeaders(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials *Credentials
+		wantAPIKey  string
+		wantBasic   bool
+	}{
+		{
+			name:        "basic auth",
+			credentials: &Credentials{URL: "foo.com", Username: "username", Password: "password"},
+			wantBasic:   true,
+		},
+		{
+			name:        "api key",
+			credentials: &Credentials{URL: "foo.com", APIKey: "secret-key"},
+			wantAPIKey:  "secret-key",
+		},
+		{
+			name:        "api key takes precedence over basic auth",
+			credentials: &Credentials{URL: "foo.com", Username: "username", Password: "password", APIKey: "secret-key"},
+			wantAPIKey:  "secret-key",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(tt.credentials, testUserAgent, testAbsolutePath, false)
+			if err != nil {
+				t.Fatalf("Unexpected Error: %v", err)
+			}
+
+			req, err := c.NewRequest(context.TODO(), http.MethodGet, "/foo", nil)
+			if err != nil {
+				t.Fatalf("NewRequest() errored out with error : %v", err)
+			}
+
+			if got := req.Header.Get(apiKeyHeader); got != tt.wantAPIKey {
+				t.Errorf("NewRequest() %s header = %v, expected %v", apiKeyHeader, got, tt.wantAPIKey)
+			}
+			if _, _, gotBasic := req.BasicAuth(); gotBasic != tt.wantBasic {
+				t.Errorf("NewRequest() basic auth set = %v, expected %v", gotBasic, tt.wantBasic)
+			}
+		})
+	}
+}
+
+func TestCredentials_HasAuth(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials Credentials
+		want        bool
+	}{
+		{"username and password", Credentials{Username: "username", Password: "password"}, true},
+		{"api key", Credentials{APIKey: "secret-key"}, true},
+		{"username only", Credentials{Username: "username"}, false},
+		{"nothing", Credentials{}, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.credentials.HasAuth(); got != tt.want {
+				t.Errorf("Credentials.HasAuth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewUploadRequest(t *testing.T) {
-	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", ""}, testUserAgent, testAbsolutePath, true)
+	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", "", 0, 0, 0, "", "", "", "", ""}, testUserAgent, testAbsolutePath, true)
 	if err != nil {
 		t.Errorf("Unexpected Error: %v", err)
 	}
@@ -133,7 +202,7 @@ func TestNewUploadRequest(t *testing.T) {
 }
 
 func TestNewUnAuthRequest(t *testing.T) {
-	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", ""}, testUserAgent, testAbsolutePath, true)
+	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", "", 0, 0, 0, "", "", "", "", ""}, testUserAgent, testAbsolutePath, true)
 	if err != nil {
 		t.Errorf("Unexpected Error: %v", err)
 	}
@@ -171,7 +240,7 @@ func TestNewUnAuthRequest(t *testing.T) {
 }
 
 func TestNewUnAuthFormEncodedRequest(t *testing.T) {
-	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", ""}, testUserAgent, testAbsolutePath, true)
+	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", "", 0, 0, 0, "", "", "", "", ""}, testUserAgent, testAbsolutePath, true)
 	if err != nil {
 		t.Errorf("Unexpected Error: %v", err)
 	}
@@ -213,7 +282,7 @@ func TestNewUnAuthFormEncodedRequest(t *testing.T) {
 }
 
 func TestNewUnAuthUploadRequest(t *testing.T) {
-	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", ""}, testUserAgent, testAbsolutePath, true)
+	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", "", 0, 0, 0, "", "", "", "", ""}, testUserAgent, testAbsolutePath, true)
 	if err != nil {
 		t.Errorf("Unexpected Error: %v", err)
 	}
@@ -308,6 +377,59 @@ func TestCheckResponse(t *testing.T) {
 	}
 }
 
+func TestCheckResponse_v4Error(t *testing.T) {
+	res := &http.Response{
+		Request:    &http.Request{},
+		Status:     "409 Conflict",
+		StatusCode: http.StatusConflict,
+		Body: ioutil.NopCloser(strings.NewReader(
+			`{"data": {"error": [{"message": "image is being updated", "severity": "ERROR"}],
+				"$objectType": "vmm.v4.error.ErrorResponse"}}`)),
+	}
+	err := CheckResponse(res)
+
+	if err == nil {
+		t.Fatalf("Expected error response.")
+	}
+
+	if expected := "error: 409 Conflict: image is being updated"; err.Error() != expected {
+		t.Errorf("error = %q, expected %q", err, expected)
+	}
+}
+
+func TestNewChunkUploadRequest(t *testing.T) {
+	c, err := NewClient(&Credentials{"foo.com", "username", "password", "", "", true, false, "", "", "", nil, "", "", "", 0, 0, 0, "", "", "", "", ""}, testUserAgent, testAbsolutePath, true)
+	if err != nil {
+		t.Errorf("Unexpected Error: %v", err)
+	}
+
+	chunk := io.NewSectionReader(strings.NewReader("0123456789"), 2, 5)
+	req, err := c.NewChunkUploadRequest(context.TODO(), http.MethodPut, "/foo", chunk)
+	if err != nil {
+		t.Fatalf("NewChunkUploadRequest() errored out with error : %v", err.Error())
+	}
+
+	if req.ContentLength != 5 {
+		t.Errorf("NewChunkUploadRequest() ContentLength = %d, expected 5", req.ContentLength)
+	}
+	got, _ := ioutil.ReadAll(req.Body)
+	if string(got) != "23456" {
+		t.Errorf("NewChunkUploadRequest() Body = %s, expected 23456", got)
+	}
+
+	// the chunk is sent again from its start on retries
+	body, err := req.GetBody()
+	if err != nil {
+		t.Fatalf("GetBody() errored out with error : %v", err)
+	}
+	if got, _ := ioutil.ReadAll(body); string(got) != "23456" {
+		t.Errorf("GetBody() = %s, expected 23456", got)
+	}
+	if req.Header.Get("Content-Type") != octetStreamType {
+		t.Errorf("NewChunkUploadRequest() Content-Type = %s, expected %s", req.Header.Get("Content-Type"), octetStreamType)
+	}
+}
+
 func TestDo(t *testing.T) {
 	ctx := context.TODO()
 	mux, client, server := setup()
@@ -0,0 +1,218 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultRetryWaitMin is the backoff used before the first retry when none is configured
+	DefaultRetryWaitMin = 1 * time.Second
+	// DefaultRetryWaitMax caps the backoff between two retries when none is configured
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// idempotentMethods lists the HTTP verbs which can be safely replayed even if the
+// server may already have processed the failed attempt.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// shouldRetry decides whether a request should be attempted again based on the
+// outcome of the previous attempt.
+//
+// 429 and 503 responses mean the request was rejected before being processed, so
+// they are retried for every verb. 502, 504 and connection level failures are
+// ambiguous (the server might have acted on the request) and are only retried for
+// idempotent verbs.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		return idempotentMethods[req.Method] && isTransientError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotentMethods[req.Method]
+	}
+	return false
+}
+
+// isTransientError reports whether err is a network failure worth retrying
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// retryBackoff returns the time to wait before the given retry attempt (starting at 0).
+// A valid Retry-After header sent by the server takes precedence over the computed
+// exponential backoff, otherwise a random jitter is applied to spread out clients.
+func retryBackoff(minWait, maxWait time.Duration, attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	backoff := float64(minWait) * math.Pow(2, float64(attempt))
+	if backoff > float64(maxWait) || math.IsInf(backoff, 0) {
+		backoff = float64(maxWait)
+	}
+
+	// equal jitter: keep half of the backoff and randomize the other half
+	half := int64(backoff / 2)
+	if half <= 0 {
+		return time.Duration(backoff)
+	}
+	//nolint:gosec
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// parseRetryAfter parses both forms of the Retry-After header (delay-seconds and HTTP-date)
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// rewindBody resets the request body so that it can be sent again.
+// It returns false if the body cannot be replayed.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+	return true
+}
+
+// doWithRetry sends the request and retries it on transient failures as per the
+// retry settings present in the client credentials.
+func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return sendWithRetry(ctx, req, c.Credentials, c.client.Do)
+}
+
+// RetryTransport is an http.RoundTripper retrying the requests on transient failures as the
+// Client does, as per the retry settings present in Credentials. Request bodies which cannot
+// be replayed are buffered, up to maxReplayBodySize bytes, so that they can be sent again.
+type RetryTransport struct {
+	Transport   http.RoundTripper
+	Credentials *Credentials
+}
+
+// maxReplayBodySize bounds the request bodies buffered by RetryTransport, larger bodies are
+// sent once
+const maxReplayBodySize = 16 * 1024 * 1024
+
+// RoundTrip implements http.RoundTripper
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Credentials == nil || t.Credentials.MaxRetryAttempts <= 0 {
+		return t.Transport.RoundTrip(req)
+	}
+
+	// a RoundTripper must not modify the request it is given
+	req = req.Clone(req.Context())
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		body, err := io.ReadAll(io.LimitReader(req.Body, maxReplayBodySize+1))
+		if err != nil {
+			req.Body.Close()
+			return nil, err
+		}
+		if len(body) > maxReplayBodySize {
+			req.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.MultiReader(bytes.NewReader(body), req.Body), req.Body}
+		} else {
+			req.Body.Close()
+			req.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(body)), nil
+			}
+			req.Body, _ = req.GetBody()
+		}
+	}
+	return sendWithRetry(req.Context(), req, t.Credentials, t.Transport.RoundTrip)
+}
+
+// sendWithRetry sends the request with send and retries it on transient failures as per the
+// retry settings present in credentials.
+func sendWithRetry(ctx context.Context, req *http.Request, credentials *Credentials, send func(*http.Request) (*http.Response, error)) (*http.Response, error) {
+	maxRetries := 0
+	minWait, maxWait := DefaultRetryWaitMin, DefaultRetryWaitMax
+	if credentials != nil {
+		maxRetries = credentials.MaxRetryAttempts
+		if credentials.RetryWaitMin > 0 {
+			minWait = credentials.RetryWaitMin
+		}
+		if credentials.RetryWaitMax > 0 {
+			maxWait = credentials.RetryWaitMax
+		}
+	}
+	if maxWait < minWait {
+		maxWait = minWait
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := send(req)
+		if attempt >= maxRetries || !shouldRetry(req, resp, err) || !rewindBody(req) {
+			return resp, err
+		}
+
+		wait := retryBackoff(minWait, maxWait, attempt, resp)
+		if err != nil {
+			log.Printf("[WARN] %s %s failed: %s. Retrying in %s (attempt %d/%d)", req.Method, req.URL.Path, err, wait, attempt+1, maxRetries)
+		} else {
+			log.Printf("[WARN] %s %s returned %s. Retrying in %s (attempt %d/%d)", req.Method, req.URL.Path, resp.Status, wait, attempt+1, maxRetries)
+			// drain the body so that the underlying connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func setupRetry(maxRetryAttempts int) (*http.ServeMux, *Client, *httptest.Server) {
+	mux, client, server := setup()
+	client.Credentials.MaxRetryAttempts = maxRetryAttempts
+	client.Credentials.RetryWaitMin = time.Millisecond
+	client.Credentials.RetryWaitMax = 2 * time.Millisecond
+
+	return mux, client, server
+}
+
+func TestDo_retryTransientStatus(t *testing.T) {
+	ctx := context.TODO()
+	mux, client, server := setupRetry(3)
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	body := new(struct{ A string })
+	if err := client.Do(ctx, req, body); err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("server called %d times, expected %d", calls, 3)
+	}
+	if body.A != "a" {
+		t.Errorf("Response body = %v, expected %v", body.A, "a")
+	}
+}
+
+func TestDo_retryReplaysBody(t *testing.T) {
+	ctx := context.TODO()
+	mux, client, server := setupRetry(1)
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		b, _ := io.ReadAll(r.Body)
+		if string(b) != `{"name":"bar"}`+"\n" {
+			t.Errorf("attempt %d: request body = %q", calls, string(b))
+		}
+		if calls == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodPost, "/", map[string]interface{}{"name": "bar"})
+	if err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do(): %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("server called %d times, expected %d", calls, 2)
+	}
+}
+
+func TestDo_noRetryForNonIdempotentBadGateway(t *testing.T) {
+	ctx := context.TODO()
+	mux, client, server := setupRetry(3)
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message":"bad gateway"}`, http.StatusBadGateway)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodPost, "/", nil)
+	if err := client.Do(ctx, req, nil); err == nil {
+		t.Error("Expected HTTP 502 error.")
+	}
+	if calls != 1 {
+		t.Errorf("server called %d times, expected %d", calls, 1)
+	}
+}
+
+func TestDo_retriesExhausted(t *testing.T) {
+	ctx := context.TODO()
+	mux, client, server := setupRetry(2)
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"message":"gateway timeout"}`, http.StatusGatewayTimeout)
+	})
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	if err := client.Do(ctx, req, nil); err == nil {
+		t.Error("Expected HTTP 504 error.")
+	}
+	if calls != 3 {
+		t.Errorf("server called %d times, expected %d", calls, 3)
+	}
+}
+
+func TestDo_retryHonorsContext(t *testing.T) {
+	mux, client, server := setupRetry(5)
+	defer server.Close()
+	client.Credentials.RetryWaitMin = time.Minute
+	client.Credentials.RetryWaitMax = time.Minute
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "busy", http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, _ := client.NewRequest(ctx, http.MethodGet, "/", nil)
+	if err := client.Do(ctx, req, nil); err != context.DeadlineExceeded {
+		t.Errorf("error = %v, expected %v", err, context.DeadlineExceeded)
+	}
+}
+
+func Test_shouldRetry(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{"get 503", http.MethodGet, http.StatusServiceUnavailable, nil, true},
+		{"post 429", http.MethodPost, http.StatusTooManyRequests, nil, true},
+		{"put 502", http.MethodPut, http.StatusBadGateway, nil, true},
+		{"post 502", http.MethodPost, http.StatusBadGateway, nil, false},
+		{"get 500", http.MethodGet, http.StatusInternalServerError, nil, false},
+		{"get 404", http.MethodGet, http.StatusNotFound, nil, false},
+		{"get eof", http.MethodGet, 0, &url.Error{Op: "Get", Err: io.EOF}, true},
+		{"post eof", http.MethodPost, 0, &url.Error{Op: "Post", Err: io.EOF}, false},
+		{"get canceled", http.MethodGet, 0, context.Canceled, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			var resp *http.Response
+			if tt.err == nil {
+				resp = &http.Response{StatusCode: tt.status}
+			}
+			if got := shouldRetry(req, resp, tt.err); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_retryBackoff(t *testing.T) {
+	minWait, maxWait := 100*time.Millisecond, time.Second
+
+	for attempt := 0; attempt < 10; attempt++ {
+		got := retryBackoff(minWait, maxWait, attempt, nil)
+		if got <= 0 || got > maxWait {
+			t.Errorf("attempt %d: backoff %s out of range (0, %s]", attempt, got, maxWait)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"7"}}}
+	if got := retryBackoff(minWait, maxWait, 0, resp); got != 7*time.Second {
+		t.Errorf("backoff = %s, expected Retry-After value %s", got, 7*time.Second)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		status    int
+		wantCalls int
+	}{
+		{"get 502", http.MethodGet, http.StatusBadGateway, 2},
+		{"post 502", http.MethodPost, http.StatusBadGateway, 1},
+		{"post 504", http.MethodPost, http.StatusGatewayTimeout, 1},
+		{"post 503", http.MethodPost, http.StatusServiceUnavailable, 2},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if b, _ := io.ReadAll(r.Body); r.Method == http.MethodPost && string(b) != `{"name":"bar"}` {
+					t.Errorf("attempt %d: request body = %q", calls, string(b))
+				}
+				if calls == 1 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				fmt.Fprint(w, `{}`)
+			}))
+			defer server.Close()
+
+			httpClient := &http.Client{Transport: &RetryTransport{
+				Transport:   http.DefaultTransport,
+				Credentials: &Credentials{MaxRetryAttempts: 3, RetryWaitMin: time.Millisecond, RetryWaitMax: 2 * time.Millisecond},
+			}}
+			// the body of the requests relayed by a proxy cannot be replayed
+			body := io.NopCloser(strings.NewReader(`{"name":"bar"}`))
+			req, _ := http.NewRequest(tt.method, server.URL, body)
+			resp, err := httpClient.Do(req)
+			if err != nil {
+				t.Fatalf("Do(): %v", err)
+			}
+			resp.Body.Close()
+
+			if calls != tt.wantCalls {
+				t.Errorf("server called %d times, expected %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTLSTestServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}))
+}
+
+func serverCertPEM(server *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
+
+func doTLSTestRequest(credentials *Credentials, server *httptest.Server) error {
+	c, err := NewBaseClient(credentials, testAbsolutePath, false)
+	if err != nil {
+		return err
+	}
+	c.BaseURL, _ = url.Parse(server.URL)
+
+	req, err := c.NewRequest(context.TODO(), http.MethodGet, "/", nil)
+	if err != nil {
+		return err
+	}
+	return c.Do(context.TODO(), req, nil)
+}
+
+func TestCredentials_TLSConfig_customCA(t *testing.T) {
+	server := newTLSTestServer()
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(serverCertPEM(server)), 0o600); err != nil {
+		t.Fatalf("error writing CA file: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		credentials *Credentials
+		wantErr     bool
+	}{
+		{"untrusted certificate", &Credentials{Username: "username", Password: "password"}, true},
+		{"insecure", &Credentials{Username: "username", Password: "password", Insecure: true}, false},
+		{"ca_cert_pem", &Credentials{Username: "username", Password: "password", CACertPEM: serverCertPEM(server)}, false},
+		{"ca_cert_file", &Credentials{Username: "username", Password: "password", CACertFile: caFile}, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := doTLSTestRequest(tt.credentials, server)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCredentials_TLSConfig_invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		credentials *Credentials
+		wantErr     string
+	}{
+		{"invalid ca_cert_pem", &Credentials{CACertPEM: "not a certificate"}, "no valid PEM certificate"},
+		{"missing ca_cert_file", &Credentials{CACertFile: filepath.Join(t.TempDir(), "missing.pem")}, "error reading ca_cert_file"},
+		{"client cert without key", &Credentials{ClientCertFile: "cert.pem"}, "must be provided together"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.credentials.TLSConfig()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("TLSConfig() error = %v, expected %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@
 package common
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"hash/crc32"
@@ -12,10 +11,9 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
-	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v4/prism"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -144,25 +142,6 @@ func getRawConfigValueAtPath(root cty.Value, path string) (cty.Value, bool) {
 	return cur, true
 }
 
-func TaskStateRefreshPrismTaskGroupFunc(ctx context.Context, client *prism.Client, taskUUID string) resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		vresp, err := client.TaskRefAPI.GetTaskById(utils.StringPtr(taskUUID), nil)
-		if err != nil {
-			return "", "", fmt.Errorf("error while polling prism task: %v", err)
-		}
-
-		// get the group results
-
-		v := vresp.Data.GetValue().(prismConfig.Task)
-
-		if getTaskStatus(v.Status) == "CANCELED" || getTaskStatus(v.Status) == "FAILED" {
-			return v, getTaskStatus(v.Status),
-				fmt.Errorf("error_detail: %s, progress_message: %d", utils.StringValue(v.ErrorMessages[0].Message), utils.IntValue(v.ProgressPercentage))
-		}
-		return v, getTaskStatus(v.Status), nil
-	}
-}
-
 func getTaskStatus(taskStatus *prismConfig.TaskStatus) string {
 	return FlattenPtrEnum(taskStatus)
 }
@@ -185,6 +164,36 @@ func EnumToMap[T interface{ GetName() string }](enums []T) map[string]T {
 	return m
 }
 
+// sdkEnum is an enum generated in the v4 SDKs. The ordinals 0 and 1 are the $UNKNOWN and
+// $REDACTED placeholders, the values of the enum follow them.
+type sdkEnum interface {
+	~int
+	GetName() string
+}
+
+const (
+	unknownEnumName  = "$UNKNOWN"
+	redactedEnumName = "$REDACTED"
+)
+
+// EnumValues returns all the values of an SDK enum type, without the $UNKNOWN and $REDACTED
+// placeholders
+func EnumValues[T sdkEnum]() []T {
+	values := make([]T, 0)
+	for e := T(2); e.GetName() != unknownEnumName; e++ {
+		values = append(values, e)
+	}
+	return values
+}
+
+// ValidateEnum returns a schema validation func accepting the names of the values of an SDK
+// enum type, so that a misspelled enum fails at plan time with the list of allowed values
+// instead of being dropped from the request. It can be used on string attributes and on the
+// elements of lists of strings.
+func ValidateEnum[T sdkEnum]() schema.SchemaValidateFunc {
+	return validation.StringInSlice(EnumToStrings(EnumValues[T]()), false)
+}
+
 // ExpandEnum expands a single string value to an enum pointer.
 // It relies on the SDK-generated enum's UnmarshalJSON for correctness (preferred approach).
 func ExpandEnum[T any](val interface{}) *T {
@@ -201,7 +210,7 @@ func ExpandEnum[T any](val interface{}) *T {
 	if u, ok := any(&out).(interface{ UnmarshalJSON([]byte) error }); ok {
 		b, err := json.Marshal(str)
 		if err == nil {
-			if err := u.UnmarshalJSON(b); err == nil {
+			if err := u.UnmarshalJSON(b); err == nil && isKnownEnum(out) {
 				return &out
 			}
 		}
@@ -227,7 +236,7 @@ func ExpandEnumList[T any](val interface{}) []T {
 		var out T
 		if u, ok := any(&out).(interface{ UnmarshalJSON([]byte) error }); ok {
 			if b, err := json.Marshal(str); err == nil {
-				if err := u.UnmarshalJSON(b); err == nil {
+				if err := u.UnmarshalJSON(b); err == nil && isKnownEnum(out) {
 					list = append(list, out)
 					continue
 				}
@@ -243,6 +252,13 @@ func ExpandEnumList[T any](val interface{}) []T {
 	return list
 }
 
+// isKnownEnum reports whether an unmarshalled enum holds a value. The SDK enums unmarshal
+// names they do not know to $UNKNOWN rather than failing.
+func isKnownEnum(e any) bool {
+	named, ok := e.(interface{ GetName() string })
+	return !ok || (named.GetName() != unknownEnumName && named.GetName() != redactedEnumName)
+}
+
 // FlattenPtrEnum converts a pointer to an enum implementing GetName() to a string.
 // Returns "" if the pointer is nil.
 func FlattenPtrEnum[T interface{ GetName() string }](enumPtr *T) string {
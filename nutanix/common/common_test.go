@@ -0,0 +1,60 @@
+package common
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
+)
+
+func TestEnumValues(t *testing.T) {
+	got := EnumToStrings(EnumValues[config.BootDeviceType]())
+	expected := []string{"CDROM", "DISK", "NETWORK"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("EnumValues() = %v, expected %v", got, expected)
+	}
+}
+
+func TestValidateEnum(t *testing.T) {
+	validate := ValidateEnum[config.EmulatedNicModel]()
+
+	if _, errs := validate("VIRTIO", "model"); len(errs) > 0 {
+		t.Errorf("expected VIRTIO to be valid, got %v", errs)
+	}
+
+	for _, value := range []string{"VIRTIOO", "virtio", "$UNKNOWN", "$REDACTED"} {
+		_, errs := validate(value, "model")
+		if len(errs) != 1 {
+			t.Errorf("expected %s to be invalid", value)
+			continue
+		}
+		if msg := errs[0].Error(); !strings.Contains(msg, "[VIRTIO E1000]") {
+			t.Errorf("expected the allowed values in the error, got %s", msg)
+		}
+	}
+}
+
+func TestExpandEnum(t *testing.T) {
+	if got := ExpandEnum[config.EmulatedNicModel]("E1000"); got == nil || *got != config.EMULATEDNICMODEL_E1000 {
+		t.Errorf("ExpandEnum(E1000) = %v, expected E1000", got)
+	}
+	if got := ExpandEnum[config.EmulatedNicModel]("E100"); got != nil {
+		t.Errorf("ExpandEnum(E100) = %v, expected nil", got.GetName())
+	}
+
+	got := ExpandEnumList[config.BootDeviceType]([]interface{}{"DISK", "FLOPPY", "NETWORK"})
+	expected := []config.BootDeviceType{config.BOOTDEVICETYPE_DISK, config.BOOTDEVICETYPE_NETWORK}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ExpandEnumList() = %v, expected %v", got, expected)
+	}
+}
+
+func TestPowerCycleReasons(t *testing.T) {
+	changed := map[string]bool{"num_sockets": true, "cd_rom": true, "name": true}
+	got := PowerCycleReasons(func(key string) bool { return changed[key] }, []string{"num_sockets", "memory_size_bytes", "cd_rom"})
+	expected := []string{"num_sockets", "cd_rom"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("PowerCycleReasons() = %v, expected %v", got, expected)
+	}
+}
@@ -0,0 +1,120 @@
+package common
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// MaxPageLimit is the largest page size accepted by the v4 list APIs
+const MaxPageLimit = 100
+
+// ListAllPages calls a v4 list API and, if fetchAll is set, follows the pagination
+// until metadata.totalAvailableResults entities have been collected.
+//
+// The entities of all the pages are merged into the data of the first response, which
+// is returned, so callers can flatten it the same way as a single page response.
+// The limit is used as page size when set, otherwise the maximum page size is used.
+// page is ignored when fetchAll is set.
+func ListAllPages[R any](fetchAll bool, page, limit *int, list func(page, limit *int) (R, error)) (R, error) {
+	if !fetchAll {
+		return list(page, limit)
+	}
+
+	pageSize := MaxPageLimit
+	if limit != nil && *limit > 0 {
+		pageSize = *limit
+	}
+
+	first, err := list(utils.IntPtr(0), utils.IntPtr(pageSize))
+	if err != nil {
+		return first, err
+	}
+
+	items, total, err := pageItems(first)
+	if err != nil || !items.IsValid() {
+		return first, err
+	}
+
+	// without totalAvailableResults, a short page means the last page was reached
+	hasMore := func(pageItemsCount int) bool {
+		if total >= 0 {
+			return pageItemsCount > 0 && items.Len() < total
+		}
+		return pageItemsCount == pageSize
+	}
+
+	for p, pageItemsCount := 1, items.Len(); hasMore(pageItemsCount); p++ {
+		log.Printf("[DEBUG] fetching page %d (%d/%d entities fetched)", p, items.Len(), total)
+
+		resp, err := list(utils.IntPtr(p), utils.IntPtr(pageSize))
+		if err != nil {
+			return first, fmt.Errorf("error while fetching page %d: %w", p, err)
+		}
+		next, _, err := pageItems(resp)
+		if err != nil {
+			return first, err
+		}
+		if !next.IsValid() {
+			break
+		}
+		pageItemsCount = next.Len()
+		items = reflect.AppendSlice(items, next)
+	}
+
+	if err := setPageItems(first, items); err != nil {
+		return first, err
+	}
+	return first, nil
+}
+
+// pageItems returns the entities held by the Data of a v4 list response and the
+// total number of available results (-1 if the API did not return it).
+func pageItems(resp interface{}) (reflect.Value, int, error) {
+	total := -1
+	v := reflect.ValueOf(resp)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return reflect.Value{}, total, fmt.Errorf("unexpected list response %T", resp)
+	}
+	v = v.Elem()
+
+	if metadata := v.FieldByName("Metadata"); metadata.IsValid() && !metadata.IsNil() {
+		if count := metadata.Elem().FieldByName("TotalAvailableResults"); count.IsValid() && !count.IsNil() {
+			total = int(count.Elem().Int())
+		}
+	}
+
+	data := v.FieldByName("Data")
+	if !data.IsValid() {
+		return reflect.Value{}, total, fmt.Errorf("unexpected list response %T", resp)
+	}
+	if data.IsNil() {
+		return reflect.Value{}, total, nil
+	}
+
+	getValue := data.MethodByName("GetValue")
+	if !getValue.IsValid() {
+		return reflect.Value{}, total, fmt.Errorf("unexpected list response data %s", data.Type())
+	}
+	value := getValue.Call(nil)[0]
+	if value.IsNil() {
+		return reflect.Value{}, total, nil
+	}
+	items := value.Elem()
+	if items.Kind() != reflect.Slice {
+		return reflect.Value{}, total, fmt.Errorf("unexpected list response data %s", items.Type())
+	}
+	return items, total, nil
+}
+
+// setPageItems replaces the entities held by the Data of a v4 list response
+func setPageItems(resp interface{}, items reflect.Value) error {
+	data := reflect.ValueOf(resp).Elem().FieldByName("Data")
+	out := data.MethodByName("SetValue").Call([]reflect.Value{items})
+	if err, ok := out[0].Interface().(error); ok && err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	import2 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/common/v1/response"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// fakeListSubnets serves total subnets, limit by limit, and records the requested pages
+func fakeListSubnets(total int, withTotal bool, pages *[]int) func(page, limit *int) (*import1.ListSubnetsApiResponse, error) {
+	return func(page, limit *int) (*import1.ListSubnetsApiResponse, error) {
+		*pages = append(*pages, *page)
+
+		subnets := make([]import1.Subnet, 0)
+		for i := *page * *limit; i < total && i < (*page+1)**limit; i++ {
+			subnets = append(subnets, import1.Subnet{Name: utils.StringPtr(fmt.Sprintf("subnet-%d", i))})
+		}
+
+		resp := import1.NewListSubnetsApiResponse()
+		resp.Metadata = import2.NewApiResponseMetadata()
+		if withTotal {
+			resp.Metadata.TotalAvailableResults = utils.IntPtr(total)
+		}
+		if len(subnets) > 0 {
+			resp.Data = import1.NewOneOfListSubnetsApiResponseData()
+			if err := resp.Data.SetValue(subnets); err != nil {
+				return nil, err
+			}
+		}
+		return resp, nil
+	}
+}
+
+func TestListAllPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		fetchAll  bool
+		limit     *int
+		total     int
+		withTotal bool
+		wantPages []int
+		wantCount int
+	}{
+		{"single page", false, utils.IntPtr(10), 25, true, []int{1}, 10},
+		{"all pages", true, utils.IntPtr(10), 25, true, []int{0, 1, 2}, 25},
+		{"exact pages", true, utils.IntPtr(10), 20, true, []int{0, 1}, 20},
+		{"default page size", true, nil, 250, true, []int{0, 1, 2}, 250},
+		{"without total", true, utils.IntPtr(10), 20, false, []int{0, 1, 2}, 20},
+		{"no results", true, utils.IntPtr(10), 0, true, []int{0}, 0},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pages := make([]int, 0)
+			resp, err := ListAllPages(tt.fetchAll, utils.IntPtr(1), tt.limit, fakeListSubnets(tt.total, tt.withTotal, &pages))
+			if err != nil {
+				t.Fatalf("ListAllPages() error = %v", err)
+			}
+
+			if fmt.Sprint(pages) != fmt.Sprint(tt.wantPages) {
+				t.Errorf("requested pages = %v, want %v", pages, tt.wantPages)
+			}
+
+			count := 0
+			if resp.Data != nil {
+				subnets := resp.Data.GetValue().([]import1.Subnet)
+				count = len(subnets)
+				first := pages[0] * len(subnets)
+				if tt.fetchAll {
+					first = 0
+				}
+				for i, subnet := range subnets {
+					if *subnet.Name != fmt.Sprintf("subnet-%d", first+i) {
+						t.Errorf("subnet %d = %s", i, *subnet.Name)
+						break
+					}
+				}
+			}
+			if count != tt.wantCount {
+				t.Errorf("got %d subnets, want %d", count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestListAllPages_error(t *testing.T) {
+	pages := make([]int, 0)
+	list := fakeListSubnets(25, true, &pages)
+
+	_, err := ListAllPages(true, nil, utils.IntPtr(10), func(page, limit *int) (*import1.ListSubnetsApiResponse, error) {
+		if *page == 1 {
+			return nil, errors.New("boom")
+		}
+		return list(page, limit)
+	})
+	if err == nil {
+		t.Fatal("expected an error when a page fails")
+	}
+}
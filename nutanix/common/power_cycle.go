@@ -0,0 +1,50 @@
+package common
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// power_cycle_policy values, what the update does with a running VM when a change needs it powered off
+const (
+	PowerCyclePolicyAllow                  = "allow"
+	PowerCyclePolicyDeny                   = "deny"
+	PowerCyclePolicyGuestShutdownThenAllow = "guest_shutdown_then_allow"
+)
+
+// PowerCyclePolicySchema returns the schema of the power_cycle_policy attribute of the VM resources
+func PowerCyclePolicySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  PowerCyclePolicyAllow,
+		ValidateFunc: validation.StringInSlice([]string{
+			PowerCyclePolicyAllow, PowerCyclePolicyDeny, PowerCyclePolicyGuestShutdownThenAllow,
+		}, false),
+	}
+}
+
+// PendingPowerCycleReasonsSchema returns the schema of the pending_power_cycle_reasons attribute,
+// the changed attributes which power cycle the VM, of the VM resources
+func PendingPowerCycleReasonsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// PowerCycleReasons lists the attributes, among the ones which can not be changed while the VM
+// is running, which are changed. It takes the HasChange of either a schema.ResourceData or a
+// schema.ResourceDiff.
+func PowerCycleReasons(hasChange func(string) bool, attributes []string) []string {
+	reasons := make([]string, 0)
+	for _, attr := range attributes {
+		if hasChange(attr) {
+			reasons = append(reasons, attr)
+		}
+	}
+	return reasons
+}
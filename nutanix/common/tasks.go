@@ -0,0 +1,213 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
+	prismError "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/error"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v4/prism"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// TaskResult is a v4 prism task which completed successfully
+type TaskResult struct {
+	prismConfig.Task
+}
+
+// EntityExtID returns the extId of the first entity affected by the task with the given relation,
+// e.g. utils.RelEntityTypeVM
+func (t *TaskResult) EntityExtID(rel string) (string, error) {
+	extIDs := t.EntityExtIDs(rel)
+	if len(extIDs) == 0 {
+		return "", fmt.Errorf("no %s entity found in the entities affected by task %s", rel, utils.StringValue(t.ExtId))
+	}
+	return extIDs[0], nil
+}
+
+// EntityExtIDs returns the extIds of all the entities affected by the task with the given relation
+func (t *TaskResult) EntityExtIDs(rel string) []string {
+	extIDs := make([]string, 0)
+	for _, entity := range t.EntitiesAffected {
+		if utils.StringValue(entity.Rel) == rel && entity.ExtId != nil {
+			extIDs = append(extIDs, *entity.ExtId)
+		}
+	}
+	return extIDs
+}
+
+// CompletionDetails returns the values of the task completion details with the given name
+func (t *TaskResult) CompletionDetails(name string) []string {
+	return ExtractCompletionDetailsFromTask(t.Task, name)
+}
+
+// TaskError is returned when a v4 prism task fails or is canceled, or when the wait for the task times out
+type TaskError struct {
+	ExtID     string
+	Operation string
+	Status    string
+	Progress  int
+	// Messages are the error messages of the task and of its failed sub-tasks
+	Messages []string
+	// TimedOut is set when the task was still running once the timeout was reached, Status and Progress
+	// are then the last ones polled
+	TimedOut bool
+}
+
+func (e *TaskError) Error() string {
+	if e.TimedOut {
+		return fmt.Sprintf("timeout while waiting for task %s (%s), it is still running on Prism Central: last polled %s at %d%%",
+			e.ExtID, e.Operation, strings.ToLower(e.Status), e.Progress)
+	}
+	msg := fmt.Sprintf("task %s (%s) %s at %d%%", e.ExtID, e.Operation, strings.ToLower(e.Status), e.Progress)
+	if len(e.Messages) > 0 {
+		msg += ": " + strings.Join(e.Messages, "; ")
+	}
+	return msg
+}
+
+// WaitForTask polls a v4 prism task until it succeeds and returns it. The task progress is logged
+// as it changes. A TaskError is returned if the task fails or is canceled, or if it is still running
+// once the timeout or the ctx deadline is reached, with the last status and progress polled.
+//
+// If ctx is canceled while waiting, e.g. when Terraform is interrupted, the task is canceled on
+// Prism Central so that no operation keeps running behind the user's back. Reaching the timeout or
+// the ctx deadline only stops waiting, the task keeps running on Prism Central.
+func WaitForTask(ctx context.Context, client *prism.Client, taskExtID string, timeout time.Duration) (*TaskResult, error) {
+	// the last poll is stored by the refresh goroutine, which may still be running once the ctx deadline is reached
+	var last atomic.Value
+	last.Store(TaskError{ExtID: taskExtID, Status: "PENDING"})
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
+		Target:  []string{"SUCCEEDED"},
+		Refresh: taskProgressRefreshFunc(client, taskExtID, &last),
+		Timeout: timeout,
+	}
+
+	task, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			cancelTask(client, taskExtID)
+			return nil, err
+		}
+		var timeoutErr *resource.TimeoutError
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) || errors.As(err, &timeoutErr) {
+			timeoutErr := last.Load().(TaskError)
+			timeoutErr.TimedOut = true
+			return nil, &timeoutErr
+		}
+		return nil, err
+	}
+
+	result := &TaskResult{Task: *task.(*prismConfig.Task)}
+	for _, w := range result.Task.Warnings {
+		log.Printf("[WARN] task %s (%s): %s", taskExtID, utils.StringValue(result.Operation), utils.StringValue(w.Message))
+	}
+	return result, nil
+}
+
+// taskProgressRefreshFunc is the refresh function of WaitForTask, logging the progress of the task. The
+// operation, status and progress of the last poll are stored in last, as a TaskError.
+func taskProgressRefreshFunc(client *prism.Client, taskExtID string, last *atomic.Value) resource.StateRefreshFunc {
+	lastProgress := -1
+	return func() (interface{}, string, error) {
+		task, err := getTask(client, taskExtID)
+		if err != nil {
+			if lastProgress >= 0 {
+				polled := last.Load().(TaskError)
+				return nil, "", fmt.Errorf("%w, last polled %s at %d%%", err, strings.ToLower(polled.Status), polled.Progress)
+			}
+			return nil, "", err
+		}
+
+		status := getTaskStatus(task.Status)
+		progress := utils.IntValue(task.ProgressPercentage)
+		last.Store(TaskError{ExtID: taskExtID, Operation: utils.StringValue(task.Operation), Status: status, Progress: progress})
+		if progress != lastProgress {
+			log.Printf("[INFO] task %s (%s): %s %d%%", taskExtID, utils.StringValue(task.Operation), status, progress)
+			lastProgress = progress
+		}
+
+		if status == "CANCELED" || status == "FAILED" {
+			return task, status, newTaskError(client, task)
+		}
+		return task, status, nil
+	}
+}
+
+// cancelTask requests the cancellation of a task, on a best effort basis
+func cancelTask(client *prism.Client, taskExtID string) {
+	log.Printf("[WARN] interrupted while waiting for task %s, canceling it", taskExtID)
+	if _, err := client.TaskRefAPI.CancelTask(utils.StringPtr(taskExtID)); err != nil {
+		log.Printf("[WARN] unable to cancel task %s: %v", taskExtID, err)
+	}
+}
+
+func getTask(client *prism.Client, taskExtID string) (*prismConfig.Task, error) {
+	resp, err := client.TaskRefAPI.GetTaskById(utils.StringPtr(taskExtID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error while polling prism task %s: %v", taskExtID, err)
+	}
+	if resp == nil || resp.Data == nil {
+		return nil, fmt.Errorf("error while polling prism task %s: empty response", taskExtID)
+	}
+	task, ok := resp.Data.GetValue().(prismConfig.Task)
+	if !ok {
+		return nil, fmt.Errorf("error while polling prism task %s: unexpected response %T", taskExtID, resp.Data.GetValue())
+	}
+	return &task, nil
+}
+
+// newTaskError builds the error of a failed or canceled task, including the errors of its failed sub-tasks
+func newTaskError(client *prism.Client, task *prismConfig.Task) *TaskError {
+	taskErr := &TaskError{
+		ExtID:     utils.StringValue(task.ExtId),
+		Operation: utils.StringValue(task.Operation),
+		Status:    getTaskStatus(task.Status),
+		Progress:  utils.IntValue(task.ProgressPercentage),
+		Messages:  taskErrorMessages(task),
+	}
+
+	for _, subTask := range task.SubTasks {
+		if subTask.ExtId == nil {
+			continue
+		}
+		sub, err := getTask(client, *subTask.ExtId)
+		if err != nil {
+			log.Printf("[DEBUG] unable to fetch sub-task %s: %v", *subTask.ExtId, err)
+			continue
+		}
+		if status := getTaskStatus(sub.Status); status != "FAILED" && status != "CANCELED" {
+			continue
+		}
+		for _, msg := range taskErrorMessages(sub) {
+			taskErr.Messages = append(taskErr.Messages, fmt.Sprintf("sub-task %s: %s", utils.StringValue(sub.Operation), msg))
+		}
+	}
+	return taskErr
+}
+
+// taskErrorMessages returns the error messages of a task, falling back to the legacy error message
+func taskErrorMessages(task *prismConfig.Task) []string {
+	messages := make([]string, 0, len(task.ErrorMessages))
+	for _, m := range task.ErrorMessages {
+		messages = append(messages, appMessage(m))
+	}
+	if len(messages) == 0 && utils.StringValue(task.LegacyErrorMessage) != "" {
+		messages = append(messages, utils.StringValue(task.LegacyErrorMessage))
+	}
+	return messages
+}
+
+func appMessage(m prismError.AppMessage) string {
+	if code := utils.StringValue(m.Code); code != "" {
+		return fmt.Sprintf("%s (%s)", utils.StringValue(m.Message), code)
+	}
+	return utils.StringValue(m.Message)
+}
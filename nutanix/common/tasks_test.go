@@ -0,0 +1,165 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
+	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func TestWaitForTask(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.TaskPolls = 2
+
+	client, err := pc.Client()
+	if err != nil {
+		t.Fatalf("Client(): %v", err)
+	}
+
+	resp, err := client.NetworkingAPI.SubnetAPIInstance.CreateSubnet(&import1.Subnet{Name: utils.StringPtr("subnet")})
+	if err != nil {
+		t.Fatalf("CreateSubnet(): %v", err)
+	}
+	taskRef := resp.Data.GetValue().(import4.TaskReference)
+
+	task, err := WaitForTask(context.Background(), client.PrismAPI, utils.StringValue(taskRef.ExtId), time.Minute)
+	if err != nil {
+		t.Fatalf("WaitForTask(): %v", err)
+	}
+
+	extID, err := task.EntityExtID(utils.RelEntityTypeSubnet)
+	if err != nil {
+		t.Fatalf("EntityExtID(): %v", err)
+	}
+	if _, ok := pc.Get("networking/config/subnets", extID); !ok {
+		t.Errorf("subnet %s returned by the task does not exist", extID)
+	}
+	if _, err := task.EntityExtID(utils.RelEntityTypeVM); err == nil {
+		t.Error("expected an error for an entity not affected by the task")
+	}
+}
+
+func TestWaitForTask_failure(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	client, err := pc.Client()
+	if err != nil {
+		t.Fatalf("Client(): %v", err)
+	}
+
+	subTask := pc.AddTask(mockpc.Task{Operation: "kDiskCreate", ErrorMessage: "not enough space"})
+	okSubTask := pc.AddTask(mockpc.Task{Operation: "kNicCreate"})
+	taskExtID := pc.AddTask(mockpc.Task{
+		Operation:          "kVmCreate",
+		LegacyErrorMessage: "vm creation failed",
+		SubTasks:           []string{subTask, okSubTask},
+	})
+
+	_, err = WaitForTask(context.Background(), client.PrismAPI, taskExtID, time.Minute)
+
+	var taskErr *TaskError
+	if !errors.As(err, &taskErr) {
+		t.Fatalf("WaitForTask() error = %v, expected a TaskError", err)
+	}
+	expected := []string{"vm creation failed", "sub-task kDiskCreate: not enough space"}
+	if !reflect.DeepEqual(taskErr.Messages, expected) {
+		t.Errorf("error messages = %v, expected %v", taskErr.Messages, expected)
+	}
+	if taskErr.Status != "FAILED" || taskErr.Operation != "kVmCreate" || taskErr.Progress != 100 {
+		t.Errorf("unexpected task error %v", taskErr)
+	}
+	if !strings.Contains(err.Error(), "failed at 100%") {
+		t.Errorf("WaitForTask() error = %v, expected the status and progress of the task", err)
+	}
+}
+
+func TestWaitForTask_cancel(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.TaskPolls = 1000
+
+	client, err := pc.Client()
+	if err != nil {
+		t.Fatalf("Client(): %v", err)
+	}
+	taskExtID := pc.AddTask(mockpc.Task{Operation: "kVmCreate"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(500*time.Millisecond, cancel)
+
+	if _, err := WaitForTask(ctx, client.PrismAPI, taskExtID, time.Minute); err == nil {
+		t.Fatal("expected an error when the context is canceled")
+	}
+	if tasks := pc.Tasks(); len(tasks) != 1 || !tasks[0].Canceled {
+		t.Errorf("task was not canceled on Prism Central: %v", tasks)
+	}
+}
+
+func TestWaitForTask_deadline(t *testing.T) {
+	tests := []struct {
+		name     string
+		deadline time.Duration
+		timeout  time.Duration
+	}{
+		{"ctx deadline", 500 * time.Millisecond, time.Minute},
+		{"timeout", time.Minute, 500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			pc := mockpc.NewServer()
+			defer pc.Close()
+			// a few percents of progress are polled before the timeout
+			pc.TaskPolls = 50
+
+			client, err := pc.Client()
+			if err != nil {
+				t.Fatalf("Client(): %v", err)
+			}
+			taskExtID := pc.AddTask(mockpc.Task{Operation: "kVmCreate"})
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.deadline)
+			defer cancel()
+
+			_, err = WaitForTask(ctx, client.PrismAPI, taskExtID, tt.timeout)
+			var taskErr *TaskError
+			if !errors.As(err, &taskErr) || !taskErr.TimedOut {
+				t.Fatalf("WaitForTask() error = %v, expected a timeout", err)
+			}
+			if taskErr.Status != "RUNNING" || taskErr.Operation != "kVmCreate" || taskErr.Progress == 0 {
+				t.Errorf("unexpected task error %+v, expected the last status and progress polled", taskErr)
+			}
+			if !strings.Contains(err.Error(), "timeout") || !strings.Contains(err.Error(), "running at") {
+				t.Errorf("WaitForTask() error = %v, expected the last status and progress polled", err)
+			}
+			if tasks := pc.Tasks(); len(tasks) != 1 || tasks[0].Canceled {
+				t.Errorf("task should keep running on Prism Central after a timeout: %v", tasks)
+			}
+		})
+	}
+}
+
+func Test_taskErrorMessages(t *testing.T) {
+	failed := prismConfig.TASKSTATUS_FAILED
+
+	// failed tasks do not always carry error messages
+	if messages := taskErrorMessages(&prismConfig.Task{Status: &failed}); len(messages) != 0 {
+		t.Errorf("messages = %v, expected none", messages)
+	}
+
+	messages := taskErrorMessages(&prismConfig.Task{Status: &failed, LegacyErrorMessage: utils.StringPtr("legacy")})
+	if !reflect.DeepEqual(messages, []string{"legacy"}) {
+		t.Errorf("messages = %v, expected the legacy error message", messages)
+	}
+}
@@ -2,6 +2,7 @@ package nutanix
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
 	era "github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v3/era"
@@ -43,6 +44,14 @@ type Config struct {
 	NdbEndpoint        string
 	NdbUsername        string
 	NdbPassword        string
+	MaxRetryAttempts   int    // MaxRetryAttempts is the number of retries for requests failing with a transient error
+	RetryWaitMin       int    // RetryWaitMin is the minimum backoff between two retries in seconds
+	RetryWaitMax       int    // RetryWaitMax is the maximum backoff between two retries in seconds
+	APIKey             string // APIKey authenticates Prism Central requests instead of username & password
+	CACertFile         string // CACertFile is a PEM file with CA certificates to trust
+	CACertPEM          string // CACertPEM contains PEM encoded CA certificates to trust
+	ClientCertFile     string // ClientCertFile is a PEM file with the client certificate used for mTLS
+	ClientKeyFile      string // ClientKeyFile is a PEM file with the private key of the client certificate
 }
 
 // Client ...
@@ -62,6 +71,14 @@ func (c *Config) Client() (*Client, error) {
 		NdbUsername:        c.NdbUsername,
 		NdbPassword:        c.NdbPassword,
 		RequiredFields:     c.RequiredFields,
+		MaxRetryAttempts:   c.MaxRetryAttempts,
+		RetryWaitMin:       time.Duration(c.RetryWaitMin) * time.Second,
+		RetryWaitMax:       time.Duration(c.RetryWaitMax) * time.Second,
+		APIKey:             c.APIKey,
+		CACertFile:         c.CACertFile,
+		CACertPEM:          c.CACertPEM,
+		ClientCertFile:     c.ClientCertFile,
+		ClientKeyFile:      c.ClientKeyFile,
 	}
 
 	v3Client, err := v3.NewV3Client(configCreds)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/internal"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/clusters"
@@ -43,6 +44,12 @@ var requiredProviderFields map[string][]string = map[string][]string{
 	"ndb":                {"ndb_endpoint", "ndb_username", "ndb_password"},
 }
 
+// alternativeProviderFields maps a required provider field to the fields which can be given instead of it
+var alternativeProviderFields = map[string][]string{
+	"username": {"api_key"},
+	"password": {"api_key"},
+}
+
 // Provider function returns the object that implements the terraform.ResourceProvider interface, specifically a schema.Provider
 func Provider() *schema.Provider {
 	// defines descriptions for ResourceProvider schema definitions
@@ -52,9 +59,23 @@ func Provider() *schema.Provider {
 
 		"password": "Password for provided user name.",
 
+		"api_key": "API key for Nutanix Prism Central, sent in the `X-ntnx-api-key` header.\n" +
+			"Can be used instead of `username` and `password`, e.g. with a service account key.",
+
 		"insecure": "Explicitly allow the provider to perform \"insecure\" SSL requests. If omitted," +
 			"default value is `false`",
 
+		"ca_cert_file": "Path to a PEM file with CA certificates used to verify the server certificates, " +
+			"in addition to the system ones.",
+
+		"ca_cert_pem": "PEM encoded CA certificates used to verify the server certificates, " +
+			"in addition to the system ones.",
+
+		"client_cert_file": "Path to a PEM file with the client certificate presented to the server (mTLS). " +
+			"Requires `client_key_file`.",
+
+		"client_key_file": "Path to a PEM file with the private key of `client_cert_file`.",
+
 		"session_auth": "Use session authentification instead of basic auth for each request",
 
 		"port": "Port for Nutanix Prism.",
@@ -71,6 +92,15 @@ func Provider() *schema.Provider {
 		"foundation_port": "Port for foundation VM",
 
 		"ndb_endpoint": "endpoint for Era VM (era ip)",
+
+		"max_retry_attempts": "Maximum number of retries for API requests failing with a transient error. " +
+			"The retried responses of the v4 APIs are decided by the Nutanix v4 SDKs. Set to `0` to disable retries.",
+
+		"retry_wait_min": "Minimum time in seconds to wait before retrying a failed API request. " +
+			"The wait grows exponentially, with jitter, up to `retry_wait_max`.",
+
+		"retry_wait_max": "Maximum time in seconds to wait between two retries of a failed API request. " +
+			"A `Retry-After` header sent by the server takes precedence.",
 	}
 
 	// Nutanix provider schema
@@ -88,12 +118,43 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("NUTANIX_PASSWORD", nil),
 				Description: descriptions["password"],
 			},
+			"api_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("NUTANIX_API_KEY", nil),
+				Description: descriptions["api_key"],
+			},
 			"insecure": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NUTANIX_INSECURE", false),
 				Description: descriptions["insecure"],
 			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NUTANIX_CA_CERT_FILE", nil),
+				Description: descriptions["ca_cert_file"],
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NUTANIX_CA_CERT_PEM", nil),
+				Description: descriptions["ca_cert_pem"],
+			},
+			"client_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NUTANIX_CLIENT_CERT_FILE", nil),
+				Description: descriptions["client_cert_file"],
+			},
+			"client_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NUTANIX_CLIENT_KEY_FILE", nil),
+				Description: descriptions["client_key_file"],
+			},
 			"session_auth": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -156,6 +217,27 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("NDB_PASSWORD", nil),
 				Description: descriptions["ndb_password"],
 			},
+			"max_retry_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NUTANIX_MAX_RETRY_ATTEMPTS", 5),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  descriptions["max_retry_attempts"],
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NUTANIX_RETRY_WAIT_MIN", 1),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  descriptions["retry_wait_min"],
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("NUTANIX_RETRY_WAIT_MAX", 30),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  descriptions["retry_wait_max"],
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"nutanix_image":                                   vmm.DataSourceNutanixImage(),
@@ -258,6 +340,15 @@ func Provider() *schema.Provider {
 			"nutanix_service_groups_v2":                       networkingv2.DatasourceNutanixServiceGroupsV2(),
 			"nutanix_address_group_v2":                        networkingv2.DatasourceNutanixAddressGroupV2(),
 			"nutanix_address_groups_v2":                       networkingv2.DatasourceNutanixAddressGroupsV2(),
+			"nutanix_vpn_gateway_v2":                          networkingv2.DataSourceNutanixVpnGatewayV2(),
+			"nutanix_vpn_gateways_v2":                         networkingv2.DataSourceNutanixVpnGatewaysV2(),
+			"nutanix_vpn_connection_v2":                       networkingv2.DataSourceNutanixVpnConnectionV2(),
+			"nutanix_vpn_connections_v2":                      networkingv2.DataSourceNutanixVpnConnectionsV2(),
+			"nutanix_bgp_route_v2":                            networkingv2.DataSourceNutanixBgpRouteV2(),
+			"nutanix_bgp_routes_v2":                           networkingv2.DataSourceNutanixBgpRoutesV2(),
+			"nutanix_layer2_stretch_related_entities_v2":      networkingv2.DataSourceNutanixLayer2StretchRelatedEntitiesV2(),
+			"nutanix_virtual_switch_v2":                       networkingv2.DataSourceNutanixVirtualSwitchV2(),
+			"nutanix_virtual_switches_v2":                     networkingv2.DataSourceNutanixVirtualSwitchesV2(),
 			"nutanix_directory_service_v2":                    iamv2.DatasourceNutanixDirectoryServiceV2(),
 			"nutanix_directory_services_v2":                   iamv2.DatasourceNutanixDirectoryServicesV2(),
 			"nutanix_saml_identity_provider_v2":               iamv2.DatasourceNutanixSamlIDPV2(),
@@ -312,6 +403,9 @@ func Provider() *schema.Provider {
 			"nutanix_ngt_configuration_v2":                    vmmv2.DatasourceNutanixNGTConfigurationV4(),
 			"nutanix_image_placement_policy_v2":               vmmv2.DatasourceNutanixImagePlacementV4(),
 			"nutanix_image_placement_policies_v2":             vmmv2.DatasourceNutanixImagePlacementsV4(),
+			"nutanix_vm_stats_v2":                             vmmv2.DatasourceNutanixVMStatsV2(),
+			"nutanix_vm_disk_stats_v2":                        vmmv2.DatasourceNutanixVMDiskStatsV2(),
+			"nutanix_virtual_machines_esxi_v2":                vmmv2.DatasourceNutanixVirtualMachinesEsxiV2(),
 			"nutanix_cluster_v2":                              clustersv2.DatasourceNutanixClusterEntityV2(),
 			"nutanix_clusters_v2":                             clustersv2.DatasourceNutanixClusterEntitiesV2(),
 			"nutanix_system_user_passwords_v2":                passwordmanagerv2.DataSourceNutanixPasswordManagersV2(),
@@ -397,6 +491,13 @@ func Provider() *schema.Provider {
 			"nutanix_pbr_v2":                                  networkingv2.ResourceNutanixPbrsV2(),
 			"nutanix_service_groups_v2":                       networkingv2.ResourceNutanixServiceGroupsV2(),
 			"nutanix_address_groups_v2":                       networkingv2.ResourceNutanixAddressGroupsV2(),
+			"nutanix_vpn_gateway_v2":                          networkingv2.ResourceNutanixVpnGatewayV2(),
+			"nutanix_vpn_connection_v2":                       networkingv2.ResourceNutanixVpnConnectionV2(),
+			"nutanix_bgp_gateway_v2":                          networkingv2.ResourceNutanixBgpGatewayV2(),
+			"nutanix_bgp_session_v2":                          networkingv2.ResourceNutanixBgpSessionV2(),
+			"nutanix_layer2_stretch_v2":                       networkingv2.ResourceNutanixLayer2StretchV2(),
+			"nutanix_virtual_switch_v2":                       networkingv2.ResourceNutanixVirtualSwitchV2(),
+			"nutanix_traffic_mirror_v2":                       networkingv2.ResourceNutanixTrafficMirrorV2(),
 			"nutanix_directory_services_v2":                   iamv2.ResourceNutanixDirectoryServicesV2(),
 			"nutanix_user_groups_v2":                          iamv2.ResourceNutanixUserGroupsV2(),
 			"nutanix_roles_v2":                                iamv2.ResourceNutanixRolesV2(),
@@ -431,9 +532,14 @@ func Provider() *schema.Provider {
 			"nutanix_vm_cdrom_insert_eject_v2":                vmmv2.ResourceNutanixVmsCdRomsInsertEjectV2(),
 			"nutanix_deploy_templates_v2":                     vmmv2.ResourceNutanixTemplateDeployV2(),
 			"nutanix_template_v2":                             vmmv2.ResourceNutanixTemplatesV2(),
+			"nutanix_template_version_v2":                     vmmv2.ResourceNutanixTemplateVersionV2(),
 			"nutanix_template_guest_os_actions_v2":            vmmv2.ResourceNutanixTemplateActionsV2(),
 			"nutanix_ngt_installation_v2":                     vmmv2.ResourceNutanixNGTInstallationV2(),
 			"nutanix_ngt_upgrade_v2":                          vmmv2.ResourceNutanixNGTUpgradeV2(),
+			"nutanix_vm_power_action_esxi_v2":                 vmmv2.ResourceNutanixVMPowerActionEsxiV2(),
+			"nutanix_ngt_installation_esxi_v2":                vmmv2.ResourceNutanixNGTInstallationEsxiV2(),
+			"nutanix_ngt_upgrade_esxi_v2":                     vmmv2.ResourceNutanixNGTUpgradeEsxiV2(),
+			"nutanix_vm_categories_esxi_v2":                   vmmv2.ResourceNutanixVMCategoriesEsxiV2(),
 			"nutanix_ngt_insert_iso_v2":                       vmmv2.ResourceNutanixNGTInsertIsoV2(),
 			"nutanix_vm_clone_v2":                             vmmv2.ResourceNutanixVMCloneV2(),
 			"nutanix_vm_gc_update_v2":                         vmmv2.ResourceNutanixVMGCUpdateV2(),
@@ -474,8 +580,7 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	for k, v := range requiredProviderFields {
 		// check if any field is not provided
 		for _, attr := range v {
-			// for string fields
-			if _, ok := d.GetOk(attr); !ok {
+			if !isProviderFieldSet(d, attr) {
 				disabledProviders = append(disabledProviders, k)
 				break
 			}
@@ -504,6 +609,14 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 		NdbUsername:        d.Get("ndb_username").(string),
 		NdbPassword:        d.Get("ndb_password").(string),
 		RequiredFields:     requiredProviderFields,
+		MaxRetryAttempts:   d.Get("max_retry_attempts").(int),
+		RetryWaitMin:       d.Get("retry_wait_min").(int),
+		RetryWaitMax:       d.Get("retry_wait_max").(int),
+		APIKey:             d.Get("api_key").(string),
+		CACertFile:         d.Get("ca_cert_file").(string),
+		CACertPEM:          d.Get("ca_cert_pem").(string),
+		ClientCertFile:     d.Get("client_cert_file").(string),
+		ClientKeyFile:      d.Get("client_key_file").(string),
 	}
 	c, err := config.Client()
 	if err != nil {
@@ -512,3 +625,17 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 
 	return c, diags
 }
+
+// isProviderFieldSet checks if a required provider field, or one of its alternatives, is given
+func isProviderFieldSet(d *schema.ResourceData, attr string) bool {
+	// for string fields
+	if _, ok := d.GetOk(attr); ok {
+		return true
+	}
+	for _, alternative := range alternativeProviderFields[attr] {
+		if _, ok := d.GetOk(alternative); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -26,6 +26,8 @@ func NewEraClient(credentials client.Credentials) (*Client, error) {
 		credentials.URL = fmt.Sprintf("%s", credentials.NdbEndpoint)
 		credentials.Password = credentials.NdbPassword
 		credentials.Username = credentials.NdbUsername
+		// NDB only supports basic auth, Prism Central api key must not be sent to it
+		credentials.APIKey = ""
 
 		c, err := client.NewBaseClient(&credentials, absolutePath, false)
 		if err != nil {
@@ -25,7 +25,7 @@ func NewFoundationCentralClient(credentials client.Credentials) (*Client, error)
 	var baseClient *client.Client
 
 	// check if all required fields are present. Else create an empty client
-	if credentials.Username != "" && credentials.Password != "" && credentials.Endpoint != "" {
+	if credentials.HasAuth() && credentials.Endpoint != "" {
 		c, err := client.NewClient(&credentials, userAgent, absolutePath, false)
 		if err != nil {
 			return nil, err
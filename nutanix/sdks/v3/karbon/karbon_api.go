@@ -1,8 +1,10 @@
 package karbon
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
 )
@@ -11,6 +13,8 @@ const (
 	absolutePath = "karbon"
 	userAgent    = "nutanix"
 	clientName   = "karbon"
+	// defaultCallTimeout bounds the Karbon API calls made with a context without deadline
+	defaultCallTimeout = 5 * time.Minute
 )
 
 // Client manages the V3 API
@@ -26,7 +30,7 @@ func NewKarbonAPIClient(credentials client.Credentials) (*Client, error) {
 	var baseClient *client.Client
 
 	// check if all required fields are present. Else create an empty client
-	if credentials.Username != "" && credentials.Password != "" && credentials.Endpoint != "" {
+	if credentials.HasAuth() && credentials.Endpoint != "" {
 		c, err := client.NewClient(&credentials, userAgent, absolutePath, false)
 		if err != nil {
 			return nil, err
@@ -53,3 +57,12 @@ func NewKarbonAPIClient(credentials client.Credentials) (*Client, error) {
 
 	return f, nil
 }
+
+// withCallTimeout returns the context of a single Karbon API call. The call is bounded by the deadline
+// of ctx, derived from the timeout of the resource operation, or by defaultCallTimeout when ctx has none.
+func withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, defaultCallTimeout)
+}
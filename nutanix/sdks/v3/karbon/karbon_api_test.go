@@ -1,7 +1,9 @@
 package karbon
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
 )
@@ -41,3 +43,22 @@ func TestNewKarbonAPIClient(t *testing.T) {
 		t.Errorf("NewKarbonAPIClient(%v) expected the base client in karbon client to have some error message", cred2)
 	}
 }
+
+func Test_withCallTimeout(t *testing.T) {
+	// the timeout of the resource operation bounds the call, even when longer than defaultCallTimeout
+	resourceDeadline := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.Background(), resourceDeadline)
+	defer cancel()
+
+	callCtx, callCancel := withCallTimeout(ctx)
+	defer callCancel()
+	if deadline, ok := callCtx.Deadline(); !ok || !deadline.Equal(resourceDeadline) {
+		t.Errorf("call deadline = %v, expected the deadline of the resource operation %v", deadline, resourceDeadline)
+	}
+
+	callCtx, callCancel = withCallTimeout(context.Background())
+	defer callCancel()
+	if deadline, ok := callCtx.Deadline(); !ok || time.Until(deadline) > defaultCallTimeout {
+		t.Errorf("call deadline = %v, expected the default call timeout %s", deadline, defaultCallTimeout)
+	}
+}
@@ -16,29 +16,34 @@ type ClusterOperations struct {
 // Service ...
 type ClusterService interface {
 	// karbon v2.1
-	ListKarbonClusters() (*ClusterListIntentResponse, error)
-	CreateKarbonCluster(createRequest *ClusterIntentInput) (*ClusterActionResponse, error)
-	GetKarbonCluster(karbonClusterName string) (*ClusterIntentResponse, error)
-	GetKarbonClusterNodePool(karbonClusterName string, nodePoolName string) (*ClusterNodePool, error)
-	DeleteKarbonCluster(karbonClusterName string) (*ClusterActionResponse, error)
-	GetKubeConfigForKarbonCluster(karbonClusterName string) (*ClusterKubeconfigResponse, error)
-	GetSSHConfigForKarbonCluster(karbonClusterName string) (*ClusterSSHconfig, error)
-	ScaleUpKarbonCluster(karbonClusterName, karbonNodepoolName string, scaleUpRequest *ClusterScaleUpIntentInput) (*ClusterActionResponse, error)
-	ScaleDownKarbonCluster(karbonClusterName, karbonNodepoolName string, scaleDownRequest *ClusterScaleDownIntentInput) (*ClusterActionResponse, error)
+	ListKarbonClusters(ctx context.Context) (*ClusterListIntentResponse, error)
+	CreateKarbonCluster(ctx context.Context, createRequest *ClusterIntentInput) (*ClusterActionResponse, error)
+	GetKarbonCluster(ctx context.Context, karbonClusterName string) (*ClusterIntentResponse, error)
+	GetKarbonClusterNodePool(ctx context.Context, karbonClusterName string, nodePoolName string) (*ClusterNodePool, error)
+	DeleteKarbonCluster(ctx context.Context, karbonClusterName string) (*ClusterActionResponse, error)
+	GetKubeConfigForKarbonCluster(ctx context.Context, karbonClusterName string) (*ClusterKubeconfigResponse, error)
+	GetSSHConfigForKarbonCluster(ctx context.Context, karbonClusterName string) (*ClusterSSHconfig, error)
+	ScaleUpKarbonCluster(ctx context.Context, karbonClusterName, karbonNodepoolName string, scaleUpRequest *ClusterScaleUpIntentInput) (*ClusterActionResponse, error)
+	ScaleDownKarbonCluster(ctx context.Context, karbonClusterName, karbonNodepoolName string, scaleDownRequest *ClusterScaleDownIntentInput) (*ClusterActionResponse, error)
 	// registries
-	ListPrivateRegistries(karbonClusterName string) (*PrivateRegistryListResponse, error)
-	AddPrivateRegistry(karbonClusterName string, createRequest PrivateRegistryOperationIntentInput) (*PrivateRegistryResponse, error)
-	DeletePrivateRegistry(karbonClusterName string, privateRegistryName string) (*PrivateRegistryOperationResponse, error)
+	ListPrivateRegistries(ctx context.Context, karbonClusterName string) (*PrivateRegistryListResponse, error)
+	AddPrivateRegistry(ctx context.Context, karbonClusterName string, createRequest PrivateRegistryOperationIntentInput) (*PrivateRegistryResponse, error)
+	DeletePrivateRegistry(ctx context.Context, karbonClusterName string, privateRegistryName string) (*PrivateRegistryOperationResponse, error)
 	// worker nodes
-	AddWorkerNodePool(karbonClusterName string, addPoolRequest *ClusterNodePool) (*ClusterActionResponse, error)
-	RemoveWorkerNodePool(karbonClusterName, karbonNodepoolName string, removeWorkerPool *RemoveWorkerNodeRequest) (*ClusterActionResponse, error)
-	DeleteWorkerNodePool(karbonClusterName, workerNodepoolName string) (*ClusterActionResponse, error)
-	UpdateWorkerNodeLables(karbonClusterName, workerNodepoolName string, body *UpdateWorkerNodeLabels) (*ClusterActionResponse, error)
+	AddWorkerNodePool(ctx context.Context, karbonClusterName string, addPoolRequest *ClusterNodePool) (*ClusterActionResponse, error)
+	RemoveWorkerNodePool(ctx context.Context, karbonClusterName, karbonNodepoolName string, removeWorkerPool *RemoveWorkerNodeRequest) (*ClusterActionResponse, error)
+	DeleteWorkerNodePool(ctx context.Context, karbonClusterName, workerNodepoolName string) (*ClusterActionResponse, error)
+	UpdateWorkerNodeLables(ctx context.Context, karbonClusterName, workerNodepoolName string, body *UpdateWorkerNodeLabels) (*ClusterActionResponse, error)
+	// upgrades
+	PrecheckKarbonClusterK8sUpgrade(ctx context.Context, karbonClusterName string, body *ClusterK8sUpgradeIntentInput) (*ClusterUpgradePrecheckResponse, error)
+	UpgradeKarbonClusterK8s(ctx context.Context, karbonClusterName string, body *ClusterK8sUpgradeIntentInput) (*ClusterActionResponse, error)
+	UpgradeKarbonClusterNodeOS(ctx context.Context, karbonClusterName string, body *ClusterNodeOSUpgradeIntentInput) (*ClusterActionResponse, error)
 }
 
 // karbon 2.1
-func (op ClusterOperations) ListKarbonClusters() (*ClusterListIntentResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) ListKarbonClusters(ctx context.Context) (*ClusterListIntentResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 	path := "/v1-beta.1/k8s/clusters"
 	req, err := op.client.NewRequest(ctx, http.MethodGet, path, nil)
 	karbonClusterListIntentResponse := new(ClusterListIntentResponse)
@@ -49,8 +54,9 @@ func (op ClusterOperations) ListKarbonClusters() (*ClusterListIntentResponse, er
 	return karbonClusterListIntentResponse, op.client.Do(ctx, req, karbonClusterListIntentResponse)
 }
 
-func (op ClusterOperations) CreateKarbonCluster(createRequest *ClusterIntentInput) (*ClusterActionResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) CreateKarbonCluster(ctx context.Context, createRequest *ClusterIntentInput) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := "/v1/k8s/clusters"
 	req, err := op.client.NewRequest(ctx, http.MethodPost, path, createRequest)
@@ -63,8 +69,9 @@ func (op ClusterOperations) CreateKarbonCluster(createRequest *ClusterIntentInpu
 	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
 }
 
-func (op ClusterOperations) GetKarbonCluster(name string) (*ClusterIntentResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) GetKarbonCluster(ctx context.Context, name string) (*ClusterIntentResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1/k8s/clusters/%s", name)
 	req, err := op.client.NewRequest(ctx, http.MethodGet, path, nil)
@@ -77,8 +84,9 @@ func (op ClusterOperations) GetKarbonCluster(name string) (*ClusterIntentRespons
 	return karbonClusterIntentResponse, op.client.Do(ctx, req, karbonClusterIntentResponse)
 }
 
-func (op ClusterOperations) GetKarbonClusterNodePool(name string, nodePoolName string) (*ClusterNodePool, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) GetKarbonClusterNodePool(ctx context.Context, name string, nodePoolName string) (*ClusterNodePool, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1-beta.1/k8s/clusters/%s/node-pools/%s", name, nodePoolName)
 
@@ -92,8 +100,9 @@ func (op ClusterOperations) GetKarbonClusterNodePool(name string, nodePoolName s
 	return karbonClusterNodePool, op.client.Do(ctx, req, karbonClusterNodePool)
 }
 
-func (op ClusterOperations) DeleteKarbonCluster(name string) (*ClusterActionResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) DeleteKarbonCluster(ctx context.Context, name string) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1/k8s/clusters/%s", name)
 
@@ -107,8 +116,9 @@ func (op ClusterOperations) DeleteKarbonCluster(name string) (*ClusterActionResp
 	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
 }
 
-func (op ClusterOperations) GetKubeConfigForKarbonCluster(name string) (*ClusterKubeconfigResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) GetKubeConfigForKarbonCluster(ctx context.Context, name string) (*ClusterKubeconfigResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1/k8s/clusters/%s/kubeconfig", name)
 
@@ -122,8 +132,9 @@ func (op ClusterOperations) GetKubeConfigForKarbonCluster(name string) (*Cluster
 	return karbonClusterKubeconfigResponse, op.client.Do(ctx, req, karbonClusterKubeconfigResponse)
 }
 
-func (op ClusterOperations) GetSSHConfigForKarbonCluster(name string) (*ClusterSSHconfig, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) GetSSHConfigForKarbonCluster(ctx context.Context, name string) (*ClusterSSHconfig, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1/k8s/clusters/%s/ssh", name)
 
@@ -137,8 +148,9 @@ func (op ClusterOperations) GetSSHConfigForKarbonCluster(name string) (*ClusterS
 	return karbonClusterSSHconfig, op.client.Do(ctx, req, karbonClusterSSHconfig)
 }
 
-func (op ClusterOperations) ListPrivateRegistries(karbonClusterName string) (*PrivateRegistryListResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) ListPrivateRegistries(ctx context.Context, karbonClusterName string) (*PrivateRegistryListResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/registries", karbonClusterName)
 
 	req, err := op.client.NewRequest(ctx, http.MethodGet, path, nil)
@@ -151,8 +163,9 @@ func (op ClusterOperations) ListPrivateRegistries(karbonClusterName string) (*Pr
 	return karbonPrivateRegistryListResponse, op.client.Do(ctx, req, karbonPrivateRegistryListResponse)
 }
 
-func (op ClusterOperations) AddPrivateRegistry(karbonClusterName string, createRequest PrivateRegistryOperationIntentInput) (*PrivateRegistryResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) AddPrivateRegistry(ctx context.Context, karbonClusterName string, createRequest PrivateRegistryOperationIntentInput) (*PrivateRegistryResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/registries", karbonClusterName)
 
 	req, err := op.client.NewRequest(ctx, http.MethodPost, path, createRequest)
@@ -165,8 +178,9 @@ func (op ClusterOperations) AddPrivateRegistry(karbonClusterName string, createR
 	return karbonPrivateRegistryResponse, op.client.Do(ctx, req, karbonPrivateRegistryResponse)
 }
 
-func (op ClusterOperations) DeletePrivateRegistry(karbonClusterName string, privateRegistryName string) (*PrivateRegistryOperationResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) DeletePrivateRegistry(ctx context.Context, karbonClusterName string, privateRegistryName string) (*PrivateRegistryOperationResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/registries/%s", karbonClusterName, privateRegistryName)
 
 	req, err := op.client.NewRequest(ctx, http.MethodDelete, path, nil)
@@ -179,8 +193,9 @@ func (op ClusterOperations) DeletePrivateRegistry(karbonClusterName string, priv
 	return karbonPrivateRegistryOperationResponse, op.client.Do(ctx, req, karbonPrivateRegistryOperationResponse)
 }
 
-func (op ClusterOperations) ScaleUpKarbonCluster(karbonClusterName, karbonNodepoolName string, scaleUpRequest *ClusterScaleUpIntentInput) (*ClusterActionResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) ScaleUpKarbonCluster(ctx context.Context, karbonClusterName, karbonNodepoolName string, scaleUpRequest *ClusterScaleUpIntentInput) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/node-pools/%s/add-nodes", karbonClusterName, karbonNodepoolName)
 	req, err := op.client.NewRequest(ctx, http.MethodPost, path, scaleUpRequest)
@@ -193,8 +208,9 @@ func (op ClusterOperations) ScaleUpKarbonCluster(karbonClusterName, karbonNodepo
 	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
 }
 
-func (op ClusterOperations) ScaleDownKarbonCluster(karbonClusterName, karbonNodepoolName string, scaleDownRequest *ClusterScaleDownIntentInput) (*ClusterActionResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) ScaleDownKarbonCluster(ctx context.Context, karbonClusterName, karbonNodepoolName string, scaleDownRequest *ClusterScaleDownIntentInput) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/node-pools/%s/remove-nodes", karbonClusterName, karbonNodepoolName)
 	req, err := op.client.NewRequest(ctx, http.MethodPost, path, scaleDownRequest)
@@ -207,8 +223,9 @@ func (op ClusterOperations) ScaleDownKarbonCluster(karbonClusterName, karbonNode
 	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
 }
 
-func (op ClusterOperations) AddWorkerNodePool(karbonClusterName string, addPoolRequest *ClusterNodePool) (*ClusterActionResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) AddWorkerNodePool(ctx context.Context, karbonClusterName string, addPoolRequest *ClusterNodePool) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/add-node-pool", karbonClusterName)
 	req, err := op.client.NewRequest(ctx, http.MethodPost, path, addPoolRequest)
@@ -221,8 +238,9 @@ func (op ClusterOperations) AddWorkerNodePool(karbonClusterName string, addPoolR
 	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
 }
 
-func (op ClusterOperations) RemoveWorkerNodePool(karbonClusterName, karbonNodepoolName string, removeWorkerPool *RemoveWorkerNodeRequest) (*ClusterActionResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) RemoveWorkerNodePool(ctx context.Context, karbonClusterName, karbonNodepoolName string, removeWorkerPool *RemoveWorkerNodeRequest) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/node-pools/%s/remove-nodes", karbonClusterName, karbonNodepoolName)
 	req, err := op.client.NewRequest(ctx, http.MethodPost, path, removeWorkerPool)
@@ -235,8 +253,9 @@ func (op ClusterOperations) RemoveWorkerNodePool(karbonClusterName, karbonNodepo
 	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
 }
 
-func (op ClusterOperations) DeleteWorkerNodePool(karbonClusterName, workerNodepoolName string) (*ClusterActionResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) DeleteWorkerNodePool(ctx context.Context, karbonClusterName, workerNodepoolName string) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1-beta.1/k8s/clusters/%s/node-pools/%s", karbonClusterName, workerNodepoolName)
 	req, err := op.client.NewRequest(ctx, http.MethodDelete, path, "")
@@ -249,8 +268,9 @@ func (op ClusterOperations) DeleteWorkerNodePool(karbonClusterName, workerNodepo
 	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
 }
 
-func (op ClusterOperations) UpdateWorkerNodeLables(karbonClusterName, workerNodePoolName string, body *UpdateWorkerNodeLabels) (*ClusterActionResponse, error) {
-	ctx := context.TODO()
+func (op ClusterOperations) UpdateWorkerNodeLables(ctx context.Context, karbonClusterName, workerNodePoolName string, body *UpdateWorkerNodeLabels) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/node-pools/%s/update-labels", karbonClusterName, workerNodePoolName)
 	req, err := op.client.NewRequest(ctx, http.MethodPost, path, body)
@@ -262,3 +282,48 @@ func (op ClusterOperations) UpdateWorkerNodeLables(karbonClusterName, workerNode
 
 	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
 }
+
+func (op ClusterOperations) PrecheckKarbonClusterK8sUpgrade(ctx context.Context, karbonClusterName string, body *ClusterK8sUpgradeIntentInput) (*ClusterUpgradePrecheckResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
+
+	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/k8s-upgrade-prechecks", karbonClusterName)
+	req, err := op.client.NewRequest(ctx, http.MethodPost, path, body)
+	karbonClusterUpgradePrecheckResponse := new(ClusterUpgradePrecheckResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return karbonClusterUpgradePrecheckResponse, op.client.Do(ctx, req, karbonClusterUpgradePrecheckResponse)
+}
+
+func (op ClusterOperations) UpgradeKarbonClusterK8s(ctx context.Context, karbonClusterName string, body *ClusterK8sUpgradeIntentInput) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
+
+	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/k8s-upgrade", karbonClusterName)
+	req, err := op.client.NewRequest(ctx, http.MethodPost, path, body)
+	karbonClusterActionResponse := new(ClusterActionResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
+}
+
+func (op ClusterOperations) UpgradeKarbonClusterNodeOS(ctx context.Context, karbonClusterName string, body *ClusterNodeOSUpgradeIntentInput) (*ClusterActionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
+
+	path := fmt.Sprintf("/v1-alpha.1/k8s/clusters/%s/node-os-upgrade", karbonClusterName)
+	req, err := op.client.NewRequest(ctx, http.MethodPost, path, body)
+	karbonClusterActionResponse := new(ClusterActionResponse)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return karbonClusterActionResponse, op.client.Do(ctx, req, karbonClusterActionResponse)
+}
@@ -0,0 +1,193 @@
+package karbon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func setup() (*http.ServeMux, *client.Client, *httptest.Server) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	c, _ := client.NewClient(&client.Credentials{
+		URL:      "https://10.2.242.13:9440",
+		Username: "admin",
+		Password: "Nutanix.123",
+		Port:     "9440",
+		Endpoint: "10.2.242.13",
+		Insecure: true,
+	},
+		userAgent,
+		absolutePath,
+		false)
+	c.BaseURL, _ = url.Parse(server.URL)
+
+	return mux, c, server
+}
+
+func testHTTPMethod(t *testing.T, r *http.Request, expected string) {
+	if expected != r.Method {
+		t.Errorf("Request method = %v, expected %v", r.Method, expected)
+	}
+}
+
+func TestClusterOperations_GetKarbonCluster(t *testing.T) {
+	mux, c, server := setup()
+
+	defer server.Close()
+
+	mux.HandleFunc("/karbon/v1/k8s/clusters/nke-1", func(w http.ResponseWriter, r *http.Request) {
+		testHTTPMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"name": "nke-1", "uuid": "cluster-uuid", "version": "1.25.6-0"}`)
+	})
+
+	op := ClusterOperations{client: c}
+	got, err := op.GetKarbonCluster(context.Background(), "nke-1")
+	if err != nil {
+		t.Fatalf("ClusterOperations.GetKarbonCluster() error = %v", err)
+	}
+
+	want := &ClusterIntentResponse{
+		Name:    utils.StringPtr("nke-1"),
+		UUID:    utils.StringPtr("cluster-uuid"),
+		Version: utils.StringPtr("1.25.6-0"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClusterOperations.GetKarbonCluster() = %v, want %v", got, want)
+	}
+}
+
+func TestClusterOperations_GetKarbonCluster_canceled(t *testing.T) {
+	mux, c, server := setup()
+
+	unblock := make(chan struct{})
+	defer server.Close()
+	defer close(unblock)
+
+	mux.HandleFunc("/karbon/v1/k8s/clusters/nke-1", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-unblock:
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	op := ClusterOperations{client: c}
+	start := time.Now()
+	if _, err := op.GetKarbonCluster(ctx, "nke-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ClusterOperations.GetKarbonCluster() error = %v, expected the context deadline to be exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("ClusterOperations.GetKarbonCluster() returned after %s, expected the call to be canceled", elapsed)
+	}
+}
+
+func TestClusterOperations_upgrades(t *testing.T) {
+	mux, c, server := setup()
+
+	defer server.Close()
+
+	mux.HandleFunc("/karbon/v1-alpha.1/k8s/clusters/nke-1/k8s-upgrade-prechecks", func(w http.ResponseWriter, r *http.Request) {
+		testHTTPMethod(t, r, http.MethodPost)
+		fmt.Fprint(w, `{"checks": [{"name": "etcd health", "passed": true}, {"name": "version skew", "passed": false, "message": "unsupported"}]}`)
+	})
+	mux.HandleFunc("/karbon/v1-alpha.1/k8s/clusters/nke-1/k8s-upgrade", func(w http.ResponseWriter, r *http.Request) {
+		testHTTPMethod(t, r, http.MethodPost)
+		body := new(ClusterK8sUpgradeIntentInput)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil || body.PackageVersion != "1.26.8-0" {
+			t.Errorf("unexpected k8s upgrade request %v: %v", body, err)
+		}
+		fmt.Fprint(w, `{"cluster_name": "nke-1", "task_uuid": "k8s-task"}`)
+	})
+	mux.HandleFunc("/karbon/v1-alpha.1/k8s/clusters/nke-1/node-os-upgrade", func(w http.ResponseWriter, r *http.Request) {
+		testHTTPMethod(t, r, http.MethodPost)
+		body := new(ClusterNodeOSUpgradeIntentInput)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil || body.NodeOSVersion != "ntnx-1.6" || !reflect.DeepEqual(body.NodePoolNames, []string{"worker"}) {
+			t.Errorf("unexpected node OS upgrade request %v: %v", body, err)
+		}
+		fmt.Fprint(w, `{"cluster_name": "nke-1", "task_uuid": "os-task"}`)
+	})
+
+	ctx := context.Background()
+	op := ClusterOperations{client: c}
+	upgradeRequest := &ClusterK8sUpgradeIntentInput{PackageVersion: "1.26.8-0"}
+
+	precheck, err := op.PrecheckKarbonClusterK8sUpgrade(ctx, "nke-1", upgradeRequest)
+	if err != nil {
+		t.Fatalf("ClusterOperations.PrecheckKarbonClusterK8sUpgrade() error = %v", err)
+	}
+	wantChecks := []ClusterUpgradePrecheck{{Name: "etcd health", Passed: true}, {Name: "version skew", Message: "unsupported"}}
+	if !reflect.DeepEqual(precheck.Checks, wantChecks) {
+		t.Errorf("ClusterOperations.PrecheckKarbonClusterK8sUpgrade() = %v, want %v", precheck.Checks, wantChecks)
+	}
+
+	k8sUpgrade, err := op.UpgradeKarbonClusterK8s(ctx, "nke-1", upgradeRequest)
+	if err != nil || k8sUpgrade.TaskUUID != "k8s-task" {
+		t.Errorf("ClusterOperations.UpgradeKarbonClusterK8s() = %v, %v", k8sUpgrade, err)
+	}
+
+	osUpgrade, err := op.UpgradeKarbonClusterNodeOS(ctx, "nke-1", &ClusterNodeOSUpgradeIntentInput{NodeOSVersion: "ntnx-1.6", NodePoolNames: []string{"worker"}})
+	if err != nil || osUpgrade.TaskUUID != "os-task" {
+		t.Errorf("ClusterOperations.UpgradeKarbonClusterNodeOS() = %v, %v", osUpgrade, err)
+	}
+}
+
+func TestPrivateRegistryOperations_GetKarbonPrivateRegistry(t *testing.T) {
+	mux, c, server := setup()
+
+	defer server.Close()
+
+	mux.HandleFunc("/karbon/v1-alpha.1/registries/registry-1", func(w http.ResponseWriter, r *http.Request) {
+		testHTTPMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"name": "registry-1", "endpoint": "10.0.0.1:5000", "uuid": "registry-uuid"}`)
+	})
+
+	op := PrivateRegistryOperations{client: c}
+	got, err := op.GetKarbonPrivateRegistry(context.Background(), "registry-1")
+	if err != nil {
+		t.Fatalf("PrivateRegistryOperations.GetKarbonPrivateRegistry() error = %v", err)
+	}
+
+	want := &PrivateRegistryResponse{
+		Name:     utils.StringPtr("registry-1"),
+		Endpoint: utils.StringPtr("10.0.0.1:5000"),
+		UUID:     utils.StringPtr("registry-uuid"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PrivateRegistryOperations.GetKarbonPrivateRegistry() = %v, want %v", got, want)
+	}
+}
+
+func TestMetaOperations_GetSemanticVersion(t *testing.T) {
+	mux, c, server := setup()
+
+	defer server.Close()
+
+	mux.HandleFunc("/karbon/v1-alpha.1/version", func(w http.ResponseWriter, r *http.Request) {
+		testHTTPMethod(t, r, http.MethodGet)
+		fmt.Fprint(w, `{"version": "2.8.0"}`)
+	})
+
+	op := MetaOperations{client: c}
+	got, err := op.GetSemanticVersion(context.Background())
+	if err != nil {
+		t.Fatalf("MetaOperations.GetSemanticVersion() error = %v", err)
+	}
+
+	want := &MetaSemanticVersionResponse{MajorVersion: 2, MinorVersion: 8, RevisionVersion: 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MetaOperations.GetSemanticVersion() = %v, want %v", got, want)
+	}
+}
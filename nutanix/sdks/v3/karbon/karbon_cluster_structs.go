@@ -213,3 +213,22 @@ type UpdateWorkerNodeLabels struct {
 	AddLabel    map[string]string `json:"add_labels,omitempty"`
 	RemoveLabel []string          `json:"remove_labels,omitempty"`
 }
+
+type ClusterK8sUpgradeIntentInput struct {
+	PackageVersion string `json:"pkg_version" mapstructure:"pkg_version, omitempty"`
+}
+
+type ClusterNodeOSUpgradeIntentInput struct {
+	NodeOSVersion string   `json:"node_os_version" mapstructure:"node_os_version, omitempty"`
+	NodePoolNames []string `json:"node_pool_names,omitempty" mapstructure:"node_pool_names, omitempty"`
+}
+
+type ClusterUpgradePrecheckResponse struct {
+	Checks []ClusterUpgradePrecheck `json:"checks" mapstructure:"checks, omitempty"`
+}
+
+type ClusterUpgradePrecheck struct {
+	Name    string `json:"name" mapstructure:"name, omitempty"`
+	Passed  bool   `json:"passed" mapstructure:"passed, omitempty"`
+	Message string `json:"message" mapstructure:"message, omitempty"`
+}
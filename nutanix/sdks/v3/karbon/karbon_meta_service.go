@@ -18,12 +18,13 @@ type MetaOperations struct {
 // Service ...
 type MetaService interface {
 	// karbon v2.1
-	GetVersion() (*MetaVersionResponse, error)
-	GetSemanticVersion() (*MetaSemanticVersionResponse, error)
+	GetVersion(ctx context.Context) (*MetaVersionResponse, error)
+	GetSemanticVersion(ctx context.Context) (*MetaSemanticVersionResponse, error)
 }
 
-func (op MetaOperations) GetVersion() (*MetaVersionResponse, error) {
-	ctx := context.TODO()
+func (op MetaOperations) GetVersion(ctx context.Context) (*MetaVersionResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 	path := "/v1-alpha.1/version"
 	req, err := op.client.NewRequest(ctx, http.MethodGet, path, nil)
 	karbonMetaVersionResponse := new(MetaVersionResponse)
@@ -33,10 +34,10 @@ func (op MetaOperations) GetVersion() (*MetaVersionResponse, error) {
 	return karbonMetaVersionResponse, op.client.Do(ctx, req, karbonMetaVersionResponse)
 }
 
-func (op MetaOperations) GetSemanticVersion() (*MetaSemanticVersionResponse, error) {
+func (op MetaOperations) GetSemanticVersion(ctx context.Context) (*MetaSemanticVersionResponse, error) {
 	const expectedVersionLength int = 3
 	metaSemanticVersionResponse := new(MetaSemanticVersionResponse)
-	rawVersion, err := op.GetVersion()
+	rawVersion, err := op.GetVersion(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -16,14 +16,15 @@ type PrivateRegistryOperations struct {
 // Service ...
 type PrivateRegistryService interface {
 	// karbon v2.1
-	ListKarbonPrivateRegistries() (*PrivateRegistryListResponse, error)
-	CreateKarbonPrivateRegistry(createRequest *PrivateRegistryIntentInput) (*PrivateRegistryResponse, error)
-	GetKarbonPrivateRegistry(name string) (*PrivateRegistryResponse, error)
-	DeleteKarbonPrivateRegistry(name string) (*PrivateRegistryOperationResponse, error)
+	ListKarbonPrivateRegistries(ctx context.Context) (*PrivateRegistryListResponse, error)
+	CreateKarbonPrivateRegistry(ctx context.Context, createRequest *PrivateRegistryIntentInput) (*PrivateRegistryResponse, error)
+	GetKarbonPrivateRegistry(ctx context.Context, name string) (*PrivateRegistryResponse, error)
+	DeleteKarbonPrivateRegistry(ctx context.Context, name string) (*PrivateRegistryOperationResponse, error)
 }
 
-func (op PrivateRegistryOperations) ListKarbonPrivateRegistries() (*PrivateRegistryListResponse, error) {
-	ctx := context.TODO()
+func (op PrivateRegistryOperations) ListKarbonPrivateRegistries(ctx context.Context) (*PrivateRegistryListResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 	path := "/v1-alpha.1/registries"
 	req, err := op.client.NewRequest(ctx, http.MethodGet, path, nil)
 	karbonPrivateRegistryListResponse := new(PrivateRegistryListResponse)
@@ -34,8 +35,9 @@ func (op PrivateRegistryOperations) ListKarbonPrivateRegistries() (*PrivateRegis
 	return karbonPrivateRegistryListResponse, op.client.Do(ctx, req, karbonPrivateRegistryListResponse)
 }
 
-func (op PrivateRegistryOperations) CreateKarbonPrivateRegistry(createRequest *PrivateRegistryIntentInput) (*PrivateRegistryResponse, error) {
-	ctx := context.TODO()
+func (op PrivateRegistryOperations) CreateKarbonPrivateRegistry(ctx context.Context, createRequest *PrivateRegistryIntentInput) (*PrivateRegistryResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 	path := "/v1-alpha.1/registries"
 	req, err := op.client.NewRequest(ctx, http.MethodPost, path, createRequest)
 	karbonPrivateRegistryResponse := new(PrivateRegistryResponse)
@@ -46,8 +48,9 @@ func (op PrivateRegistryOperations) CreateKarbonPrivateRegistry(createRequest *P
 	return karbonPrivateRegistryResponse, op.client.Do(ctx, req, karbonPrivateRegistryResponse)
 }
 
-func (op PrivateRegistryOperations) GetKarbonPrivateRegistry(name string) (*PrivateRegistryResponse, error) {
-	ctx := context.TODO()
+func (op PrivateRegistryOperations) GetKarbonPrivateRegistry(ctx context.Context, name string) (*PrivateRegistryResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 
 	path := fmt.Sprintf("/v1-alpha.1/registries/%s", name)
 	fmt.Printf("Path: %s", path)
@@ -61,8 +64,9 @@ func (op PrivateRegistryOperations) GetKarbonPrivateRegistry(name string) (*Priv
 	return karbonPrivateRegistryResponse, op.client.Do(ctx, req, karbonPrivateRegistryResponse)
 }
 
-func (op PrivateRegistryOperations) DeleteKarbonPrivateRegistry(name string) (*PrivateRegistryOperationResponse, error) {
-	ctx := context.TODO()
+func (op PrivateRegistryOperations) DeleteKarbonPrivateRegistry(ctx context.Context, name string) (*PrivateRegistryOperationResponse, error) {
+	ctx, cancel := withCallTimeout(ctx)
+	defer cancel()
 	path := fmt.Sprintf("/v1-alpha.1/registries/%s", name)
 
 	req, err := op.client.NewRequest(ctx, http.MethodDelete, path, nil)
@@ -1,8 +1,6 @@
 package lcm
 
 import (
-	"strconv"
-
 	"github.com/nutanix/ntnx-api-golang-clients/lifecycle-go-client/v4/api"
 	lcm "github.com/nutanix/ntnx-api-golang-clients/lifecycle-go-client/v4/client"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
@@ -22,20 +20,21 @@ func NewLcmClient(credentials client.Credentials) (*Client, error) {
 	var baseClient *lcm.ApiClient
 
 	// check if all required fields are present. Else create an empty client
-	if credentials.Username != "" && credentials.Password != "" && credentials.Endpoint != "" {
+	if credentials.HasAuth() && credentials.Endpoint != "" {
 		pcClient := lcm.NewApiClient()
 
-		pcClient.Host = credentials.Endpoint
+		gateway, err := sdkconfig.GatewayFor(credentials)
+		if err != nil {
+			return nil, err
+		}
+		pcClient.Scheme, pcClient.Host, pcClient.Port = gateway.Scheme, gateway.Host, gateway.Port
+		gateway.Configure(pcClient)
 		pcClient.Password = credentials.Password
 		pcClient.Username = credentials.Username
-		pcClient.Port = sdkconfig.DefaultPort
-		if credentials.Port != "" {
-			if p, err := strconv.Atoi(credentials.Port); err == nil {
-				pcClient.Port = p
-			}
-		}
-		pcClient.VerifySSL = false
 		pcClient.AllowVersionNegotiation = sdkconfig.AllowVersionNegotiation
+		if err := sdkconfig.ConfigureAPIKey(pcClient, credentials); err != nil {
+			return nil, err
+		}
 		baseClient = pcClient
 	}
 
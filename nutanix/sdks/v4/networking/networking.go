@@ -1,8 +1,6 @@
 package networking
 
 import (
-	"strconv"
-
 	"github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/api"
 	network "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/client"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
@@ -10,43 +8,58 @@ import (
 )
 
 type Client struct {
-	Routes                *api.RoutesApi
-	RoutesTable           *api.RouteTablesApi
-	APIClientInstance     *network.ApiClient
-	RoutingPolicy         *api.RoutingPoliciesApi
-	SubnetAPIInstance     *api.SubnetsApi
-	VpcAPIInstance        *api.VpcsApi
-	FloatingIPAPIInstance *api.FloatingIpsApi
+	Routes                   *api.RoutesApi
+	RoutesTable              *api.RouteTablesApi
+	APIClientInstance        *network.ApiClient
+	RoutingPolicy            *api.RoutingPoliciesApi
+	SubnetAPIInstance        *api.SubnetsApi
+	VpcAPIInstance           *api.VpcsApi
+	FloatingIPAPIInstance    *api.FloatingIpsApi
+	GatewayAPIInstance       *api.GatewaysApi
+	VpnConnectionAPIInstance *api.VpnConnectionsApi
+	BgpSessionAPIInstance    *api.BgpSessionsApi
+	BgpRouteAPIInstance      *api.BgpRoutesApi
+	Layer2StretchAPIInstance *api.Layer2StretchesApi
+	VirtualSwitchAPIInstance *api.VirtualSwitchesApi
+	TrafficMirrorAPIInstance *api.TrafficMirrorsApi
 }
 
 func NewNetworkingClient(credentials client.Credentials) (*Client, error) {
 	var baseClient *network.ApiClient
 
 	// check if all required fields are present. Else create an empty client
-	if credentials.Username != "" && credentials.Password != "" && credentials.Endpoint != "" {
+	if credentials.HasAuth() && credentials.Endpoint != "" {
 		pcClient := network.NewApiClient()
 
-		pcClient.Host = credentials.Endpoint
+		gateway, err := sdkconfig.GatewayFor(credentials)
+		if err != nil {
+			return nil, err
+		}
+		pcClient.Scheme, pcClient.Host, pcClient.Port = gateway.Scheme, gateway.Host, gateway.Port
+		gateway.Configure(pcClient)
 		pcClient.Password = credentials.Password
 		pcClient.Username = credentials.Username
-		pcClient.Port = sdkconfig.DefaultPort
-		if credentials.Port != "" {
-			if p, err := strconv.Atoi(credentials.Port); err == nil {
-				pcClient.Port = p
-			}
-		}
-		pcClient.VerifySSL = false
 		pcClient.AllowVersionNegotiation = sdkconfig.AllowVersionNegotiation
+		if err := sdkconfig.ConfigureAPIKey(pcClient, credentials); err != nil {
+			return nil, err
+		}
 		baseClient = pcClient
 	}
 
 	f := &Client{
-		Routes:                api.NewRoutesApi(baseClient),
-		RoutesTable:           api.NewRouteTablesApi(baseClient),
-		RoutingPolicy:         api.NewRoutingPoliciesApi(baseClient),
-		SubnetAPIInstance:     api.NewSubnetsApi(baseClient),
-		VpcAPIInstance:        api.NewVpcsApi(baseClient),
-		FloatingIPAPIInstance: api.NewFloatingIpsApi(baseClient),
+		Routes:                   api.NewRoutesApi(baseClient),
+		RoutesTable:              api.NewRouteTablesApi(baseClient),
+		RoutingPolicy:            api.NewRoutingPoliciesApi(baseClient),
+		SubnetAPIInstance:        api.NewSubnetsApi(baseClient),
+		VpcAPIInstance:           api.NewVpcsApi(baseClient),
+		FloatingIPAPIInstance:    api.NewFloatingIpsApi(baseClient),
+		GatewayAPIInstance:       api.NewGatewaysApi(baseClient),
+		VpnConnectionAPIInstance: api.NewVpnConnectionsApi(baseClient),
+		BgpSessionAPIInstance:    api.NewBgpSessionsApi(baseClient),
+		BgpRouteAPIInstance:      api.NewBgpRoutesApi(baseClient),
+		Layer2StretchAPIInstance: api.NewLayer2StretchesApi(baseClient),
+		VirtualSwitchAPIInstance: api.NewVirtualSwitchesApi(baseClient),
+		TrafficMirrorAPIInstance: api.NewTrafficMirrorsApi(baseClient),
 	}
 
 	return f, nil
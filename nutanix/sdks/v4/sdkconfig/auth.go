@@ -0,0 +1,25 @@
+package sdkconfig
+
+import (
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
+)
+
+// APIKeyConfigurer is implemented by the ApiClient of every generated v4 SDK.
+type APIKeyConfigurer interface {
+	SetApiKey(key string) error
+	SetUserName(username string)
+	SetPassword(password string)
+}
+
+// ConfigureAPIKey makes a v4 SDK ApiClient authenticate with the X-ntnx-api-key header
+// when an api key is provided. Basic auth credentials are dropped in that case so that
+// requests are authorized by the api key only, as done by the v3 client.
+func ConfigureAPIKey(apiClient APIKeyConfigurer, credentials client.Credentials) error {
+	if credentials.APIKey == "" {
+		return nil
+	}
+
+	apiClient.SetUserName("")
+	apiClient.SetPassword("")
+	return apiClient.SetApiKey(credentials.APIKey)
+}
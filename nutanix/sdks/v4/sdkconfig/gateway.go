@@ -0,0 +1,200 @@
+package sdkconfig
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
+)
+
+// gatewayTokenHeader carries the secret the v4 SDK clients present to the gateway, so that
+// other local processes cannot send requests to Prism Central through it
+const gatewayTokenHeader = "X-Nutanix-Provider-Gateway"
+
+// GatewayClient is implemented by the ApiClient of every generated v4 SDK.
+type GatewayClient interface {
+	AddDefaultHeader(headerName string, headerValue string)
+	SetMaxRetryAttempts(maxRetryAttempts int)
+}
+
+// Gateway relays the requests of the v4 SDK clients to Prism Central.
+//
+// The generated ApiClients own their http transport: it is rebuilt, with only VerifySSL
+// set, whenever VerifySSL or the connect timeout change, and it cannot be replaced. The SDK
+// clients are pointed to the gateway instead, which listens on the loopback interface, and
+// the gateway sends their requests to Prism Central with the provider TLS settings: insecure,
+// custom CA certificates and client certificate.
+//
+// The gateway also retries the requests as the v3 client does, as per the provider retry
+// settings: the SDKs retry 408, 429, 503 and 504 responses whatever the method, and their
+// retry policy cannot be replaced either.
+type Gateway struct {
+	// Scheme, Host and Port are the address the SDK clients send their requests to
+	Scheme string
+	Host   string
+	Port   int
+
+	token    string
+	upstream *url.URL
+	proxy    *httputil.ReverseProxy
+	server   *http.Server
+}
+
+// gatewayKey identifies the settings a gateway is created with. The SDK clients of a provider
+// configuration share one gateway, the credentials are sent by the clients.
+type gatewayKey struct {
+	endpoint, port                string
+	insecure                      bool
+	caCertFile, caCertPEM         string
+	clientCertFile, clientKeyFile string
+	maxRetryAttempts              int
+	retryWaitMin, retryWaitMax    time.Duration
+}
+
+var (
+	gatewaysMu sync.Mutex
+	gateways   = make(map[gatewayKey]*Gateway)
+)
+
+// GatewayFor returns the gateway to Prism Central described by the credentials, started on the
+// first call.
+func GatewayFor(credentials client.Credentials) (*Gateway, error) {
+	key := gatewayKey{
+		endpoint:       credentials.Endpoint,
+		port:           credentials.Port,
+		insecure:       credentials.Insecure,
+		caCertFile:     credentials.CACertFile,
+		caCertPEM:      credentials.CACertPEM,
+		clientCertFile: credentials.ClientCertFile,
+		clientKeyFile:  credentials.ClientKeyFile,
+
+		maxRetryAttempts: credentials.MaxRetryAttempts,
+		retryWaitMin:     credentials.RetryWaitMin,
+		retryWaitMax:     credentials.RetryWaitMax,
+	}
+
+	gatewaysMu.Lock()
+	defer gatewaysMu.Unlock()
+	if g, ok := gateways[key]; ok {
+		return g, nil
+	}
+	g, err := newGateway(credentials)
+	if err != nil {
+		return nil, err
+	}
+	gateways[key] = g
+	return g, nil
+}
+
+func newGateway(credentials client.Credentials) (*Gateway, error) {
+	port := DefaultPort
+	if credentials.Port != "" {
+		if p, err := strconv.Atoi(credentials.Port); err == nil {
+			port = p
+		}
+	}
+	upstream := &url.URL{Scheme: "https", Host: net.JoinHostPort(credentials.Endpoint, strconv.Itoa(port))}
+
+	tlsConfig, err := credentials.TLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		MaxIdleConns:          10,
+		MaxIdleConnsPerHost:   10,
+		MaxConnsPerHost:       100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("error generating the v4 SDK gateway token: %s", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("error starting the v4 SDK gateway: %s", err)
+	}
+
+	g := &Gateway{
+		Scheme:   "http",
+		Host:     "127.0.0.1",
+		Port:     listener.Addr().(*net.TCPAddr).Port,
+		token:    hex.EncodeToString(token),
+		upstream: upstream,
+	}
+	g.proxy = &httputil.ReverseProxy{
+		Director:       g.direct,
+		Transport:      &client.RetryTransport{Transport: transport, Credentials: &credentials},
+		ModifyResponse: g.modifyResponse,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			log.Printf("[DEBUG] %s %s failed: %s", r.Method, r.URL.Path, err)
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		},
+	}
+	g.server = &http.Server{
+		Handler:           g,
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go func() {
+		if err := g.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Printf("[ERROR] v4 SDK gateway to %s stopped: %s", upstream.Host, err)
+		}
+	}()
+
+	return g, nil
+}
+
+// Configure points a v4 SDK ApiClient, whose Scheme, Host and Port are set to the ones of the
+// gateway, to the gateway. The requests are retried by the gateway only.
+func (g *Gateway) Configure(apiClient GatewayClient) {
+	apiClient.AddDefaultHeader(gatewayTokenHeader, g.token)
+	apiClient.SetMaxRetryAttempts(0)
+}
+
+// ServeHTTP relays a request of a v4 SDK client to Prism Central
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(gatewayTokenHeader)), []byte(g.token)) != 1 {
+		http.Error(w, "unknown v4 SDK client", http.StatusForbidden)
+		return
+	}
+	r.Header.Del(gatewayTokenHeader)
+	g.proxy.ServeHTTP(w, r)
+}
+
+func (g *Gateway) direct(req *http.Request) {
+	req.URL.Scheme = g.upstream.Scheme
+	req.URL.Host = g.upstream.Host
+	req.Host = g.upstream.Host
+	// the gateway is not a proxy Prism Central should know about
+	req.Header["X-Forwarded-For"] = nil
+}
+
+// modifyResponse keeps the redirects followed by the SDK clients going through the gateway
+func (g *Gateway) modifyResponse(resp *http.Response) error {
+	location, err := resp.Location()
+	if err != nil || location.Host != g.upstream.Host {
+		return nil
+	}
+	location.Scheme = g.Scheme
+	location.Host = net.JoinHostPort(g.Host, strconv.Itoa(g.Port))
+	resp.Header.Set("Location", location.String())
+	return nil
+}
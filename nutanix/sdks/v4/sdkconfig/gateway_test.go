@@ -0,0 +1,233 @@
+package sdkconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	vmm "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/client"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
+)
+
+func newGatewayTestServer() *httptest.Server {
+	return httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(gatewayTokenHeader) != "" {
+			http.Error(w, "gateway token sent to Prism Central", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	}))
+}
+
+// newGatewayTestClient returns a vmm ApiClient sending its requests to Prism Central through a gateway
+func newGatewayTestClient(t *testing.T, server *httptest.Server, credentials client.Credentials) *vmm.ApiClient {
+	serverURL, _ := url.Parse(server.URL)
+	credentials.Endpoint, credentials.Port = serverURL.Hostname(), serverURL.Port()
+
+	gateway, err := newGateway(credentials)
+	if err != nil {
+		t.Fatalf("newGateway() error = %v", err)
+	}
+	t.Cleanup(func() { gateway.server.Close() })
+
+	apiClient := vmm.NewApiClient()
+	apiClient.Scheme, apiClient.Host, apiClient.Port = gateway.Scheme, gateway.Host, gateway.Port
+	apiClient.AllowVersionNegotiation = false
+	gateway.Configure(apiClient)
+	return apiClient
+}
+
+func callGatewayTestAPI(apiClient *vmm.ApiClient) error {
+	return callGatewayTestAPIWithMethod(apiClient, http.MethodGet, nil)
+}
+
+func callGatewayTestAPIWithMethod(apiClient *vmm.ApiClient, method string, body interface{}) error {
+	uri := "/api/vmm/v4.2/ahv/config/vms"
+	_, err := apiClient.CallApi(&uri, method, body, url.Values{}, map[string]string{},
+		url.Values{}, []string{"application/json"}, []string{}, []string{"basicAuthScheme"})
+	return err
+}
+
+func TestGateway_TLS(t *testing.T) {
+	server := newGatewayTestServer()
+	server.StartTLS()
+	defer server.Close()
+
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	tests := []struct {
+		name        string
+		credentials client.Credentials
+		wantErr     bool
+	}{
+		{"untrusted certificate", client.Credentials{}, true},
+		{"insecure", client.Credentials{Insecure: true}, false},
+		{"ca_cert_pem", client.Credentials{CACertPEM: caPEM}, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			apiClient := newGatewayTestClient(t, server, tt.credentials)
+			if err := callGatewayTestAPI(apiClient); (err != nil) != tt.wantErr {
+				t.Errorf("CallApi() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestGateway_clientCertificate checks the client certificate is still presented once the SDK has
+// rebuilt its transport, which it does when VerifySSL or the connect timeout change
+func TestGateway_clientCertificate(t *testing.T) {
+	certFile, keyFile, clientCAs := writeClientCertificate(t)
+
+	server := newGatewayTestServer()
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	apiClient := newGatewayTestClient(t, server, client.Credentials{
+		Insecure:       true,
+		ClientCertFile: certFile,
+		ClientKeyFile:  keyFile,
+	})
+	if err := callGatewayTestAPI(apiClient); err != nil {
+		t.Fatalf("CallApi() error = %v", err)
+	}
+
+	apiClient.ConnectTimeout = 5 * time.Second
+	apiClient.SetVerifySSL(!apiClient.VerifySSL)
+	if err := callGatewayTestAPI(apiClient); err != nil {
+		t.Errorf("CallApi() after the SDK transport was rebuilt, error = %v", err)
+	}
+}
+
+func TestGateway_unknownClient(t *testing.T) {
+	server := newGatewayTestServer()
+	server.StartTLS()
+	defer server.Close()
+
+	apiClient := newGatewayTestClient(t, server, client.Credentials{Insecure: true})
+
+	resp, err := http.Get(fmt.Sprintf("%s://%s:%d/api/vmm/v4.2/ahv/config/vms", apiClient.Scheme, apiClient.Host, apiClient.Port))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("status = %d, expected requests without the gateway token to be rejected", resp.StatusCode)
+	}
+}
+
+// TestGateway_retries checks the requests are retried as by the v3 client, and not by the SDK
+func TestGateway_retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		status    int
+		wantErr   bool
+		wantCalls int32
+	}{
+		{"get 503", http.MethodGet, http.StatusServiceUnavailable, false, 2},
+		{"post 503", http.MethodPost, http.StatusServiceUnavailable, false, 2},
+		{"get 502", http.MethodGet, http.StatusBadGateway, false, 2},
+		{"post 504", http.MethodPost, http.StatusGatewayTimeout, true, 1},
+		{"get 408", http.MethodGet, http.StatusRequestTimeout, true, 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) == 1 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, `{}`)
+			}))
+			server.StartTLS()
+			defer server.Close()
+
+			apiClient := newGatewayTestClient(t, server, client.Credentials{
+				Insecure:         true,
+				MaxRetryAttempts: 2,
+				RetryWaitMin:     time.Millisecond,
+				RetryWaitMax:     2 * time.Millisecond,
+			})
+			var body interface{}
+			if tt.method == http.MethodPost {
+				body = &struct {
+					Name string `json:"name"`
+				}{"vm"}
+			}
+			if err := callGatewayTestAPIWithMethod(apiClient, tt.method, body); (err != nil) != tt.wantErr {
+				t.Errorf("CallApi() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("server called %d times, expected %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// writeClientCertificate writes a self-signed client certificate and its key, and returns the pool
+// the server verifies the certificate with
+func writeClientCertificate(t *testing.T) (certFile, keyFile string, pool *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating the client key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-nutanix"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating the client certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("error parsing the client certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("error encoding the client key: %v", err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("error writing the client certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatalf("error writing the client key: %v", err)
+	}
+
+	pool = x509.NewCertPool()
+	pool.AddCert(cert)
+	return certFile, keyFile, pool
+}
@@ -0,0 +1,181 @@
+package vmm
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
+)
+
+const (
+	// contentAbsolutePath is the path of the vmm content API, in the version of the vmm SDK
+	contentAbsolutePath  = "api/vmm/v4.2/content"
+	imageUploadUserAgent = "nutanix"
+
+	// DefaultImageUploadChunkSize is the size of the parts image files are uploaded in
+	DefaultImageUploadChunkSize = 64 * 1024 * 1024
+	// DefaultImageUploadChunkAttempts is the number of times a chunk is sent before an upload fails
+	DefaultImageUploadChunkAttempts = 5
+	// DefaultImageUploadRetryWait is the wait before sending a failed chunk again, doubled on every attempt
+	DefaultImageUploadRetryWait = 5 * time.Second
+)
+
+// ImageUploadAPI uploads local files to images. The generated vmm SDK only supports downloading
+// image files, the upload is done with the provider http client.
+type ImageUploadAPI struct {
+	client *client.Client
+}
+
+// ImageUploadOptions are the settings of an image file upload
+type ImageUploadOptions struct {
+	// ChunkSize is the size of the parts the file is sent in
+	ChunkSize int64
+	// ChunkAttempts is the number of times a chunk is sent before the upload fails
+	ChunkAttempts int
+	// RetryWait is the wait before sending a failed chunk again, doubled on every attempt
+	RetryWait time.Duration
+	// ChecksumType (sha1 or sha256) and Checksum, if set, are verified by Prism Central once
+	// the whole file is uploaded
+	ChecksumType string
+	Checksum     string
+}
+
+func newImageUploadAPI(credentials client.Credentials) (*ImageUploadAPI, error) {
+	c, err := newContentClient(credentials, contentAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	return &ImageUploadAPI{client: c}, nil
+}
+
+// newContentClient returns the provider http client for the content files APIs under absolutePath, which
+// does not retry the requests
+func newContentClient(credentials client.Credentials, absolutePath string) (*client.Client, error) {
+	if !credentials.HasAuth() || credentials.Endpoint == "" {
+		return &client.Client{UserAgent: imageUploadUserAgent, ErrorMsg: "vmm client is missing. Please provide the Prism Central endpoint and credentials in provider configuration."}, nil
+	}
+
+	// the transfers are retried by chunk, or resumed, by their own loops, the requests are sent once
+	credentials.MaxRetryAttempts = 0
+	c, err := client.NewBaseClient(&credentials, absolutePath, false)
+	if err != nil {
+		return nil, err
+	}
+	c.UserAgent = imageUploadUserAgent
+	return c, nil
+}
+
+// UploadImageFile streams a local file to the image with the given extId. The file is sent in
+// chunks of opts.ChunkSize bytes. A chunk failing is sent again, up to opts.ChunkAttempts times,
+// so that the upload resumes from the failed chunk rather than from the start of the file. The offset
+// is not kept once the upload fails, a new upload starts from the first byte.
+func (api *ImageUploadAPI) UploadImageFile(ctx context.Context, imageExtID, filePath string, opts ImageUploadOptions) error {
+	return uploadFile(ctx, api.client, fmt.Sprintf("/images/%s/file", imageExtID), filePath, "image "+imageExtID, opts)
+}
+
+// uploadFile streams a local file in chunks to path, target names the entity the file is uploaded to in errors and logs
+func uploadFile(ctx context.Context, c *client.Client, path, filePath, target string, opts ImageUploadOptions) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("error: cannot open file: %s", err)
+	}
+	defer file.Close()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("error: cannot read file %s: %s", filePath, err)
+	}
+	size := fileInfo.Size()
+	if size == 0 {
+		return fmt.Errorf("error: file %s is empty", filePath)
+	}
+
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultImageUploadChunkSize
+	}
+	if opts.ChunkAttempts <= 0 {
+		opts.ChunkAttempts = DefaultImageUploadChunkAttempts
+	}
+
+	for offset := int64(0); offset < size; offset += opts.ChunkSize {
+		length := opts.ChunkSize
+		if offset+length > size {
+			length = size - offset
+		}
+
+		chunk := io.NewSectionReader(file, offset, length)
+		if err := uploadChunk(ctx, c, path, chunk, offset, size, opts); err != nil {
+			return fmt.Errorf("error uploading %s to %s at byte %d: %w", filePath, target, offset, err)
+		}
+		log.Printf("[DEBUG] uploaded %d/%d bytes of %s to %s", offset+length, size, filePath, target)
+	}
+	return nil
+}
+
+// uploadChunk sends a chunk of the file, starting at offset, until it succeeds or
+// opts.ChunkAttempts is reached
+func uploadChunk(ctx context.Context, c *client.Client, path string, chunk *io.SectionReader, offset, size int64, opts ImageUploadOptions) error {
+	wait := opts.RetryWait
+	for attempt := 1; ; attempt++ {
+		if _, err := chunk.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		req, err := c.NewChunkUploadRequest(ctx, http.MethodPut, path, chunk)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+chunk.Size()-1, size))
+		if opts.ChecksumType != "" && opts.Checksum != "" {
+			req.Header.Set("X-Nutanix-Checksum-Type", opts.ChecksumType)
+			req.Header.Set("X-Nutanix-Checksum-Bytes", opts.Checksum)
+		}
+
+		err = c.Do(ctx, req, nil)
+		if err == nil || ctx.Err() != nil || attempt >= opts.ChunkAttempts {
+			return err
+		}
+
+		log.Printf("[WARN] upload of %s failed at byte %d: %s. Resuming in %s (attempt %d/%d)", path, offset, err, wait, attempt, opts.ChunkAttempts)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
+
+// FileChecksum returns the hex digest of a local file for the given checksum type, sha1 or sha256
+func FileChecksum(filePath, checksumType string) (string, error) {
+	var h hash.Hash
+	switch checksumType {
+	case "sha1":
+		h = sha1.New() //nolint:gosec
+	case "sha256":
+		h = sha256.New()
+	default:
+		return "", fmt.Errorf("unsupported checksum type %s", checksumType)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("error: cannot open file: %s", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(h, file); err != nil {
+		return "", fmt.Errorf("error: cannot read file %s: %s", filePath, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,140 @@
+package vmm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
+)
+
+const testImageContent = "0123456789"
+
+func setupImageUpload(t *testing.T, handler http.HandlerFunc) (*ImageUploadAPI, string) {
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, _ := url.Parse(server.URL)
+	api, err := newImageUploadAPI(client.Credentials{
+		URL:      serverURL.Host,
+		Endpoint: serverURL.Hostname(),
+		Port:     serverURL.Port(),
+		Username: "username",
+		Password: "password",
+		Insecure: true,
+		// the chunks are retried by the upload, not by the client
+		MaxRetryAttempts: 3,
+		RetryWaitMin:     time.Millisecond,
+		RetryWaitMax:     2 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("newImageUploadAPI(): %v", err)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "image.qcow2")
+	if err := os.WriteFile(filePath, []byte(testImageContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return api, filePath
+}
+
+func TestImageUploadAPI_UploadImageFile(t *testing.T) {
+	var ranges []string
+	received := make([]byte, len(testImageContent))
+	failures := 1
+
+	api, filePath := setupImageUpload(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/vmm/v4.2/content/images/image-uuid/file" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-Nutanix-Checksum-Type") != "sha256" || r.Header.Get("X-Nutanix-Checksum-Bytes") != "digest" {
+			t.Errorf("checksum headers not sent: %v", r.Header)
+		}
+
+		contentRange := r.Header.Get("Content-Range")
+		body, _ := io.ReadAll(r.Body)
+
+		// the second chunk fails once
+		if strings.HasPrefix(contentRange, "bytes 4-") && failures > 0 {
+			failures--
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprint(w, `{"data": {"error": [{"message": "connection to the storage lost"}]}}`)
+			return
+		}
+
+		ranges = append(ranges, contentRange)
+		var start, end, size int
+		fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &size)
+		copy(received[start:end+1], body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := api.UploadImageFile(context.Background(), "image-uuid", filePath, ImageUploadOptions{
+		ChunkSize:    4,
+		ChecksumType: "sha256",
+		Checksum:     "digest",
+	})
+	if err != nil {
+		t.Fatalf("UploadImageFile(): %v", err)
+	}
+
+	expected := []string{"bytes 0-3/10", "bytes 4-7/10", "bytes 8-9/10"}
+	if strings.Join(ranges, ",") != strings.Join(expected, ",") {
+		t.Errorf("uploaded ranges = %v, expected %v", ranges, expected)
+	}
+	if string(received) != testImageContent {
+		t.Errorf("uploaded content = %s, expected %s", received, testImageContent)
+	}
+}
+
+func TestImageUploadAPI_UploadImageFile_failure(t *testing.T) {
+	attempts := 0
+	api, filePath := setupImageUpload(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"data": {"error": [{"message": "image service unavailable"}]}}`)
+	})
+
+	err := api.UploadImageFile(context.Background(), "image-uuid", filePath, ImageUploadOptions{ChunkAttempts: 3})
+	if err == nil || !strings.Contains(err.Error(), "image service unavailable") {
+		t.Errorf("UploadImageFile() error = %v, expected the server error", err)
+	}
+	if attempts != 3 {
+		t.Errorf("chunk sent %d times, expected 3", attempts)
+	}
+}
+
+func TestFileChecksum(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "image.iso")
+	if err := os.WriteFile(filePath, []byte(testImageContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"sha1":   "87acec17cd9dcd20a716cc2cf67417b71c8a7016",
+		"sha256": "84d89877f0d4041efb6bf91a16f0248f2fd573e6af05c19f96bedb9f882f7882",
+	}
+	for checksumType, expected := range tests {
+		got, err := FileChecksum(filePath, checksumType)
+		if err != nil {
+			t.Fatalf("FileChecksum(%s): %v", checksumType, err)
+		}
+		if got != expected {
+			t.Errorf("FileChecksum(%s) = %s, expected %s", checksumType, got, expected)
+		}
+	}
+
+	if _, err := FileChecksum(filePath, "md5"); err == nil {
+		t.Error("expected an error for an unsupported checksum type")
+	}
+}
@@ -0,0 +1,197 @@
+package vmm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
+)
+
+const (
+	// DefaultOvaDownloadAttempts is the number of times a download is resumed before it fails
+	DefaultOvaDownloadAttempts = 5
+	// DefaultOvaDownloadRetryWait is the wait before resuming a failed download, doubled on every attempt
+	DefaultOvaDownloadRetryWait = 5 * time.Second
+
+	// partialDownloadSuffix is appended to the path of a file being downloaded
+	partialDownloadSuffix = ".part"
+)
+
+// OvaFileAPI streams OVA files between Prism Central and local files. The generated vmm SDK downloads
+// OVA files to a temporary file in one request, the transfers are done with the provider http client.
+type OvaFileAPI struct {
+	client *client.Client
+}
+
+// OvaUploadOptions are the settings of an OVA file upload, OVA files are uploaded as image files are
+type OvaUploadOptions = ImageUploadOptions
+
+// OvaDownloadOptions are the settings of an OVA file download
+type OvaDownloadOptions struct {
+	// SizeBytes is the size of the OVA file. A partial file of this size is complete and not downloaded again.
+	SizeBytes int64
+	// Attempts is the number of times the download is resumed before it fails
+	Attempts int
+	// RetryWait is the wait before resuming a failed download, doubled on every attempt
+	RetryWait time.Duration
+}
+
+func newOvaFileAPI(credentials client.Credentials) (*OvaFileAPI, error) {
+	c, err := newContentClient(credentials, contentAbsolutePath)
+	if err != nil {
+		return nil, err
+	}
+	return &OvaFileAPI{client: c}, nil
+}
+
+// UploadOvaFile streams a local OVA file to the OVA with the given extId, in chunks of opts.ChunkSize bytes.
+// A chunk failing is sent again, up to opts.ChunkAttempts times.
+func (api *OvaFileAPI) UploadOvaFile(ctx context.Context, ovaExtID, filePath string, opts OvaUploadOptions) error {
+	return uploadFile(ctx, api.client, fmt.Sprintf("/ovas/%s/file", ovaExtID), filePath, "ova "+ovaExtID, opts)
+}
+
+// DownloadOvaFile streams the file of the OVA with the given extId to filePath, without holding it in memory.
+// The file is written to filePath.<extId>.part and renamed once complete. A partial file left by an interrupted
+// download of the same OVA, by this call or a previous one, is resumed from its end with a range request.
+// Partial files of other OVAs to the same path are removed. It returns the size of the downloaded file.
+func (api *OvaFileAPI) DownloadOvaFile(ctx context.Context, ovaExtID, filePath string, opts OvaDownloadOptions) (int64, error) {
+	if opts.Attempts <= 0 {
+		opts.Attempts = DefaultOvaDownloadAttempts
+	}
+
+	partPath := partialDownloadPath(filePath, ovaExtID)
+	removeStalePartialDownloads(filePath, partPath)
+	wait := opts.RetryWait
+	for attempt := 1; ; attempt++ {
+		size, err := api.downloadPart(ctx, fmt.Sprintf("/ovas/%s/file", ovaExtID), partPath, opts.SizeBytes)
+		if err == nil {
+			if err := os.Rename(partPath, filePath); err != nil {
+				return 0, fmt.Errorf("error: cannot move %s to %s: %s", partPath, filePath, err)
+			}
+			return size, nil
+		}
+		if ctx.Err() != nil || attempt >= opts.Attempts || !errors.Is(err, errDownloadInterrupted) {
+			return 0, fmt.Errorf("error downloading ova %s to %s: %w", ovaExtID, filePath, err)
+		}
+
+		log.Printf("[WARN] download of ova %s failed at byte %d: %s. Resuming in %s (attempt %d/%d)", ovaExtID, size, err, wait, attempt, opts.Attempts)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return 0, ctx.Err()
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
+
+// partialDownloadPath returns the path of the partial file of the OVA with the given extId. Naming it after
+// the OVA keeps a download from resuming with the bytes of another OVA, e.g. of a previous disk format export.
+func partialDownloadPath(filePath, ovaExtID string) string {
+	return fmt.Sprintf("%s.%s%s", filePath, ovaExtID, partialDownloadSuffix)
+}
+
+// removeStalePartialDownloads removes the partial files to filePath other than partPath
+func removeStalePartialDownloads(filePath, partPath string) {
+	entries, err := os.ReadDir(filepath.Dir(filePath))
+	if err != nil {
+		return
+	}
+	prefix := filepath.Base(filePath) + "."
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || name == filepath.Base(partPath) || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, partialDownloadSuffix) {
+			continue
+		}
+		stale := filepath.Join(filepath.Dir(filePath), name)
+		log.Printf("[DEBUG] removing partial file %s of another download", stale)
+		if err := os.Remove(stale); err != nil {
+			log.Printf("[WARN] cannot remove partial file %s: %s", stale, err)
+		}
+	}
+}
+
+// errDownloadInterrupted is returned when the connection is lost while the file is streamed, the download can be resumed
+var errDownloadInterrupted = errors.New("download interrupted")
+
+// downloadPart appends the rest of the file at path to the partial file and returns its size
+func (api *OvaFileAPI) downloadPart(ctx context.Context, path, partPath string, sizeBytes int64) (int64, error) {
+	file, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return 0, fmt.Errorf("error: cannot open file: %s", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("error: cannot read file %s: %s", partPath, err)
+	}
+	offset := info.Size()
+	if sizeBytes > 0 && offset == sizeBytes {
+		log.Printf("[DEBUG] %s is already complete", partPath)
+		return offset, nil
+	}
+	if sizeBytes > 0 && offset > sizeBytes {
+		offset = 0
+	}
+
+	req, err := api.client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return offset, err
+	}
+	req.Header.Set("Accept", "application/octet-stream")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := api.client.DoStream(ctx, req)
+	if err != nil {
+		return offset, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// the size is not known and the partial file already holds the whole file
+		var total int64
+		if _, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes */%d", &total); err != nil || total == offset {
+			log.Printf("[DEBUG] %s is already complete", partPath)
+			return offset, nil
+		}
+		if err := file.Truncate(0); err != nil {
+			return offset, fmt.Errorf("error: cannot truncate file %s: %s", partPath, err)
+		}
+		return 0, fmt.Errorf("%w: partial file of %d bytes is larger than the file of %d bytes", errDownloadInterrupted, offset, total)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return offset, fmt.Errorf("error: unexpected response %s", resp.Status)
+	case resp.StatusCode != http.StatusPartialContent:
+		// a server ignoring the range sends the whole file again
+		offset = 0
+	}
+	if err := file.Truncate(offset); err != nil {
+		return offset, fmt.Errorf("error: cannot truncate file %s: %s", partPath, err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return offset, err
+	}
+	log.Printf("[DEBUG] downloading %s to %s from byte %d", path, partPath, offset)
+
+	written, err := io.Copy(file, resp.Body)
+	offset += written
+	if err != nil {
+		return offset, fmt.Errorf("%w at byte %d: %s", errDownloadInterrupted, offset, err)
+	}
+	if sizeBytes > 0 && offset != sizeBytes {
+		return offset, fmt.Errorf("%w: received %d bytes, expected %d", errDownloadInterrupted, offset, sizeBytes)
+	}
+	return offset, nil
+}
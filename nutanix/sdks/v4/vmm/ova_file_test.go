@@ -0,0 +1,198 @@
+package vmm
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
+)
+
+const testOvaContent = "ova-0123456789"
+
+func setupOvaFileAPI(t *testing.T, handler http.HandlerFunc) *OvaFileAPI {
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	serverURL, _ := url.Parse(server.URL)
+	api, err := newOvaFileAPI(client.Credentials{
+		URL:      serverURL.Host,
+		Endpoint: serverURL.Hostname(),
+		Port:     serverURL.Port(),
+		Username: "username",
+		Password: "password",
+		Insecure: true,
+	})
+	if err != nil {
+		t.Fatalf("newOvaFileAPI(): %v", err)
+	}
+	return api
+}
+
+// serveOvaFile serves the OVA content from the requested range, the first interruptions responses are cut after 4 bytes
+func serveOvaFile(t *testing.T, ranges *[]string, interruptions int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/vmm/v4.2/content/ovas/ova-uuid/file" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		*ranges = append(*ranges, r.Header.Get("Range"))
+
+		start := 0
+		status := http.StatusOK
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start); err == nil {
+			status = http.StatusPartialContent
+		}
+		if start >= len(testOvaContent) {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(testOvaContent)))
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		content := testOvaContent[start:]
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+		w.WriteHeader(status)
+		if interruptions > 0 {
+			interruptions--
+			fmt.Fprint(w, content[:4])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		fmt.Fprint(w, content)
+	}
+}
+
+func TestOvaFileAPI_DownloadOvaFile(t *testing.T) {
+	var ranges []string
+	api := setupOvaFileAPI(t, serveOvaFile(t, &ranges, 2))
+
+	filePath := filepath.Join(t.TempDir(), "appliance.ova")
+	size, err := api.DownloadOvaFile(context.Background(), "ova-uuid", filePath, OvaDownloadOptions{SizeBytes: int64(len(testOvaContent))})
+	if err != nil {
+		t.Fatalf("DownloadOvaFile(): %v", err)
+	}
+
+	expected := []string{"", "bytes=4-", "bytes=8-"}
+	if strings.Join(ranges, ",") != strings.Join(expected, ",") {
+		t.Errorf("requested ranges = %q, expected %q", ranges, expected)
+	}
+	content, _ := os.ReadFile(filePath)
+	if string(content) != testOvaContent || size != int64(len(testOvaContent)) {
+		t.Errorf("downloaded %d bytes %q, expected %q", size, content, testOvaContent)
+	}
+	if _, err := os.Stat(partialDownloadPath(filePath, "ova-uuid")); !os.IsNotExist(err) {
+		t.Errorf("partial file left after the download: %v", err)
+	}
+}
+
+func TestOvaFileAPI_DownloadOvaFile_resumesPartialFile(t *testing.T) {
+	var ranges []string
+	api := setupOvaFileAPI(t, serveOvaFile(t, &ranges, 0))
+
+	// left by an interrupted apply
+	filePath := filepath.Join(t.TempDir(), "appliance.ova")
+	if err := os.WriteFile(partialDownloadPath(filePath, "ova-uuid"), []byte(testOvaContent[:6]), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := api.DownloadOvaFile(context.Background(), "ova-uuid", filePath, OvaDownloadOptions{}); err != nil {
+		t.Fatalf("DownloadOvaFile(): %v", err)
+	}
+	if strings.Join(ranges, ",") != "bytes=6-" {
+		t.Errorf("requested ranges = %q, expected the rest of the file", ranges)
+	}
+	if content, _ := os.ReadFile(filePath); string(content) != testOvaContent {
+		t.Errorf("downloaded %q, expected %q", content, testOvaContent)
+	}
+}
+
+func TestOvaFileAPI_DownloadOvaFile_completePartialFile(t *testing.T) {
+	var ranges []string
+	api := setupOvaFileAPI(t, serveOvaFile(t, &ranges, 0))
+
+	// the size is not known, the server answers the range past the end with a 416
+	filePath := filepath.Join(t.TempDir(), "appliance.ova")
+	if err := os.WriteFile(partialDownloadPath(filePath, "ova-uuid"), []byte(testOvaContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := api.DownloadOvaFile(context.Background(), "ova-uuid", filePath, OvaDownloadOptions{})
+	if err != nil {
+		t.Fatalf("DownloadOvaFile(): %v", err)
+	}
+	if content, _ := os.ReadFile(filePath); string(content) != testOvaContent || size != int64(len(testOvaContent)) {
+		t.Errorf("downloaded %d bytes %q, expected %q", size, content, testOvaContent)
+	}
+}
+
+func TestOvaFileAPI_DownloadOvaFile_discardsOtherOvaPartialFile(t *testing.T) {
+	var ranges []string
+	api := setupOvaFileAPI(t, serveOvaFile(t, &ranges, 0))
+
+	// left by an interrupted download of a previous export
+	filePath := filepath.Join(t.TempDir(), "appliance.ova")
+	stalePath := partialDownloadPath(filePath, "previous-ova-uuid")
+	if err := os.WriteFile(stalePath, []byte("stale-"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := api.DownloadOvaFile(context.Background(), "ova-uuid", filePath, OvaDownloadOptions{}); err != nil {
+		t.Fatalf("DownloadOvaFile(): %v", err)
+	}
+	if strings.Join(ranges, ",") != "" {
+		t.Errorf("requested ranges = %q, expected the whole file", ranges)
+	}
+	if content, _ := os.ReadFile(filePath); string(content) != testOvaContent {
+		t.Errorf("downloaded %q, expected %q", content, testOvaContent)
+	}
+	if _, err := os.Stat(stalePath); !os.IsNotExist(err) {
+		t.Errorf("partial file of another ova left: %v", err)
+	}
+}
+
+func TestOvaFileAPI_DownloadOvaFile_failure(t *testing.T) {
+	var ranges []string
+	api := setupOvaFileAPI(t, serveOvaFile(t, &ranges, 5))
+
+	filePath := filepath.Join(t.TempDir(), "appliance.ova")
+	_, err := api.DownloadOvaFile(context.Background(), "ova-uuid", filePath, OvaDownloadOptions{SizeBytes: int64(len(testOvaContent)), Attempts: 2})
+	if err == nil {
+		t.Fatal("expected the download to fail")
+	}
+	if len(ranges) != 2 {
+		t.Errorf("download attempted %d times, expected 2", len(ranges))
+	}
+	// the partial file is kept for the next apply
+	if content, _ := os.ReadFile(partialDownloadPath(filePath, "ova-uuid")); string(content) != testOvaContent[:8] {
+		t.Errorf("partial file = %q, expected the 8 bytes received", content)
+	}
+}
+
+func TestOvaFileAPI_UploadOvaFile(t *testing.T) {
+	var received []byte
+	api := setupOvaFileAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/vmm/v4.2/content/ovas/ova-uuid/file" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		received = append(received, body...)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	filePath := filepath.Join(t.TempDir(), "appliance.ova")
+	if err := os.WriteFile(filePath, []byte(testOvaContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.UploadOvaFile(context.Background(), "ova-uuid", filePath, OvaUploadOptions{ChunkSize: 5}); err != nil {
+		t.Fatalf("UploadOvaFile(): %v", err)
+	}
+	if string(received) != testOvaContent {
+		t.Errorf("uploaded %q, expected %q", received, testOvaContent)
+	}
+}
@@ -1,8 +1,6 @@
 package vmm
 
 import (
-	"strconv"
-
 	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/api"
 	vmm "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/client"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
@@ -15,35 +13,54 @@ type Client struct {
 	VMAPIInstance              *api.VmApi
 	ImagesPlacementAPIInstance *api.ImagePlacementPoliciesApi
 	OvasAPIInstance            *api.OvasApi
+	StatsAPIInstance           *api.StatsApi
+	EsxiVMAPIInstance          *api.EsxiVmApi
+	ImageUploadAPIInstance     *ImageUploadAPI
+	OvaFileAPIInstance         *OvaFileAPI
 }
 
 func NewVmmClient(credentials client.Credentials) (*Client, error) {
 	var baseClient *vmm.ApiClient
 
 	// check if all required fields are present. Else create an empty client
-	if credentials.Username != "" && credentials.Password != "" && credentials.Endpoint != "" {
+	if credentials.HasAuth() && credentials.Endpoint != "" {
 		pcClient := vmm.NewApiClient()
 
-		pcClient.Host = credentials.Endpoint
+		gateway, err := sdkconfig.GatewayFor(credentials)
+		if err != nil {
+			return nil, err
+		}
+		pcClient.Scheme, pcClient.Host, pcClient.Port = gateway.Scheme, gateway.Host, gateway.Port
+		gateway.Configure(pcClient)
 		pcClient.Password = credentials.Password
 		pcClient.Username = credentials.Username
-		pcClient.Port = sdkconfig.DefaultPort
-		if credentials.Port != "" {
-			if p, err := strconv.Atoi(credentials.Port); err == nil {
-				pcClient.Port = p
-			}
-		}
-		pcClient.VerifySSL = false
 		pcClient.AllowVersionNegotiation = sdkconfig.AllowVersionNegotiation
+		if err := sdkconfig.ConfigureAPIKey(pcClient, credentials); err != nil {
+			return nil, err
+		}
 		baseClient = pcClient
 	}
 
+	imageUploadAPI, err := newImageUploadAPI(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	ovaFileAPI, err := newOvaFileAPI(credentials)
+	if err != nil {
+		return nil, err
+	}
+
 	f := &Client{
 		ImagesAPIInstance:          api.NewImagesApi(baseClient),
 		TemplatesAPIInstance:       api.NewTemplatesApi(baseClient),
 		VMAPIInstance:              api.NewVmApi(baseClient),
 		ImagesPlacementAPIInstance: api.NewImagePlacementPoliciesApi(baseClient),
 		OvasAPIInstance:            api.NewOvasApi(baseClient),
+		StatsAPIInstance:           api.NewStatsApi(baseClient),
+		EsxiVMAPIInstance:          api.NewEsxiVmApi(baseClient),
+		ImageUploadAPIInstance:     imageUploadAPI,
+		OvaFileAPIInstance:         ovaFileAPI,
 	}
 
 	return f, nil
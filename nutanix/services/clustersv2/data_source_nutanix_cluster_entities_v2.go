@@ -24,6 +24,11 @@ func DatasourceNutanixClusterEntitiesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -96,7 +101,9 @@ func DatasourceNutanixClusterEntitiesV2Read(ctx context.Context, d *schema.Resou
 		selectQ = nil
 	}
 
-	resp, err := conn.ClusterEntityAPI.ListClusters(page, limit, filter, orderBy, apply, expand, selectQ)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListClustersApiResponse, error) {
+		return conn.ClusterEntityAPI.ListClusters(page, limit, filter, orderBy, apply, expand, selectQ)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching cluster entities : %v", err)
 	}
@@ -24,6 +24,11 @@ func DatasourceNutanixClusterProfilesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -78,7 +83,9 @@ func DatasourceNutanixClusterProfilesV2Read(ctx context.Context, d *schema.Resou
 		selectQ = nil
 	}
 
-	resp, err := conn.ClusterProfilesAPI.ListClusterProfiles(page, limit, filter, orderBy, selectQ)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListClusterProfilesApiResponse, error) {
+		return conn.ClusterProfilesAPI.ListClusterProfiles(page, limit, filter, orderBy, selectQ)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching cluster profiles : %v", err)
 	}
@@ -24,6 +24,11 @@ func DatasourceNutanixHostEntitiesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -87,7 +92,9 @@ func DatasourceNutanixHostEntitiesV2Read(ctx context.Context, d *schema.Resource
 		selectQ = nil
 	}
 
-	resp, err := conn.ClusterEntityAPI.ListHosts(page, limit, filter, orderBy, apply, selectQ)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListHostsApiResponse, error) {
+		return conn.ClusterEntityAPI.ListHosts(page, limit, filter, orderBy, apply, selectQ)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching host entities : %v", err)
 	}
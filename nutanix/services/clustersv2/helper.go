@@ -9,12 +9,10 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/clustermgmt/v4/config"
 	import4 "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/common/v1/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/prism/v4/config"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v4/clusters"
@@ -626,30 +624,12 @@ func UpdateClusterCategories(ctx context.Context, d *schema.ResourceData, meta i
 		taskUUID := TaskRef.ExtId
 
 		// Wait for the categories to be disassociated
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutUpdate),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
-			resourceUUID, _ := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-			if resourceUUID != nil {
-				rUUID := resourceUUID.Data.GetValue().(import2.Task)
-				aJSON, _ = json.MarshalIndent(rUUID, "", "  ")
-				log.Printf("[DEBUG] Error Disassociate Categories from Cluster Task Details: %s", string(aJSON))
-			}
+		taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
+		if errWaitTask != nil {
 			return diag.Errorf("error waiting for categories to be disassociated from cluster (%s): %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 
-		// Get task details
-		taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-		if err != nil {
-			return diag.Errorf("error while fetching disassociate categories from cluster task: %v", err)
-		}
-
-		aJSON, _ = json.MarshalIndent(taskResp, "", "  ")
+		aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 		log.Printf("[DEBUG] Disassociate categories from cluster task details: %s", string(aJSON))
 	}
 
@@ -671,24 +651,12 @@ func UpdateClusterCategories(ctx context.Context, d *schema.ResourceData, meta i
 		taskUUID := TaskRef.ExtId
 
 		// Wait for the categories to be associated
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutUpdate),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
+		if errWaitTask != nil {
 			return diag.Errorf("error waiting for categories to be associated to the cluster (%s): %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 
-		// Get task details
-		taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-		if err != nil {
-			return diag.Errorf("error while fetching associate categories to cluster task: %v", err)
-		}
-
-		aJSON, _ = json.Marshal(taskResp)
+		aJSON, _ = json.Marshal(taskDetails)
 		log.Printf("[DEBUG] Associate categories to cluster task details: %s", string(aJSON))
 	}
 
@@ -6,12 +6,10 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/clustermgmt/v4/config"
 	clustermgmtPrism "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/prism/v4/config"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
@@ -496,21 +494,10 @@ func ResourceNutanixClusterAddNodeV2Create(ctx context.Context, d *schema.Resour
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the node to be added
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for node (%s) to add: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching add node task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Add Node Task Details: %s", string(aJSON))
 
@@ -573,25 +560,10 @@ func ResourceNutanixClusterAddNodeV2Delete(ctx context.Context, d *schema.Resour
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the node to be removed
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
-		taskResp, _ := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-		taskDetails := taskResp.Data.GetValue().(import2.Task)
-		aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
-		log.Printf("[ERROR] Remove Node Task Details: %s", string(aJSON))
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for node (%s) to remove: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching remove node task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Remove Node Task Details: %s", string(aJSON))
 	return nil
@@ -13,13 +13,10 @@ import (
 
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/clustermgmt/v4/config"
 	import4 "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/common/v1/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/prism/v4/config"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v4/clusters"
@@ -381,7 +378,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice(SMTPTypeStrings, false),
+										ValidateFunc: common.ValidateEnum[config.SmtpType](),
 									},
 								},
 							},
@@ -412,7 +409,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice(ManagementServerTypeStrings, false),
+										ValidateFunc: common.ValidateEnum[config.ManagementServerType](),
 									},
 									"is_drs_enabled": {
 										Type:     schema.TypeBool,
@@ -441,7 +438,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice(KeyManagementServerTypeStrings, false),
+							ValidateFunc: common.ValidateEnum[config.KeyManagementServerType](),
 						},
 						"backplane": {
 							Type:     schema.TypeList,
@@ -501,7 +498,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 										Computed: true,
 										Elem: &schema.Schema{
 											Type:         schema.TypeString,
-											ValidateFunc: validation.StringInSlice(HTTPProxyTypeStrings, false),
+											ValidateFunc: common.ValidateEnum[config.HttpProxyType](),
 										},
 									},
 								},
@@ -520,7 +517,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 									"target_type": {
 										Type:         schema.TypeString,
 										Required:     true,
-										ValidateFunc: validation.StringInSlice(HTTPProxyWhiteListTargetStrings, false),
+										ValidateFunc: common.ValidateEnum[config.HttpProxyWhiteListTargetType](),
 									},
 								},
 							},
@@ -579,7 +576,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 							Computed: true,
 							Elem: &schema.Schema{
 								Type:         schema.TypeString,
-								ValidateFunc: validation.StringInSlice(ClusterFunctionStrings, false),
+								ValidateFunc: common.ValidateEnum[config.ClusterFunctionRef](),
 							},
 						},
 						"timezone": {
@@ -664,7 +661,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice(ClusterArchStrings, false),
+							ValidateFunc: common.ValidateEnum[config.ClusterArchReference](),
 						},
 						"fault_tolerance_state": {
 							Type:     schema.TypeList,
@@ -684,7 +681,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice(DomainAwarenessLevelStrings, false),
+										ValidateFunc: common.ValidateEnum[config.DomainAwarenessLevel](),
 									},
 									"current_cluster_fault_tolerance": {
 										Type:     schema.TypeString,
@@ -694,7 +691,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice(ClusterFaultToleranceStrings, false),
+										ValidateFunc: common.ValidateEnum[config.ClusterFaultToleranceRef](),
 									},
 									"redundancy_status": {
 										Type:     schema.TypeList,
@@ -723,7 +720,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice(OperationModeStrings, false),
+							ValidateFunc: common.ValidateEnum[config.OperationMode](),
 						},
 						"is_lts": {
 							Type:     schema.TypeBool,
@@ -734,9 +731,10 @@ func ResourceNutanixClusterV2() *schema.Resource {
 							Computed: true,
 						},
 						"encryption_in_transit_status": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: common.ValidateEnum[config.EncryptionStatus](),
 						},
 						"encryption_option": {
 							Type:     schema.TypeList,
@@ -767,7 +765,7 @@ func ResourceNutanixClusterV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice(PIIScrubbingLevelStrings, false),
+										ValidateFunc: common.ValidateEnum[config.PIIScrubbingLevel](),
 									},
 								},
 							},
@@ -886,21 +884,10 @@ func ResourceNutanixClusterV2Create(ctx context.Context, d *schema.ResourceData,
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the cluster to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for cluster (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching cluster task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Create Cluster Task Details: %s", string(aJSON))
 
@@ -1029,21 +1016,10 @@ func ResourceNutanixClusterV2Update(ctx context.Context, d *schema.ResourceData,
 	taskconn := meta.(*conns.Client).PrismAPI
 
 	// Wait for the cluster to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-	if _, errWait := stateConf.WaitForStateContext(ctx); errWait != nil {
+	taskDetails, errWait := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
+	if errWait != nil {
 		return diag.Errorf("error waiting for cluster (%s) to update: %s", utils.StringValue(taskUUID), errWait)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching cluster update task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Update Cluster Task Details: %s", string(aJSON))
 	log.Printf("[DEBUG] Cluster update completed successfully")
@@ -1105,21 +1081,10 @@ func ResourceNutanixClusterV2Delete(ctx context.Context, d *schema.ResourceData,
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the cluster to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for cluster (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching cluster delete task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Delete Cluster Task Details: %s", string(aJSON))
 	return nil
@@ -1851,22 +1816,11 @@ func handleClusterProfileAssociationUpdate(ctx context.Context, d *schema.Resour
 		taskUUID := TaskRef.ExtId
 
 		// Wait for the cluster profile to be disassociated
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutUpdate),
-		}
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
+		if errWaitTask != nil {
 			return diag.Errorf("error waiting for cluster profile (%s) to disassociate: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 		log.Printf("[DEBUG] Cluster profile disassociation task %s completed", utils.StringValue(taskUUID))
-		// Get UUID from TASK API
-		taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-		if err != nil {
-			return diag.Errorf("error while fetching cluster profile disassociation task: %v", err)
-		}
-		taskDetails := taskResp.Data.GetValue().(import2.Task)
 		aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 		log.Printf("[DEBUG] Disassociate Cluster Profile Task Details: %s", string(aJSON))
 	}
@@ -1883,22 +1837,11 @@ func handleClusterProfileAssociationUpdate(ctx context.Context, d *schema.Resour
 		taskUUID := TaskRef.ExtId
 
 		// Wait for the cluster profile to be associated
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutUpdate),
-		}
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
+		if errWaitTask != nil {
 			return diag.Errorf("error waiting for cluster profile (%s) to associate: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 		log.Printf("[DEBUG] Cluster profile association task %s completed", utils.StringValue(taskUUID))
-		// Get UUID from TASK API
-		taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-		if err != nil {
-			return diag.Errorf("error while fetching cluster profile association task: %v", err)
-		}
-		taskDetails := taskResp.Data.GetValue().(import2.Task)
 		aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 		log.Printf("[DEBUG] Associate Cluster Profile Task Details: %s", string(aJSON))
 	}
@@ -2000,25 +1943,10 @@ func removeNodeFromCluster(ctx context.Context, d *schema.ResourceData, meta int
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the node to be removed
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
-		taskResp, _ := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-		taskDetails := taskResp.Data.GetValue().(import2.Task)
-		aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
-		log.Printf("[ERROR] Remove Node Task Details: %s", string(aJSON))
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for node (%s) to remove: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching node removal task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Remove Node Task Details: %s", string(aJSON))
 	return nil
@@ -2113,21 +2041,10 @@ func expandClusterWithNewNode(ctx context.Context, d *schema.ResourceData, meta
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the node to be added
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for node (%s) to add: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching add node task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Add Node Task Details: %s", string(aJSON))
 	return nil
@@ -2177,21 +2094,10 @@ func fetchNetworkDetailsForNodes(ctx context.Context, d *schema.ResourceData, me
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the fetch node networking details operation to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for fetch node networking details (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask), nil
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching node networking details task: %v", err), nil
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Fetch Node Networking Details Task Details: %s", string(aJSON))
 
@@ -2247,21 +2153,10 @@ func discoverUnconfiguredNode(ctx context.Context, d *schema.ResourceData, meta
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the discover unconfigured nodes operation to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for unconfigured nodes (%s) to discover: %s", utils.StringValue(taskUUID), errWaitTask), nil
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching discover unconfigured nodes task: %v", err), nil
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Discover Unconfigured Nodes Task Details: %s", string(aJSON))
 	uuid := strings.Split(utils.StringValue(taskDetails.ExtId), "=:")[1]
@@ -7,13 +7,11 @@ import (
 	"regexp"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/clustermgmt/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/common/v1/config"
 	import3 "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/prism/v4/config"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
@@ -44,7 +42,7 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 				Computed: true,
 				Elem: &schema.Schema{
 					Type:         schema.TypeString,
-					ValidateFunc: validation.StringInSlice(AllowedOverridesStrings, false),
+					ValidateFunc: common.ValidateEnum[config.ConfigType](),
 				},
 			},
 			"name_server_ip_list": {
@@ -106,7 +104,7 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice(SMTPTypeStrings, false),
+							ValidateFunc: common.ValidateEnum[config.SmtpType](),
 						},
 					},
 				},
@@ -164,7 +162,7 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 									"auth_type": {
 										Type:         schema.TypeString,
 										Required:     true,
-										ValidateFunc: validation.StringInSlice(SnmpAuthTypeStrings, false),
+										ValidateFunc: common.ValidateEnum[config.SnmpAuthType](),
 									},
 									"auth_key": {
 										Type:         schema.TypeString,
@@ -175,7 +173,7 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice(SnmpPrivTypeStrings, false),
+										ValidateFunc: common.ValidateEnum[config.SnmpPrivType](),
 									},
 									"priv_key": {
 										Type:         schema.TypeString,
@@ -195,7 +193,7 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 									"protocol": {
 										Type:         schema.TypeString,
 										Required:     true,
-										ValidateFunc: validation.StringInSlice(SnmpProtocolStrings, false),
+										ValidateFunc: common.ValidateEnum[config.SnmpProtocol](),
 									},
 									"port": {
 										Type:     schema.TypeInt,
@@ -235,7 +233,7 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice(SnmpProtocolStrings, false),
+										ValidateFunc: common.ValidateEnum[config.SnmpProtocol](),
 									},
 									"port": {
 										Type:     schema.TypeInt,
@@ -256,7 +254,7 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 									"version": {
 										Type:         schema.TypeString,
 										Required:     true,
-										ValidateFunc: validation.StringInSlice(SnmpTrapVersionStrings, false),
+										ValidateFunc: common.ValidateEnum[config.SnmpTrapVersion](),
 									},
 									"receiver_name": {
 										Type:         schema.TypeString,
@@ -309,7 +307,7 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 						"network_protocol": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validation.StringInSlice(RsyslogNetworkProtocolStrings, false),
+							ValidateFunc: common.ValidateEnum[config.RsyslogNetworkProtocol](),
 						},
 						"modules": {
 							Type:     schema.TypeList,
@@ -320,12 +318,12 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 									"name": {
 										Type:         schema.TypeString,
 										Required:     true,
-										ValidateFunc: validation.StringInSlice(RsyslogModuleNameStrings, false),
+										ValidateFunc: common.ValidateEnum[config.RsyslogModuleName](),
 									},
 									"log_severity_level": {
 										Type:         schema.TypeString,
 										Required:     true,
-										ValidateFunc: validation.StringInSlice(RsyslogLogSeverityLevelStrings, false),
+										ValidateFunc: common.ValidateEnum[config.RsyslogModuleLogSeverityLevel](),
 									},
 									"should_log_monitor_files": {
 										Type:     schema.TypeBool,
@@ -353,7 +351,7 @@ func ResourceNutanixClusterProfileV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice(PIIScrubbingLevelStrings, false),
+							ValidateFunc: common.ValidateEnum[config.PIIScrubbingLevel](),
 						},
 					},
 				},
@@ -442,26 +440,15 @@ func ResourceNutanixClusterProfileV2Create(ctx context.Context, d *schema.Resour
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the cluster profile to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for cluster profile (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching cluster profile task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Create Cluster Profile Task Details: %s", string(aJSON))
 
 	// Get UUID from TASK API using entity type constant
-	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeClusterProfile, "Cluster profile")
+	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeClusterProfile, "Cluster profile")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -660,21 +647,10 @@ func ResourceNutanixClusterProfileV2Update(ctx context.Context, d *schema.Resour
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the cluster profile to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for cluster profile (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching cluster profile task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Update Cluster Profile Task Details: %s", string(aJSON))
 
@@ -693,21 +669,10 @@ func ResourceNutanixClusterProfileV2Delete(ctx context.Context, d *schema.Resour
 	taskUUID := TaskRef.ExtId
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the cluster profile to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for cluster profile (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching cluster profile delete task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Delete Cluster Profile Task Details: %s", string(aJSON))
 
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nutanix/ntnx-api-golang-clients/datapolicies-go-client/v4/models/datapolicies/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -22,6 +23,11 @@ func DatasourceNutanixProtectionPoliciesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -76,7 +82,9 @@ func DatasourceNutanixProtectionPoliciesV2Read(ctx context.Context, d *schema.Re
 		selects = nil
 	}
 
-	resp, err := conn.ProtectionPolicies.ListProtectionPolicies(page, limit, filter, orderBy, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*config.ListProtectionPoliciesApiResponse, error) {
+		return conn.ProtectionPolicies.ListProtectionPolicies(page, limit, filter, orderBy, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while Listing Protection Policies: %s", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/datapolicies-go-client/v4/models/datapolicies/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -22,6 +23,11 @@ func DataSourceNutanixStoragePoliciesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -79,7 +85,9 @@ func dataSourceNutanixStoragePoliciesV2Read(ctx context.Context, d *schema.Resou
 	} else {
 		selects = nil
 	}
-	resp, err := conn.StoragePolicies.ListStoragePolicies(page, limit, filter, orderBy, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListStoragePoliciesApiResponse, error) {
+		return conn.StoragePolicies.ListStoragePolicies(page, limit, filter, orderBy, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching storage policies: %v", err)
 	}
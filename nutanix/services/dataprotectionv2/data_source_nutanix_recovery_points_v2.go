@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nutanix/ntnx-api-golang-clients/dataprotection-go-client/v4/models/dataprotection/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -23,6 +24,11 @@ func DatasourceNutanixRecoveryPointsV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -88,7 +94,9 @@ func DatasourceNutanixRecoveryPointsV2Read(ctx context.Context, d *schema.Resour
 
 	clusterID := d.Get("cluster_id").(string)
 
-	resp, err := conn.RecoveryPoint.ListRecoveryPoints(&clusterID, page, limit, filter, orderBy, selectQ)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*config.ListRecoveryPointsApiResponse, error) {
+		return conn.RecoveryPoint.ListRecoveryPoints(&clusterID, page, limit, filter, orderBy, selectQ)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching Recovery Points : %v", err)
 	}
@@ -6,11 +6,9 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nutanix/ntnx-api-golang-clients/dataprotection-go-client/v4/models/dataprotection/v4/config"
 	dataprtotectionPrismConfig "github.com/nutanix/ntnx-api-golang-clients/dataprotection-go-client/v4/models/prism/v4/config"
-	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
@@ -69,29 +67,16 @@ func ResourceNutanixRecoveryPointReplicateV2Create(ctx context.Context, d *schem
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the recovery point to be replicated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for recovery point (%s) to replicate: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching replicate recovery point task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
-
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Replicate Recovery Point Task Details: %s", string(aJSON))
 
 	// Set the UUID of the replicated recovery point from completion details
-	values := common.ExtractCompletionDetailsFromTask(taskDetails, utils.CompletionDetailsNameRecoveryPoint)
+	values := taskDetails.CompletionDetails(utils.CompletionDetailsNameRecoveryPoint)
 	if len(values) == 0 {
 		return diag.Errorf("Recovery point not found in task completion details")
 	}
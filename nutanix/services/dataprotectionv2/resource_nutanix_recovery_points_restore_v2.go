@@ -7,11 +7,9 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nutanix/ntnx-api-golang-clients/dataprotection-go-client/v4/models/dataprotection/v4/config"
 	dataprtotectionPrismConfig "github.com/nutanix/ntnx-api-golang-clients/dataprotection-go-client/v4/models/prism/v4/config"
-	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
@@ -115,35 +113,22 @@ func ResourceNutanixRecoveryPointRestoreV2Create(ctx context.Context, d *schema.
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the recovery point restore operation to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for recovery point restore (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching restore recovery point task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
-
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Restore Recovery Point Task Details: %s", string(aJSON))
 
 	// Extract VM and Volume Group ExtIds from completion details
 	vmExtIds := make([]string, 0)
 	vgExtIds := make([]string, 0)
-	vmExtIdsStrs := common.ExtractCompletionDetailsFromTask(taskDetails, utils.CompletionDetailsNameVMExtIDs)
+	vmExtIdsStrs := taskDetails.CompletionDetails(utils.CompletionDetailsNameVMExtIDs)
 	for _, vmExtIdsStr := range vmExtIdsStrs {
 		vmExtIds = append(vmExtIds, strings.Split(vmExtIdsStr, ",")...)
 	}
-	vgExtIdsStrs := common.ExtractCompletionDetailsFromTask(taskDetails, utils.CompletionDetailsNameVGExtIDs)
+	vgExtIdsStrs := taskDetails.CompletionDetails(utils.CompletionDetailsNameVGExtIDs)
 	for _, vgExtIdsStr := range vgExtIdsStrs {
 		vgExtIds = append(vgExtIds, strings.Split(vgExtIdsStr, ",")...)
 	}
@@ -16,6 +16,9 @@ func ResourceNutanixFCAPIKeys() *schema.Resource {
 		CreateContext: resourceNutanixFCAPIKeysCreate,
 		ReadContext:   resourceNutanixFCAPIKeysRead,
 		DeleteContext: resourceNutanixFCAPIKeysDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 		Schema: map[string]*schema.Schema{
 			"alias": {
 				Type:     schema.TypeString,
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/iam-go-client/v4/models/iam/v4/authz"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -25,6 +26,11 @@ func DatasourceNutanixAuthorizationPoliciesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -156,7 +162,9 @@ func DatasourceNutanixAuthorizationPoliciesV2Read(ctx context.Context, d *schema
 		expand = nil
 	}
 
-	resp, err := conn.AuthAPIInstance.ListAuthorizationPolicies(page, limit, filter, orderBy, expand, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListAuthorizationPoliciesApiResponse, error) {
+		return conn.AuthAPIInstance.ListAuthorizationPolicies(page, limit, filter, orderBy, expand, selects)
+	})
 	if err != nil {
 		fmt.Println(err)
 		return diag.Errorf("error while fetching auth policies: %v", err)
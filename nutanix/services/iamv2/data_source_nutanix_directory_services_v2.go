@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/iam-go-client/v4/models/iam/v4/authn"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -25,6 +26,11 @@ func DatasourceNutanixDirectoryServicesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -200,7 +206,9 @@ func DatasourceNutanixDirectoryServicesV2Read(ctx context.Context, d *schema.Res
 		selects = nil
 	}
 
-	resp, err := conn.DirectoryServiceAPIInstance.ListDirectoryServices(page, limit, filter, orderBy, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListDirectoryServicesApiResponse, error) {
+		return conn.DirectoryServiceAPIInstance.ListDirectoryServices(page, limit, filter, orderBy, selects)
+	})
 	if err != nil {
 		fmt.Println(err)
 		var errordata map[string]interface{}
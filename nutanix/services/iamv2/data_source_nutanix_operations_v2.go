@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/iam-go-client/v4/models/iam/v4/authz"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -23,6 +24,11 @@ func DatasourceNutanixOperationsV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -139,7 +145,9 @@ func DatasourceNutanixOperationsV4Read(ctx context.Context, d *schema.ResourceDa
 		selects = nil
 	}
 
-	resp, err := conn.OperationsAPIInstance.ListOperations(page, limit, filter, orderBy, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListOperationsApiResponse, error) {
+		return conn.OperationsAPIInstance.ListOperations(page, limit, filter, orderBy, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching operations : %v", err)
 	}
@@ -9,6 +9,7 @@ import (
 	iamResponse "github.com/nutanix/ntnx-api-golang-clients/iam-go-client/v4/models/common/v1/response"
 	iamConfig "github.com/nutanix/ntnx-api-golang-clients/iam-go-client/v4/models/iam/v4/authz"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -27,6 +28,11 @@ func DatasourceNutanixRolesV2() *schema.Resource {
 				Type:        schema.TypeInt,
 				Optional:    true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -183,7 +189,9 @@ func DatasourceNutanixRolesV2Read(ctx context.Context, d *schema.ResourceData, m
 		selects = nil
 	}
 
-	resp, err := conn.RolesAPIInstance.ListRoles(page, limit, filter, orderBy, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*iamConfig.ListRolesApiResponse, error) {
+		return conn.RolesAPIInstance.ListRoles(page, limit, filter, orderBy, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching roles: %v", err)
 	}
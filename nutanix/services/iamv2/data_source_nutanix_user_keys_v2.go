@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -29,6 +30,11 @@ func DatasourceNutanixUserKeysV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -86,7 +92,9 @@ func DataSourceNutanixUserKeysV2Read(ctx context.Context, d *schema.ResourceData
 	} else {
 		selects = nil
 	}
-	resp, err := conn.UsersAPIInstance.ListUserKeys(userExtID, page, limit, filter, orderBy, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import3.ListUserKeysApiResponse, error) {
+		return conn.UsersAPIInstance.ListUserKeys(userExtID, page, limit, filter, orderBy, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching the user keys: %v", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	iamConfig "github.com/nutanix/ntnx-api-golang-clients/iam-go-client/v4/models/iam/v4/authn"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -23,6 +24,11 @@ func DatasourceNutanixUsersV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -236,7 +242,9 @@ func datasourceNutanixUsersV2Read(ctx context.Context, d *schema.ResourceData, m
 		selects = nil
 	}
 
-	resp, err := conn.UsersAPIInstance.ListUsers(page, limit, filter, orderBy, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*iamConfig.ListUsersApiResponse, error) {
+		return conn.UsersAPIInstance.ListUsers(page, limit, filter, orderBy, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching users : %v", err)
 	}
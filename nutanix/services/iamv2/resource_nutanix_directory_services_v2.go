@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/iam-go-client/v4/models/iam/v4/authn"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -43,8 +44,9 @@ func ResourceNutanixDirectoryServicesV2() *schema.Resource {
 				Required: true,
 			},
 			"directory_type": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: common.ValidateEnum[import1.DirectoryType](),
 			},
 			"service_account": {
 				Type:     schema.TypeList,
@@ -117,9 +119,10 @@ func ResourceNutanixDirectoryServicesV2() *schema.Resource {
 				},
 			},
 			"group_search_type": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: common.ValidateEnum[import1.GroupSearchType](),
 			},
 			"white_listed_groups": {
 				Type:     schema.TypeList,
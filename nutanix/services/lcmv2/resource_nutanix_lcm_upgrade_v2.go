@@ -6,12 +6,10 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/nutanix/ntnx-api-golang-clients/lifecycle-go-client/v4/models/lifecycle/v4/common"
 	taskRef "github.com/nutanix/ntnx-api-golang-clients/lifecycle-go-client/v4/models/prism/v4/config"
-	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	commonUtils "github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
@@ -74,16 +72,18 @@ func ResourceLcmUpgradeV2() *schema.Resource {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Schema{
-					Description: "List of String",
-					Type:        schema.TypeString,
+					Description:  "List of String",
+					Type:         schema.TypeString,
+					ValidateFunc: commonUtils.ValidateEnum[common.SystemAutoMgmtFlag](),
 				},
 			},
 			"auto_handle_flags": {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Schema{
-					Description: "List of String",
-					Type:        schema.TypeString,
+					Description:  "List of String",
+					Type:         schema.TypeString,
+					ValidateFunc: commonUtils.ValidateEnum[common.SystemAutoMgmtFlag](),
 				},
 			},
 			"max_wait_time_in_secs": {
@@ -135,23 +135,11 @@ func ResourceLcmUpgradeV2Create(ctx context.Context, d *schema.ResourceData, met
 	taskconn := meta.(*conns.Client).PrismAPI
 
 	// Wait for the LCM upgrade to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"QUEUED", "RUNNING", "PENDING"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: commonUtils.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := commonUtils.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for LCM upgrade (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get task details from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching LCM upgrade task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] LCM Upgrade Task Details: %s", string(aJSON))
 
@@ -223,16 +211,5 @@ func expandEntityUpdateSpecs(entityUpdateSpec []interface{}) []common.EntityUpda
 }
 
 func expandSystemAutoMgmtFlag(flags []interface{}) []common.SystemAutoMgmtFlag {
-	if len(flags) == 0 {
-		return nil
-	}
-
-	systemAutoMgmtFlags := make([]common.SystemAutoMgmtFlag, 0)
-	for _, flag := range flags {
-		if flag == "POWER_OFF_UVMS" {
-			p := common.SYSTEMAUTOMGMTFLAG_POWER_OFF_UVMS
-			systemAutoMgmtFlags = append(systemAutoMgmtFlags, p)
-		}
-	}
-	return systemAutoMgmtFlags
+	return commonUtils.ExpandEnumList[common.SystemAutoMgmtFlag](flags)
 }
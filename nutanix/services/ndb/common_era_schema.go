@@ -2,7 +2,9 @@ package ndb
 
 import (
 	"context"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	era "github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v3/era"
@@ -616,3 +618,17 @@ func expandIPInfos(pr []interface{}) []*era.IPInfos {
 	}
 	return nil
 }
+
+// splitImportID splits an import id of the form part1/part2, format is the expected form used in the error
+func splitImportID(importID, format string) ([]string, error) {
+	parts := strings.Split(importID, "/")
+	if len(parts) != strings.Count(format, "/")+1 {
+		return nil, fmt.Errorf("invalid import id (%q), expected %s", importID, format)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("invalid import id (%q), expected %s", importID, format)
+		}
+	}
+	return parts, nil
+}
@@ -18,6 +18,9 @@ func ResourceNutanixNDBLinkedDB() *schema.Resource {
 		ReadContext:   resourceNutanixNDBLinkedDBRead,
 		UpdateContext: resourceNutanixNDBLinkedDBUpdate,
 		DeleteContext: resourceNutanixNDBLinkedDBDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNutanixNDBLinkedDBImport,
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(EraProvisionTimeout),
 			Delete: schema.DefaultTimeout(EraProvisionTimeout),
@@ -334,3 +337,16 @@ func flattenLinkedDBInfo(pr era.Info) []interface{} {
 	res = append(res, info)
 	return res
 }
+
+// resourceNutanixNDBLinkedDBImport imports a linked database by <database_id>/<linked_database_id>
+func resourceNutanixNDBLinkedDBImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportID(d.Id(), "<database_id>/<linked_database_id>")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("database_id", parts[0]); err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
@@ -18,6 +18,9 @@ func ResourceNutanixNDBNetwork() *schema.Resource {
 		ReadContext:   resourceNutanixNDBNetworkRead,
 		UpdateContext: resourceNutanixNDBNetworkUpdate,
 		DeleteContext: resourceNutanixNDBNetworkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
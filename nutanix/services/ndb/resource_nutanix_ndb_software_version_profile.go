@@ -22,6 +22,9 @@ func ResourceNutanixNDBSoftwareVersionProfile() *schema.Resource {
 		ReadContext:   resourceNutanixNDBSoftwareVersionProfileRead,
 		UpdateContext: resourceNutanixNDBSoftwareVersionProfileUpdate,
 		DeleteContext: resourceNutanixNDBSoftwareVersionProfileDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNutanixNDBSoftwareVersionProfileImport,
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(SoftwareVersionProfileTimeout),
 		},
@@ -421,3 +424,16 @@ func resourceNutanixNDBSoftwareVersionProfileDelete(ctx context.Context, d *sche
 	}
 	return nil
 }
+
+// resourceNutanixNDBSoftwareVersionProfileImport imports a software profile version by <profile_id>/<version_id>
+func resourceNutanixNDBSoftwareVersionProfileImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportID(d.Id(), "<profile_id>/<version_id>")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("profile_id", parts[0]); err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
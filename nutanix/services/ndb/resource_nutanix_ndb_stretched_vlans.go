@@ -17,6 +17,9 @@ func ResourceNutanixNDBStretchedVlan() *schema.Resource {
 		ReadContext:   resourceNutanixNDBStretchedVlanRead,
 		UpdateContext: resourceNutanixNDBStretchedVlanUpdate,
 		DeleteContext: resourceNutanixNDBStretchedVlanDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNutanixNDBStretchedVlanImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -296,6 +299,24 @@ func resourceNutanixNDBStretchedVlanDelete(ctx context.Context, d *schema.Resour
 	return nil
 }
 
+// resourceNutanixNDBStretchedVlanImport imports a stretched vlan by its id, vlan_ids are the ids of its vlans
+func resourceNutanixNDBStretchedVlanImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*conns.Client).Era
+
+	resp, err := conn.Service.GetStretchedVlan(ctx, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	vlanIDs := make([]string, 0, len(resp.Vlans))
+	for _, vlan := range resp.Vlans {
+		vlanIDs = append(vlanIDs, utils.StringValue(vlan.ID))
+	}
+	if err := d.Set("vlan_ids", vlanIDs); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func flattenStretchedVlans(net []*era.NetworkIntentResponse) []interface{} {
 	if len(net) > 0 {
 		netList := make([]interface{}, len(net))
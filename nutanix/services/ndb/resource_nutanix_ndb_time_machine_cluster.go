@@ -18,6 +18,9 @@ func ResourceNutanixNDBTmsCluster() *schema.Resource {
 		ReadContext:   resourceNutanixNDBTmsClusterRead,
 		UpdateContext: resourceNutanixNDBTmsClusterUpdate,
 		DeleteContext: resourceNutanixNDBTmsClusterDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNutanixNDBTmsClusterImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"time_machine_id": {
 				Type:     schema.TypeString,
@@ -233,3 +236,34 @@ func resourceNutanixNDBTmsClusterDelete(ctx context.Context, d *schema.ResourceD
 	}
 	return nil
 }
+
+// resourceNutanixNDBTmsClusterImport imports a time machine cluster by <time_machine_id>/<nx_cluster_id>
+func resourceNutanixNDBTmsClusterImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*conns.Client).Era
+
+	parts, err := splitImportID(d.Id(), "<time_machine_id>/<nx_cluster_id>")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := conn.Service.ReadTimeMachineCluster(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("time_machine_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("nx_cluster_id", parts[1]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("sla_id", resp.SLAID); err != nil {
+		return nil, err
+	}
+	// the id of a time machine cluster is generated on create
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
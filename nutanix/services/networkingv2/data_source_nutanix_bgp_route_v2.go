@@ -0,0 +1,171 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func DataSourceNutanixBgpRouteV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceNutanixBgpRouteV2Read,
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"bgp_session_ext_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bgp_route_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bgp_session_reference": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"destination": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipv4": SchemaForValueRequiredPrefixLengthRequired(),
+						"ipv6": SchemaForValueRequiredPrefixLengthRequired(),
+					},
+				},
+			},
+			"next_hop": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"next_hop_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"next_hop_reference": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"next_hop_ip_address": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ipv4": SchemaForValueRequiredPrefixLength(),
+									"ipv6": SchemaForValueRequiredPrefixLength(),
+								},
+							},
+						},
+						"next_hop_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"bgp_communities": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"autonomous_system_number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"community_value": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+func DataSourceNutanixBgpRouteV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	extID := d.Get("ext_id").(string)
+	sessionExtID := d.Get("bgp_session_ext_id").(string)
+
+	resp, err := conn.BgpRouteAPIInstance.GetRouteForBgpSessionById(utils.StringPtr(extID), utils.StringPtr(sessionExtID))
+	if err != nil {
+		return diag.Errorf("error while fetching BGP route : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.BgpRoute)
+
+	for attr, value := range flattenBgpRoute(getResp) {
+		if attr == "ext_id" {
+			continue
+		}
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(utils.StringValue(getResp.ExtId))
+	return nil
+}
+
+func flattenBgpRoute(pr import1.BgpRoute) map[string]interface{} {
+	route := map[string]interface{}{
+		"ext_id":                pr.ExtId,
+		"name":                  pr.Name,
+		"description":           pr.Description,
+		"bgp_session_reference": pr.BgpSessionReference,
+		"destination":           flattenDestination(pr.Destination),
+		"next_hop":              flattenNextHop(pr.Nexthop),
+		"bgp_communities":       flattenBgpCommunities(pr.BgpCommunities),
+		"links":                 flattenLinks(pr.Links),
+		"tenant_id":             pr.TenantId,
+		"metadata":              flattenMetadata(pr.Metadata),
+	}
+	if pr.BgpRouteType != nil {
+		route["bgp_route_type"] = pr.BgpRouteType.GetName()
+	}
+	return route
+}
@@ -0,0 +1,107 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func DataSourceNutanixBgpRoutesV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceNutanixBgpRoutesV2Read,
+		Schema: map[string]*schema.Schema{
+			"bgp_session_ext_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"page": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order_by": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"bgp_routes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     DataSourceNutanixBgpRouteV2(),
+			},
+		},
+	}
+}
+
+func DataSourceNutanixBgpRoutesV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	sessionExtID := d.Get("bgp_session_ext_id").(string)
+
+	// initialize query params
+	var filter, orderBy *string
+	var page, limit *int
+
+	if pagef, ok := d.GetOk("page"); ok {
+		page = utils.IntPtr(pagef.(int))
+	}
+	if limitf, ok := d.GetOk("limit"); ok {
+		limit = utils.IntPtr(limitf.(int))
+	}
+	if filterf, ok := d.GetOk("filter"); ok {
+		filter = utils.StringPtr(filterf.(string))
+	}
+	if order, ok := d.GetOk("order_by"); ok {
+		orderBy = utils.StringPtr(order.(string))
+	}
+
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListBgpRoutesApiResponse, error) {
+		return conn.BgpRouteAPIInstance.ListRoutesByBgpSessionId(utils.StringPtr(sessionExtID), page, limit, filter, orderBy)
+	})
+	if err != nil {
+		return diag.Errorf("error while fetching BGP routes : %v", err)
+	}
+
+	if resp.Data == nil {
+		if err := d.Set("bgp_routes", []map[string]interface{}{}); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(resource.UniqueId())
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "🫙 No data found.",
+			Detail:   "The API returned an empty list of BGP routes.",
+		}}
+	}
+
+	getResp := resp.Data.GetValue().([]import1.BgpRoute)
+	routes := make([]map[string]interface{}, len(getResp))
+	for i, v := range getResp {
+		routes[i] = flattenBgpRoute(v)
+	}
+	if err := d.Set("bgp_routes", routes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.UniqueId())
+	return nil
+}
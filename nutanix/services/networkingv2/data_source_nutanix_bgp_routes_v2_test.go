@@ -0,0 +1,98 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const datasourceNameBgpRoutes = "data.nutanix_bgp_routes_v2.test"
+
+func TestAccV2NutanixBgpRoutesDataSource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-bgp-session-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBgpRoutesDataSourceConfig(name, vlanID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(datasourceNameBgpRoutes, "bgp_routes.#"),
+					resource.TestCheckResourceAttrPair(datasourceNameBgpRoutes, "bgp_session_ext_id", resourceNameBgpSession, "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccV2NutanixBgpRoutesDataSource_WithFilter(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-bgp-session-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBgpRoutesDataSourceWithFilterConfig(name, vlanID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(datasourceNameBgpRoutes, "bgp_routes.#"),
+					checkAttributeLength(datasourceNameBgpRoutes, "bgp_routes", 1),
+					resource.TestCheckResourceAttr(datasourceNameBgpRoutes, "bgp_routes.0.bgp_route_type", "ADVERTISED"),
+					resource.TestCheckResourceAttr(datasourceNameBgpRoutes, "bgp_routes.0.destination.0.ipv4.0.ip.0.value", "172.16.0.0"),
+				),
+			},
+		},
+	})
+}
+
+func testBgpRoutesDataSourceConfig(name string, vlanID int) string {
+	return testBgpSessionConfig(name, vlanID, 150) + `
+	data "nutanix_bgp_routes_v2" "test" {
+		bgp_session_ext_id = nutanix_bgp_session_v2.test.id
+	}
+`
+}
+
+func testBgpRoutesDataSourceWithFilterConfig(name string, vlanID int) string {
+	return testBgpSessionConfig(name, vlanID, 150) + `
+	data "nutanix_bgp_routes_v2" "test" {
+		bgp_session_ext_id = nutanix_bgp_session_v2.test.id
+		filter = "bgpRouteType eq Networking.Config.BgpRouteType'ADVERTISED'"
+	}
+`
+}
+
+func TestUnitV2NutanixBgpRoutesDatasource_NoRoutes(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(mockpc.Collection{
+		Path:       "networking/config/bgp-sessions/session-1/bgp-routes",
+		ObjectType: "networking.v4.config.BgpRoute",
+	})
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	// a session which is not established has no routes, it is not an error
+	ds := networkingv2.DataSourceNutanixBgpRoutesV2()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"bgp_session_ext_id": "session-1"})
+	diags := ds.ReadContext(context.Background(), d, meta)
+	if diags.HasError() || len(diags) != 1 {
+		t.Fatalf("read: %v, expected a no data warning", diags)
+	}
+	if got := d.Get("bgp_routes.#"); got != 0 || d.Id() == "" {
+		t.Errorf("bgp_routes.# = %v, id = %q, expected no routes", got, d.Id())
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -23,6 +24,11 @@ func DatasourceNutanixFloatingIPsV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -223,7 +229,9 @@ func datasourceNutanixFloatingIPsV2Read(ctx context.Context, d *schema.ResourceD
 		expand = nil
 	}
 
-	resp, err := conn.FloatingIPAPIInstance.ListFloatingIps(page, limit, filter, orderBy, expand)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListFloatingIpsApiResponse, error) {
+		return conn.FloatingIPAPIInstance.ListFloatingIps(page, limit, filter, orderBy, expand)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching floating_ips : %v", err)
 	}
@@ -0,0 +1,132 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// related entity types and sites of a Layer2 stretch
+const (
+	stretchEntitySubnet        = "SUBNET"
+	stretchEntityVpnConnection = "VPN_CONNECTION"
+	stretchEntityGateway       = "GATEWAY"
+	stretchEntityPrismCentral  = "PRISM_CENTRAL"
+
+	stretchSiteLocal  = "LOCAL"
+	stretchSiteRemote = "REMOTE"
+)
+
+func DataSourceNutanixLayer2StretchRelatedEntitiesV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceNutanixLayer2StretchRelatedEntitiesV2Read,
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"connection_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"stretch_status": schemaForStretchStatus(),
+			"related_entities": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ext_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"entity_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"site": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DataSourceNutanixLayer2StretchRelatedEntitiesV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	extID := d.Get("ext_id").(string)
+	resp, err := conn.Layer2StretchAPIInstance.GetLayer2StretchById(utils.StringPtr(extID))
+	if err != nil {
+		return diag.Errorf("error while fetching Layer2 stretch : %v", err)
+	}
+
+	stretch := resp.Data.GetValue().(import1.Layer2Stretch)
+
+	entities := make([]map[string]interface{}, 0)
+	addEntity := func(ref *string, entityType, site string) {
+		if utils.StringValue(ref) == "" {
+			return
+		}
+		entities = append(entities, map[string]interface{}{
+			"ext_id":      utils.StringValue(ref),
+			"entity_type": entityType,
+			"site":        site,
+		})
+	}
+
+	isVpn := stretch.ConnectionType != nil && *stretch.ConnectionType == import1.STRETCHCONNECTIONTYPE_VPN
+	connectionType := stretchEntityGateway
+	if isVpn {
+		connectionType = stretchEntityVpnConnection
+	}
+
+	if local := stretch.LocalSiteParams; local != nil {
+		addEntity(local.StretchSubnetReference, stretchEntitySubnet, stretchSiteLocal)
+		addEntity(local.ConnectionReference, connectionType, stretchSiteLocal)
+		// the gateways of the local VPN connection are managed on this Prism Central as well
+		if isVpn && utils.StringValue(local.ConnectionReference) != "" {
+			vpnResp, err := conn.VpnConnectionAPIInstance.GetVpnConnectionById(local.ConnectionReference)
+			if err != nil {
+				return diag.Errorf("error while fetching VPN connection of Layer2 stretch : %v", err)
+			}
+			vpnConnection := vpnResp.Data.GetValue().(import1.VpnConnection)
+			addEntity(vpnConnection.LocalGatewayReference, stretchEntityGateway, stretchSiteLocal)
+			addEntity(vpnConnection.RemoteGatewayReference, stretchEntityGateway, stretchSiteLocal)
+		}
+		addEntity(local.PcClusterReference, stretchEntityPrismCentral, stretchSiteLocal)
+	}
+	if remote := stretch.RemoteSiteParams; remote != nil {
+		addEntity(remote.StretchSubnetReference, stretchEntitySubnet, stretchSiteRemote)
+		addEntity(remote.ConnectionReference, connectionType, stretchSiteRemote)
+		addEntity(remote.PcClusterReference, stretchEntityPrismCentral, stretchSiteRemote)
+	}
+
+	if err := d.Set("name", stretch.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if stretch.ConnectionType != nil {
+		if err := d.Set("connection_type", stretch.ConnectionType.GetName()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("stretch_status", flattenStretchStatus(stretch.StretchStatus)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("related_entities", entities); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(extID)
+	return nil
+}
@@ -0,0 +1,105 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const datasourceNameLayer2StretchRelatedEntities = "data.nutanix_layer2_stretch_related_entities_v2.test"
+
+func TestAccV2NutanixLayer2StretchRelatedEntitiesDatasource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-layer2-stretch-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testLayer2StretchConfig(name, vlanID, 1400) + `
+				data "nutanix_layer2_stretch_related_entities_v2" "test" {
+					ext_id = nutanix_layer2_stretch_v2.test.id
+				}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(datasourceNameLayer2StretchRelatedEntities, "name", name),
+					resource.TestCheckResourceAttr(datasourceNameLayer2StretchRelatedEntities, "connection_type", "VPN"),
+					checkAttributeLength(datasourceNameLayer2StretchRelatedEntities, "related_entities", 6),
+					resource.TestCheckResourceAttrPair(datasourceNameLayer2StretchRelatedEntities, "related_entities.0.ext_id", "nutanix_subnet_v2.stretch", "id"),
+					resource.TestCheckResourceAttr(datasourceNameLayer2StretchRelatedEntities, "related_entities.0.entity_type", "SUBNET"),
+					resource.TestCheckResourceAttr(datasourceNameLayer2StretchRelatedEntities, "related_entities.0.site", "LOCAL"),
+					resource.TestCheckResourceAttrPair(datasourceNameLayer2StretchRelatedEntities, "related_entities.1.ext_id", resourceNameVpnConnection, "id"),
+					resource.TestCheckResourceAttr(datasourceNameLayer2StretchRelatedEntities, "related_entities.1.entity_type", "VPN_CONNECTION"),
+					resource.TestCheckResourceAttrPair(datasourceNameLayer2StretchRelatedEntities, "related_entities.2.ext_id", resourceNameVpnLocalGateway, "id"),
+					resource.TestCheckResourceAttr(datasourceNameLayer2StretchRelatedEntities, "related_entities.2.entity_type", "GATEWAY"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitV2NutanixLayer2StretchRelatedEntitiesDatasource_Vpn(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(layer2Stretches)
+	pc.Register(vpnConnections)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	vpnConnection := pc.Add(vpnConnections.Path, map[string]interface{}{
+		"name":                   "dr-vpn",
+		"localGatewayReference":  "local-gateway",
+		"remoteGatewayReference": "remote-gateway",
+	})
+	stretch := pc.Add(layer2Stretches.Path, map[string]interface{}{
+		"name":           "dr-stretch",
+		"connectionType": "VPN",
+		"localSiteParams": map[string]interface{}{
+			"stretchSubnetReference": "local-subnet",
+			"connectionReference":    vpnConnection,
+		},
+		"remoteSiteParams": map[string]interface{}{
+			"stretchSubnetReference": "remote-subnet",
+			"pcClusterReference":     "remote-pc",
+		},
+	})
+
+	ds := networkingv2.DataSourceNutanixLayer2StretchRelatedEntitiesV2()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"ext_id": stretch})
+	if diags := ds.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	expected := []map[string]interface{}{
+		{"ext_id": "local-subnet", "entity_type": "SUBNET", "site": "LOCAL"},
+		{"ext_id": vpnConnection, "entity_type": "VPN_CONNECTION", "site": "LOCAL"},
+		{"ext_id": "local-gateway", "entity_type": "GATEWAY", "site": "LOCAL"},
+		{"ext_id": "remote-gateway", "entity_type": "GATEWAY", "site": "LOCAL"},
+		{"ext_id": "remote-subnet", "entity_type": "SUBNET", "site": "REMOTE"},
+		{"ext_id": "remote-pc", "entity_type": "PRISM_CENTRAL", "site": "REMOTE"},
+	}
+	entities := d.Get("related_entities").([]interface{})
+	if len(entities) != len(expected) {
+		t.Fatalf("related_entities = %v, expected %v", entities, expected)
+	}
+	for i, entity := range entities {
+		for attr, value := range expected[i] {
+			if got := entity.(map[string]interface{})[attr]; got != value {
+				t.Errorf("related_entities.%d.%s = %v, expected %v", i, attr, got, value)
+			}
+		}
+	}
+	if d.Get("connection_type") != "VPN" || d.Get("name") != "dr-stretch" {
+		t.Errorf("connection_type = %v, name = %v, expected the VPN stretch", d.Get("connection_type"), d.Get("name"))
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -23,6 +24,11 @@ func DatasourceNutanixPbrsV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -386,7 +392,9 @@ func DatasourceNutanixPbrsV2Read(ctx context.Context, d *schema.ResourceData, me
 		orderBy = nil
 	}
 
-	resp, err := conn.RoutingPolicy.ListRoutingPolicies(page, limit, filter, orderBy, nil, nil)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListRoutingPoliciesApiResponse, error) {
+		return conn.RoutingPolicy.ListRoutingPolicies(page, limit, filter, orderBy, nil, nil)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching routing policies : %v", err)
 	}
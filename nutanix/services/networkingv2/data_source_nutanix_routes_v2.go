@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -24,6 +25,11 @@ func DatasourceNutanixRoutesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -77,7 +83,9 @@ func DatasourceNutanixRoutesV2Read(ctx context.Context, d *schema.ResourceData,
 		orderBy = nil
 	}
 
-	resp, err := conn.Routes.ListRoutesByRouteTableId(&routeTableExtID, page, limit, filter, orderBy)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*config.ListRoutesApiResponse, error) {
+		return conn.Routes.ListRoutesByRouteTableId(&routeTableExtID, page, limit, filter, orderBy)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching routes : %v", err)
 	}
@@ -9,6 +9,7 @@ import (
 	import2 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/common/v1/response"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -494,9 +495,10 @@ func DataSourceVPCSchemaV2() map[string]*schema.Schema {
 			Computed: true,
 		},
 		"vpc_type": {
-			Type:     schema.TypeString,
-			Optional: true,
-			Computed: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: common.ValidateEnum[import1.VpcType](),
 		},
 		"links": {
 			Type:     schema.TypeList,
@@ -0,0 +1,225 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func DataSourceNutanixVirtualSwitchV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceNutanixVirtualSwitchV2Read,
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bond_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"mtu": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"is_quick_mode": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"igmp_spec": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"is_snooping_enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"snooping_timeout": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"querier_spec": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"is_querier_enabled": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"vlan_id_list": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeInt,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"clusters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ext_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vlan_identifier": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"gateway_ip_address": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"value": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"prefix_length": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"hosts": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ext_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"host_nics": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"ip_address": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"ip": SchemaForValuePrefixLength(),
+												"prefix_length": {
+													Type:     schema.TypeInt,
+													Computed: true,
+												},
+											},
+										},
+									},
+									"route_table": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"active_uplink": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"internal_bridge_name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"is_default": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"owner_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"has_deployment_error": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"has_update_in_progress": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"has_delete_in_progress": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+func DataSourceNutanixVirtualSwitchV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	extID := d.Get("ext_id")
+	resp, err := conn.VirtualSwitchAPIInstance.GetVirtualSwitchById(utils.StringPtr(extID.(string)), nil)
+	if err != nil {
+		return diag.Errorf("error while fetching virtual switch : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.VirtualSwitch)
+
+	for attr, value := range flattenVirtualSwitchEntity(getResp) {
+		if attr == "ext_id" {
+			continue
+		}
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(utils.StringValue(getResp.ExtId))
+	return nil
+}
@@ -0,0 +1,51 @@
+package networkingv2_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+)
+
+const (
+	datasourceNameVirtualSwitch   = "data.nutanix_virtual_switch_v2.test"
+	datasourceNameVirtualSwitches = "data.nutanix_virtual_switches_v2.test"
+)
+
+func TestAccV2NutanixVirtualSwitchesDatasource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vs-%d", r)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVirtualSwitchesDatasourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					checkAttributeLength(datasourceNameVirtualSwitches, "virtual_switches", 1),
+					resource.TestCheckResourceAttr(datasourceNameVirtualSwitches, "virtual_switches.0.name", name),
+					resource.TestCheckResourceAttrPair(datasourceNameVirtualSwitches, "virtual_switches.0.ext_id", resourceNameVirtualSwitch, "id"),
+					resource.TestCheckResourceAttr(datasourceNameVirtualSwitches, "virtual_switches.0.bond_mode", "ACTIVE_BACKUP"),
+					resource.TestCheckResourceAttrPair(datasourceNameVirtualSwitch, "name", resourceNameVirtualSwitch, "name"),
+					resource.TestCheckResourceAttrPair(datasourceNameVirtualSwitch, "mtu", resourceNameVirtualSwitch, "mtu"),
+					resource.TestCheckResourceAttr(datasourceNameVirtualSwitch, "clusters.0.hosts.0.ext_id", testVars.Networking.VirtualSwitch.HostExtID),
+				),
+			},
+		},
+	})
+}
+
+func testVirtualSwitchesDatasourceConfig(name string) string {
+	return testVirtualSwitchConfig(name, "ACTIVE_BACKUP", 1500) + fmt.Sprintf(`
+	data "nutanix_virtual_switches_v2" "test" {
+		filter = "name eq '%[1]s'"
+		depends_on = [nutanix_virtual_switch_v2.test]
+	}
+
+	data "nutanix_virtual_switch_v2" "test" {
+		ext_id = nutanix_virtual_switch_v2.test.id
+	}
+`, name)
+}
@@ -0,0 +1,263 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func DataSourceNutanixVpnConnectionV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceNutanixVpnConnectionV2Read,
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"local_gateway_reference": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"remote_gateway_reference": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"local_gateway_role": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ipsec_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ike_authentication_algorithm": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_encryption_algorithm": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ike_lifetime_secs": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"ipsec_authentication_algorithm": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipsec_encryption_algorithm": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ipsec_lifetime_secs": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"esp_pfs_dh_group_number": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"local_authentication_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"remote_authentication_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"local_vti_ip":  schemaForGatewayIPAddress(false),
+						"remote_vti_ip": schemaForGatewayIPAddress(false),
+					},
+				},
+			},
+			"dpd_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"operation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"interval_secs": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"timeout_secs": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"qos_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ingress_limit_mbps": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"egress_limit_mbps": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"dynamic_route_priority": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"advertised_prefixes": schemaForVpnPrefixes(false),
+			"learned_prefixes":    schemaForVpnPrefixes(false),
+			"ipsec_tunnel_status": schemaForVpnStatus(),
+			"ebgp_status":         schemaForVpnStatus(),
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+func DataSourceNutanixVpnConnectionV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	extID := d.Get("ext_id")
+	resp, err := conn.VpnConnectionAPIInstance.GetVpnConnectionById(utils.StringPtr(extID.(string)))
+	if err != nil {
+		return diag.Errorf("error while fetching VPN connection : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.VpnConnection)
+
+	for attr, value := range flattenVpnConnection(getResp) {
+		if attr == "ext_id" {
+			continue
+		}
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(utils.StringValue(getResp.ExtId))
+	return nil
+}
+
+func flattenVpnConnection(pr import1.VpnConnection) map[string]interface{} {
+	connection := map[string]interface{}{
+		"ext_id":                   pr.ExtId,
+		"name":                     pr.Name,
+		"description":              pr.Description,
+		"local_gateway_reference":  pr.LocalGatewayReference,
+		"remote_gateway_reference": pr.RemoteGatewayReference,
+		"ipsec_config":             flattenVpnIpsecConfig(pr.IpsecConfig),
+		"dpd_config":               flattenVpnDpdConfig(pr.DpdConfig),
+		"qos_config":               flattenVpnQosConfig(pr.QosConfig),
+		"dynamic_route_priority":   pr.DynamicRoutePriority,
+		"advertised_prefixes":      flattenExternallyRoutablePrefixes(pr.AdvertisedPrefixes),
+		"learned_prefixes":         flattenExternallyRoutablePrefixes(pr.LearnedPrefixes),
+		"ipsec_tunnel_status":      flattenVpnStatus(pr.IpsecTunnelStatus),
+		"ebgp_status":              flattenVpnStatus(pr.EbgpStatus),
+		"links":                    flattenLinks(pr.Links),
+		"tenant_id":                pr.TenantId,
+		"metadata":                 flattenMetadata(pr.Metadata),
+	}
+	if pr.LocalGatewayRole != nil {
+		connection["local_gateway_role"] = pr.LocalGatewayRole.GetName()
+	}
+	return connection
+}
+
+func flattenVpnIpsecConfig(pr *import1.IpsecConfig) []map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+
+	ipsec := map[string]interface{}{
+		"ike_lifetime_secs":        utils.Int64Value(pr.IkeLifetimeSecs),
+		"ipsec_lifetime_secs":      utils.Int64Value(pr.IpsecLifetimeSecs),
+		"esp_pfs_dh_group_number":  pr.EspPfsDhGroupNumber,
+		"local_authentication_id":  pr.LocalAuthenticationId,
+		"remote_authentication_id": pr.RemoteAuthenticationId,
+		"local_vti_ip":             flattenIPAddress(pr.LocalVtiIp),
+		"remote_vti_ip":            flattenIPAddress(pr.RemoteVtiIp),
+	}
+	if pr.IkeAuthenticationAlgorithm != nil {
+		ipsec["ike_authentication_algorithm"] = pr.IkeAuthenticationAlgorithm.GetName()
+	}
+	if pr.IkeEncryptionAlgorithm != nil {
+		ipsec["ike_encryption_algorithm"] = pr.IkeEncryptionAlgorithm.GetName()
+	}
+	if pr.IpsecAuthenticationAlgorithm != nil {
+		ipsec["ipsec_authentication_algorithm"] = pr.IpsecAuthenticationAlgorithm.GetName()
+	}
+	if pr.IpsecEncryptionAlgorithm != nil {
+		ipsec["ipsec_encryption_algorithm"] = pr.IpsecEncryptionAlgorithm.GetName()
+	}
+	return []map[string]interface{}{ipsec}
+}
+
+func flattenVpnDpdConfig(pr *import1.DpdConfig) []map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+
+	dpd := map[string]interface{}{
+		"interval_secs": utils.Int64Value(pr.IntervalSecs),
+		"timeout_secs":  utils.Int64Value(pr.TimeoutSecs),
+	}
+	if pr.Operation != nil {
+		dpd["operation"] = pr.Operation.GetName()
+	}
+	return []map[string]interface{}{dpd}
+}
+
+func flattenVpnQosConfig(pr *import1.QosConfig) []map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+	return []map[string]interface{}{{
+		"ingress_limit_mbps": utils.Int64Value(pr.IngressLimitMbps),
+		"egress_limit_mbps":  utils.Int64Value(pr.EgressLimitMbps),
+	}}
+}
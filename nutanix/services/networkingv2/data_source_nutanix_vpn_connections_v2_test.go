@@ -0,0 +1,53 @@
+package networkingv2_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+)
+
+const (
+	datasourceNameVpnConnection  = "data.nutanix_vpn_connection_v2.test"
+	datasourceNameVpnConnections = "data.nutanix_vpn_connections_v2.test"
+)
+
+func TestAccV2NutanixVpnConnectionsDatasource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vpn-connection-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVpnConnectionsDatasourceConfig(name, vlanID),
+				Check: resource.ComposeTestCheckFunc(
+					checkAttributeLength(datasourceNameVpnConnections, "vpn_connections", 1),
+					resource.TestCheckResourceAttr(datasourceNameVpnConnections, "vpn_connections.0.name", name),
+					resource.TestCheckResourceAttrPair(datasourceNameVpnConnections, "vpn_connections.0.ext_id", resourceNameVpnConnection, "id"),
+					resource.TestCheckResourceAttr(datasourceNameVpnConnections, "vpn_connections.0.local_gateway_role", "INITIATOR"),
+					resource.TestCheckResourceAttrPair(datasourceNameVpnConnection, "name", resourceNameVpnConnection, "name"),
+					resource.TestCheckResourceAttrPair(datasourceNameVpnConnection, "local_gateway_reference", resourceNameVpnLocalGateway, "id"),
+					resource.TestCheckResourceAttr(datasourceNameVpnConnection, "ipsec_config.0.ike_encryption_algorithm", "AES256"),
+					resource.TestCheckResourceAttr(datasourceNameVpnConnection, "advertised_prefixes.0.ipv4.0.prefix_length", "16"),
+				),
+			},
+		},
+	})
+}
+
+func testVpnConnectionsDatasourceConfig(name string, vlanID int) string {
+	return testVpnConnectionConfig(name, vlanID, false, 150) + fmt.Sprintf(`
+	data "nutanix_vpn_connections_v2" "test" {
+		filter = "name eq '%[1]s'"
+		depends_on = [nutanix_vpn_connection_v2.test]
+	}
+
+	data "nutanix_vpn_connection_v2" "test" {
+		ext_id = nutanix_vpn_connection_v2.test.id
+	}
+`, name)
+}
@@ -0,0 +1,290 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func DataSourceNutanixVpnGatewayV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceNutanixVpnGatewayV2Read,
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vpc_reference": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"gateway_device_vendor": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"deployment": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_reference": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"should_synchronize_system_dns_servers": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"should_synchronize_system_ntp_servers": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"vcenter_datastore_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"interfaces": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"subnet_reference": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"ip_address":              schemaForGatewayIPAddress(false),
+									"default_gateway_address": schemaForGatewayIPAddress(false),
+									"mtu": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"mac_address": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"local_vpn_service": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ebgp_config": dataSourceSchemaForVpnEbgpConfig(),
+					},
+				},
+			},
+			"remote_vpn_service": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_address": schemaForGatewayIPAddress(false),
+						"ebgp_config":     dataSourceSchemaForVpnEbgpConfig(),
+						"should_install_xi_route": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"service_address": schemaForGatewayIPAddress(false),
+			"status":          schemaForVpnStatus(),
+			"installed_software_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"supported_software_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vm_reference": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSchemaForVpnEbgpConfig() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"asn": {
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"should_redistribute_routes": {
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func DataSourceNutanixVpnGatewayV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	extID := d.Get("ext_id")
+	resp, err := conn.GatewayAPIInstance.GetGatewayById(utils.StringPtr(extID.(string)))
+	if err != nil {
+		return diag.Errorf("error while fetching VPN gateway : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.Gateway)
+
+	for attr, value := range flattenVpnGateway(getResp) {
+		if attr == "ext_id" {
+			continue
+		}
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(utils.StringValue(getResp.ExtId))
+	return nil
+}
+
+func flattenVpnGateway(pr import1.Gateway) map[string]interface{} {
+	localVpn, remoteVpn, serviceAddress := flattenVpnGatewayServices(pr.Services)
+
+	return map[string]interface{}{
+		"ext_id":                     pr.ExtId,
+		"name":                       pr.Name,
+		"description":                pr.Description,
+		"vpc_reference":              pr.VpcReference,
+		"gateway_device_vendor":      pr.GatewayDeviceVendor,
+		"deployment":                 flattenGatewayDeployment(pr.Deployment),
+		"local_vpn_service":          localVpn,
+		"remote_vpn_service":         remoteVpn,
+		"service_address":            serviceAddress,
+		"status":                     flattenVpnStatus(pr.Status),
+		"installed_software_version": pr.InstalledSoftwareVersion,
+		"supported_software_version": pr.SupportedSoftwareVersion,
+		"vm_reference":               pr.VmReference,
+		"links":                      flattenLinks(pr.Links),
+		"tenant_id":                  pr.TenantId,
+		"metadata":                   flattenMetadata(pr.Metadata),
+	}
+}
+
+// flattenVpnGatewayServices returns the local and remote VPN services of a gateway, with the address the
+// local gateway is reachable at
+func flattenVpnGatewayServices(pr *import1.OneOfGatewayServices) (local, remote, serviceAddress []map[string]interface{}) {
+	if pr == nil {
+		return nil, nil, nil
+	}
+
+	switch services := pr.GetValue().(type) {
+	case import1.LocalNetworkServices:
+		if services.LocalVpnService != nil {
+			local = []map[string]interface{}{{
+				"ebgp_config": flattenVpnBgpConfig(services.LocalVpnService.EbgpConfig),
+			}}
+		}
+		serviceAddress = flattenIPAddress(services.ServiceAddress)
+	case import1.RemoteNetworkServices:
+		if services.RemoteVpnService != nil {
+			remote = []map[string]interface{}{{
+				"service_address":         flattenIPAddress(services.RemoteVpnService.ServiceAddress),
+				"ebgp_config":             flattenVpnBgpConfig(services.RemoteVpnService.EbgpConfig),
+				"should_install_xi_route": services.RemoteVpnService.ShouldInstallXiRoute,
+			}}
+		}
+	}
+	return local, remote, serviceAddress
+}
+
+func flattenVpnBgpConfig(pr *import1.BgpConfig) []map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+	return []map[string]interface{}{{
+		"asn":                        utils.Int64Value(pr.Asn),
+		"should_redistribute_routes": pr.ShouldRedistributeRoutes,
+	}}
+}
+
+func flattenGatewayDeployment(pr *import1.GatewayDeployment) []map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+
+	interfaces := make([]map[string]interface{}, len(pr.Interfaces))
+	for i, v := range pr.Interfaces {
+		interfaces[i] = map[string]interface{}{
+			"subnet_reference":        v.SubnetReference,
+			"ip_address":              flattenIPAddress(v.IpAddress),
+			"default_gateway_address": flattenIPAddress(v.DefaultGatewayAddress),
+			"mtu":                     v.Mtu,
+			"mac_address":             v.MacAddress,
+		}
+	}
+
+	return []map[string]interface{}{{
+		"cluster_reference":                     pr.ClusterReference,
+		"should_synchronize_system_dns_servers": pr.ShouldSynchronizeSystemDnsServers,
+		"should_synchronize_system_ntp_servers": pr.ShouldSynchronizeSystemNtpServers,
+		"vcenter_datastore_name":                pr.VcenterDatastoreName,
+		"interfaces":                            interfaces,
+	}}
+}
+
+func flattenVpnStatus(pr *import1.Status) []map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+	status := map[string]interface{}{
+		"message": pr.Message,
+	}
+	if pr.State != nil {
+		status["state"] = pr.State.GetName()
+	}
+	return []map[string]interface{}{status}
+}
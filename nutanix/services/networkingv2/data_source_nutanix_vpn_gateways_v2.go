@@ -0,0 +1,119 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func DataSourceNutanixVpnGatewaysV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DataSourceNutanixVpnGatewaysV2Read,
+		Schema: map[string]*schema.Schema{
+			"page": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order_by": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"expand": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vpn_gateways": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     DataSourceNutanixVpnGatewayV2(),
+			},
+		},
+	}
+}
+
+func DataSourceNutanixVpnGatewaysV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	// initialize query params
+	var filter, orderBy, expand *string
+	var page, limit *int
+
+	if pagef, ok := d.GetOk("page"); ok {
+		page = utils.IntPtr(pagef.(int))
+	}
+	if limitf, ok := d.GetOk("limit"); ok {
+		limit = utils.IntPtr(limitf.(int))
+	}
+	if filterf, ok := d.GetOk("filter"); ok {
+		filter = utils.StringPtr(filterf.(string))
+	}
+	if order, ok := d.GetOk("order_by"); ok {
+		orderBy = utils.StringPtr(order.(string))
+	}
+	if expandf, ok := d.GetOk("expand"); ok {
+		expand = utils.StringPtr(expandf.(string))
+	}
+
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListGatewaysApiResponse, error) {
+		return conn.GatewayAPIInstance.ListGateways(page, limit, filter, orderBy, expand, nil)
+	})
+	if err != nil {
+		return diag.Errorf("error while fetching VPN gateways : %v", err)
+	}
+
+	if resp.Data == nil {
+		if err := d.Set("vpn_gateways", []map[string]interface{}{}); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(resource.UniqueId())
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "🫙 No data found.",
+			Detail:   "The API returned an empty list of VPN gateways.",
+		}}
+	}
+
+	getResp := resp.Data.GetValue().([]import1.Gateway)
+	if err := d.Set("vpn_gateways", flattenVpnGatewaysEntities(getResp)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.UniqueId())
+	return nil
+}
+
+// flattenVpnGatewaysEntities flattens the gateways running a VPN service, the network gateways of the
+// other services (e.g. BGP) are listed by the same API
+func flattenVpnGatewaysEntities(pr []import1.Gateway) []map[string]interface{} {
+	gateways := make([]map[string]interface{}, 0)
+	for _, v := range pr {
+		gateway := flattenVpnGateway(v)
+		if len(gateway["local_vpn_service"].([]map[string]interface{})) == 0 &&
+			len(gateway["remote_vpn_service"].([]map[string]interface{})) == 0 {
+			continue
+		}
+		gateways = append(gateways, gateway)
+	}
+	return gateways
+}
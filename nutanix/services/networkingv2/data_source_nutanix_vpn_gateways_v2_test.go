@@ -0,0 +1,109 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const (
+	datasourceNameVpnGateway  = "data.nutanix_vpn_gateway_v2.test"
+	datasourceNameVpnGateways = "data.nutanix_vpn_gateways_v2.test"
+)
+
+func TestAccV2NutanixVpnGatewaysDatasource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vpn-gateway-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVpnGatewaysDatasourceConfig(name, vlanID),
+				Check: resource.ComposeTestCheckFunc(
+					checkAttributeLength(datasourceNameVpnGateways, "vpn_gateways", 1),
+					resource.TestCheckResourceAttr(datasourceNameVpnGateways, "vpn_gateways.0.name", name),
+					resource.TestCheckResourceAttrPair(datasourceNameVpnGateways, "vpn_gateways.0.ext_id", resourceNameVpnLocalGateway, "id"),
+					resource.TestCheckResourceAttrPair(datasourceNameVpnGateways, "vpn_gateways.0.vpc_reference", "nutanix_vpc_v2.test", "id"),
+					resource.TestCheckResourceAttr(datasourceNameVpnGateways, "vpn_gateways.0.local_vpn_service.#", "1"),
+					resource.TestCheckResourceAttrPair(datasourceNameVpnGateway, "name", resourceNameVpnRemoteGateway, "name"),
+					resource.TestCheckResourceAttr(datasourceNameVpnGateway, "gateway_device_vendor", "Juniper"),
+					resource.TestCheckResourceAttr(datasourceNameVpnGateway, "remote_vpn_service.0.service_address.0.ipv4.0.value", "203.0.113.10"),
+				),
+			},
+		},
+	})
+}
+
+func testVpnGatewaysDatasourceConfig(name string, vlanID int) string {
+	return testVpnGatewayConfig(name, vlanID, false) + fmt.Sprintf(`
+	data "nutanix_vpn_gateways_v2" "test" {
+		filter = "name eq '%[1]s'"
+		depends_on = [nutanix_vpn_gateway_v2.local, nutanix_vpn_gateway_v2.remote]
+	}
+
+	data "nutanix_vpn_gateway_v2" "test" {
+		ext_id = nutanix_vpn_gateway_v2.remote.id
+	}
+`, name)
+}
+
+func TestUnitV2NutanixVpnGatewaysDatasource_OnlyVpnGateways(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(gateways)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	vpnGateway := pc.Add(gateways.Path, map[string]interface{}{
+		"name": "vpn-gateway",
+		"services": map[string]interface{}{
+			"$objectType":     "networking.v4.config.LocalNetworkServices",
+			"localVpnService": map[string]interface{}{},
+			"serviceAddress":  map[string]interface{}{"ipv4": map[string]interface{}{"value": "198.51.100.5"}},
+		},
+	})
+	pc.Add(gateways.Path, map[string]interface{}{
+		"name": "bgp-gateway",
+		"services": map[string]interface{}{
+			"$objectType":     "networking.v4.config.LocalNetworkServices",
+			"localBgpService": map[string]interface{}{"asn": 65010},
+		},
+	})
+
+	ds := networkingv2.DataSourceNutanixVpnGatewaysV2()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{})
+	if diags := ds.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	for attr, expected := range map[string]interface{}{
+		"vpn_gateways.#":                                1,
+		"vpn_gateways.0.ext_id":                         vpnGateway,
+		"vpn_gateways.0.local_vpn_service.#":            1,
+		"vpn_gateways.0.service_address.0.ipv4.0.value": "198.51.100.5",
+	} {
+		if got := d.Get(attr); got != expected {
+			t.Errorf("%s = %v, expected %v", attr, got, expected)
+		}
+	}
+
+	single := networkingv2.DataSourceNutanixVpnGatewayV2()
+	d = schema.TestResourceDataRaw(t, single.Schema, map[string]interface{}{"ext_id": vpnGateway})
+	if diags := single.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != vpnGateway || d.Get("name") != "vpn-gateway" {
+		t.Errorf("id = %s, name = %v, expected the vpn gateway", d.Id(), d.Get("name"))
+	}
+}
@@ -23,6 +23,15 @@ type TestConfig struct {
 				EndIP   string `json:"end_ip"`
 			}
 		}
+		Layer2Stretch struct {
+			RemotePcExtID     string `json:"remote_pc_ext_id"`
+			RemoteSubnetExtID string `json:"remote_subnet_ext_id"`
+		} `json:"layer2_stretch"`
+		VirtualSwitch struct {
+			ClusterExtID string   `json:"cluster_ext_id"`
+			HostExtID    string   `json:"host_ext_id"`
+			HostNics     []string `json:"host_nics"`
+		} `json:"virtual_switch"`
 	} `json:"networking"`
 }
 
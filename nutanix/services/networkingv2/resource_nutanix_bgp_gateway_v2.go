@@ -0,0 +1,397 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func ResourceNutanixBgpGatewayV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixBgpGatewayV2Create,
+		ReadContext:   ResourceNutanixBgpGatewayV2Read,
+		UpdateContext: ResourceNutanixBgpGatewayV2Update,
+		DeleteContext: ResourceNutanixBgpGatewayV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		// a gateway is either deployed locally or describes a remote peer, it can't be switched in place
+		CustomizeDiff: customdiff.ForceNewIfChange("local_bgp_service", func(ctx context.Context, old, new, meta interface{}) bool {
+			return len(old.([]interface{})) != len(new.([]interface{}))
+		}),
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"gateway_device_vendor": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"deployment": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"local_bgp_service"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_reference": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"should_synchronize_system_dns_servers": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"should_synchronize_system_ntp_servers": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"vcenter_datastore_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"interfaces": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"subnet_reference": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"ip_address":              schemaForGatewayIPAddress(false),
+									"default_gateway_address": schemaForGatewayIPAddress(false),
+									"mtu": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Computed: true,
+									},
+									"mac_address": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"local_bgp_service": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"local_bgp_service", "remote_bgp_service"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"asn": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"vpc_reference": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"is_bgp_add_path_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"remote_bgp_service": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": schemaForGatewayIPAddress(true),
+						"asn": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"service_address": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipv4": SchemaForValuePrefixLength(),
+						"ipv6": SchemaForValuePrefixLength(),
+					},
+				},
+			},
+			"status": schemaForVpnStatus(),
+			"installed_software_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"supported_software_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vm_reference": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+func ResourceNutanixBgpGatewayV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	inputSpec := import1.Gateway{}
+	if name, ok := d.GetOk("name"); ok {
+		inputSpec.Name = utils.StringPtr(name.(string))
+	}
+	if desc, ok := d.GetOk("description"); ok {
+		inputSpec.Description = utils.StringPtr(desc.(string))
+	}
+	if vendor, ok := d.GetOk("gateway_device_vendor"); ok {
+		inputSpec.GatewayDeviceVendor = utils.StringPtr(vendor.(string))
+	}
+	if deployment, ok := d.GetOk("deployment"); ok {
+		inputSpec.Deployment = expandGatewayDeployment(deployment.([]interface{}))
+	}
+	services, err := expandBgpGatewayServices(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	inputSpec.Services = services
+
+	resp, err := conn.GatewayAPIInstance.CreateGateway(&inputSpec)
+	if err != nil {
+		return diag.Errorf("error while creating BGP gateway : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the BGP gateway to be created
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
+		return diag.Errorf("error waiting for BGP gateway (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	extID, err := taskDetails.EntityExtID(utils.RelEntityTypeGateway)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(extID)
+	return ResourceNutanixBgpGatewayV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixBgpGatewayV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.GatewayAPIInstance.GetGatewayById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching BGP gateway : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.Gateway)
+
+	for attr, value := range flattenBgpGateway(getResp) {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func ResourceNutanixBgpGatewayV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	readResp, err := conn.GatewayAPIInstance.GetGatewayById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching BGP gateway : %v", err)
+	}
+
+	updateSpec := readResp.Data.GetValue().(import1.Gateway)
+	// Extract E-Tag Header
+	etagValue := conn.GatewayAPIInstance.ApiClient.GetEtag(readResp)
+
+	args := make(map[string]interface{})
+	args["If-Match"] = utils.StringPtr(etagValue)
+
+	if d.HasChange("name") {
+		updateSpec.Name = utils.StringPtr(d.Get("name").(string))
+	}
+	if d.HasChange("description") {
+		updateSpec.Description = utils.StringPtr(d.Get("description").(string))
+	}
+	if d.HasChange("gateway_device_vendor") {
+		updateSpec.GatewayDeviceVendor = utils.StringPtr(d.Get("gateway_device_vendor").(string))
+	}
+	if d.HasChanges("local_bgp_service", "remote_bgp_service") {
+		services, err := expandBgpGatewayServices(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		updateSpec.Services = services
+	}
+
+	resp, err := conn.GatewayAPIInstance.UpdateGatewayById(utils.StringPtr(d.Id()), &updateSpec, args)
+	if err != nil {
+		return diag.Errorf("error while updating BGP gateway : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the BGP gateway to be updated
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for BGP gateway (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return ResourceNutanixBgpGatewayV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixBgpGatewayV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.GatewayAPIInstance.DeleteGatewayById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while deleting BGP gateway : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the BGP gateway to be deleted
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
+		return diag.Errorf("error waiting for BGP gateway (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func expandBgpGatewayServices(d *schema.ResourceData) (*import1.OneOfGatewayServices, error) {
+	services := import1.NewOneOfGatewayServices()
+
+	if local, ok := d.GetOk("local_bgp_service"); ok {
+		val := local.([]interface{})[0].(map[string]interface{})
+		localServices := import1.NewLocalNetworkServices()
+		localServices.LocalBgpService = import1.NewLocalBgpService()
+		localServices.LocalBgpService.Asn = utils.Int64Ptr(int64(val["asn"].(int)))
+		if vpcRef := val["vpc_reference"].(string); vpcRef != "" {
+			localServices.LocalBgpService.VpcReference = utils.StringPtr(vpcRef)
+		}
+		if addPath, ok := val["is_bgp_add_path_enabled"].(bool); ok {
+			localServices.LocalBgpService.IsBgpAddPathEnabled = utils.BoolPtr(addPath)
+		}
+		return services, services.SetValue(*localServices)
+	}
+
+	remoteServices := import1.NewRemoteNetworkServices()
+	remoteServices.RemoteBgpService = import1.NewRemoteBgpService()
+	if remote, ok := d.GetOk("remote_bgp_service"); ok {
+		val := remote.([]interface{})[0].(map[string]interface{})
+		remoteServices.RemoteBgpService.Address = expandIPAddressMap(val["address"])
+		remoteServices.RemoteBgpService.Asn = utils.Int64Ptr(int64(val["asn"].(int)))
+	}
+	return services, services.SetValue(*remoteServices)
+}
+
+func flattenBgpGateway(pr import1.Gateway) map[string]interface{} {
+	var local, remote, serviceAddress []map[string]interface{}
+	if pr.Services != nil {
+		switch services := pr.Services.GetValue().(type) {
+		case import1.LocalNetworkServices:
+			if services.LocalBgpService != nil {
+				local = []map[string]interface{}{{
+					"asn":                     utils.Int64Value(services.LocalBgpService.Asn),
+					"vpc_reference":           services.LocalBgpService.VpcReference,
+					"is_bgp_add_path_enabled": services.LocalBgpService.IsBgpAddPathEnabled,
+				}}
+			}
+			serviceAddress = flattenIPAddress(services.ServiceAddress)
+		case import1.RemoteNetworkServices:
+			if services.RemoteBgpService != nil {
+				remote = []map[string]interface{}{{
+					"address": flattenIPAddress(services.RemoteBgpService.Address),
+					"asn":     utils.Int64Value(services.RemoteBgpService.Asn),
+				}}
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"ext_id":                     pr.ExtId,
+		"name":                       pr.Name,
+		"description":                pr.Description,
+		"gateway_device_vendor":      pr.GatewayDeviceVendor,
+		"deployment":                 flattenGatewayDeployment(pr.Deployment),
+		"local_bgp_service":          local,
+		"remote_bgp_service":         remote,
+		"service_address":            serviceAddress,
+		"status":                     flattenVpnStatus(pr.Status),
+		"installed_software_version": pr.InstalledSoftwareVersion,
+		"supported_software_version": pr.SupportedSoftwareVersion,
+		"vm_reference":               pr.VmReference,
+		"links":                      flattenLinks(pr.Links),
+		"tenant_id":                  pr.TenantId,
+		"metadata":                   flattenMetadata(pr.Metadata),
+	}
+}
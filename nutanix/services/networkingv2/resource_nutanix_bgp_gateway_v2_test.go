@@ -0,0 +1,260 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const (
+	resourceNameBgpLocalGateway  = "nutanix_bgp_gateway_v2.local"
+	resourceNameBgpRemoteGateway = "nutanix_bgp_gateway_v2.remote"
+)
+
+func TestAccV2NutanixBgpGatewayResource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-bgp-gateway-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBgpGatewayConfig(name, vlanID, 65001),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameBgpLocalGateway, "name", name),
+					resource.TestCheckResourceAttr(resourceNameBgpLocalGateway, "local_bgp_service.0.asn", "65001"),
+					resource.TestCheckResourceAttrPair(resourceNameBgpLocalGateway, "local_bgp_service.0.vpc_reference", "nutanix_vpc_v2.test", "id"),
+					resource.TestCheckResourceAttr(resourceNameBgpLocalGateway, "remote_bgp_service.#", "0"),
+					resource.TestCheckResourceAttrSet(resourceNameBgpLocalGateway, "status.#"),
+					resource.TestCheckResourceAttrSet(resourceNameBgpLocalGateway, "links.#"),
+					resource.TestCheckResourceAttr(resourceNameBgpRemoteGateway, "remote_bgp_service.0.asn", "65100"),
+					resource.TestCheckResourceAttr(resourceNameBgpRemoteGateway, "remote_bgp_service.0.address.0.ipv4.0.value", "10.10.10.1"),
+					resource.TestCheckResourceAttr(resourceNameBgpRemoteGateway, "local_bgp_service.#", "0"),
+				),
+			},
+			{
+				Config: testBgpGatewayConfig(name, vlanID, 65002),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameBgpLocalGateway, "name", name),
+					resource.TestCheckResourceAttr(resourceNameBgpLocalGateway, "local_bgp_service.0.asn", "65002"),
+					resource.TestCheckResourceAttrPair(resourceNameBgpLocalGateway, "local_bgp_service.0.vpc_reference", "nutanix_vpc_v2.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccV2NutanixBgpGatewayResource_LocalAndRemoteService(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "nutanix_bgp_gateway_v2" "test" {
+					name = "tf-test-bgp-gateway-invalid"
+					local_bgp_service {
+						asn = 65001
+					}
+					remote_bgp_service {
+						asn = 65100
+						address {
+							ipv4 {
+								value = "10.10.10.1"
+							}
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("only one of `local_bgp_service,remote_bgp_service` can be specified"),
+			},
+		},
+	})
+}
+
+// testAccVpcWithExternalSubnetConfig is a VPC connected to an external VLAN subnet, which the
+// gateways of the VPC are deployed on
+func testAccVpcWithExternalSubnetConfig(vlanID int) string {
+	return fmt.Sprintf(`
+	data "nutanix_clusters_v2" "clusters" {}
+
+	locals {
+		cluster0 =  [
+			  for cluster in data.nutanix_clusters_v2.clusters.cluster_entities :
+			  cluster.ext_id if cluster.config[0].cluster_function[0] != "PRISM_CENTRAL"
+		][0]
+	}
+
+	resource "nutanix_subnet_v2" "external" {
+		name = "tf-test-gateway-subnet-%[1]d"
+		description = "external subnet of the gateways"
+		cluster_reference = local.cluster0
+		subnet_type = "VLAN"
+		network_id = %[1]d
+		is_external = true
+		ip_config {
+			ipv4 {
+				ip_subnet {
+					ip {
+						value = "192.168.0.0"
+					}
+					prefix_length = 24
+				}
+				default_gateway_ip {
+					value = "192.168.0.1"
+				}
+				pool_list{
+					start_ip {
+						value = "192.168.0.20"
+					}
+					end_ip {
+						value = "192.168.0.30"
+					}
+				}
+			}
+		}
+		depends_on = [data.nutanix_clusters_v2.clusters]
+	}
+
+	resource "nutanix_vpc_v2" "test" {
+		name = "tf-test-gateway-vpc-%[1]d"
+		external_subnets {
+			subnet_reference = nutanix_subnet_v2.external.id
+		}
+		externally_routable_prefixes {
+			ipv4 {
+				ip {
+					value = "172.16.0.0"
+				}
+				prefix_length = 16
+			}
+		}
+		depends_on = [nutanix_subnet_v2.external]
+	}
+`, vlanID)
+}
+
+func testBgpGatewayConfig(name string, vlanID, asn int) string {
+	return testAccVpcWithExternalSubnetConfig(vlanID) + fmt.Sprintf(`
+	resource "nutanix_bgp_gateway_v2" "local" {
+		name = "%[1]s"
+		description = "bgp gateway of the vpc"
+		deployment {
+			cluster_reference = local.cluster0
+			interfaces {
+				subnet_reference = nutanix_subnet_v2.external.id
+			}
+		}
+		local_bgp_service {
+			asn = %[2]d
+			vpc_reference = nutanix_vpc_v2.test.id
+		}
+	}
+
+	resource "nutanix_bgp_gateway_v2" "remote" {
+		name = "%[1]s-tor"
+		description = "top of rack router"
+		remote_bgp_service {
+			asn = 65100
+			address {
+				ipv4 {
+					value = "10.10.10.1"
+				}
+			}
+		}
+	}
+`, name, asn)
+}
+
+func TestUnitV2NutanixBgpGatewayResource_ServiceTypeChange(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(gateways)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := networkingv2.ResourceNutanixBgpGatewayV2()
+	local := map[string]interface{}{
+		"name": "tf-bgp-gateway",
+		"local_bgp_service": []interface{}{map[string]interface{}{
+			"asn":           65001,
+			"vpc_reference": "vpc-1",
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, local)
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	gateway, _ := pc.Get(gateways.Path, d.Id())
+	if services := gateway["services"].(map[string]interface{}); services["$objectType"] != "networking.v4.config.LocalNetworkServices" {
+		t.Errorf("services = %v, expected local network services", services)
+	}
+
+	// a local gateway changed to describe a remote peer is replaced
+	remote := map[string]interface{}{
+		"name": "tf-bgp-gateway",
+		"remote_bgp_service": []interface{}{map[string]interface{}{
+			"asn": 65100,
+			"address": []interface{}{map[string]interface{}{
+				"ipv4": []interface{}{map[string]interface{}{"value": "10.0.0.1"}},
+			}},
+		}},
+	}
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(remote), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Errorf("changing a local gateway to a remote one should replace it: %v", diff)
+	}
+
+	// the ASN of a local gateway is updated in place
+	local["local_bgp_service"].([]interface{})[0].(map[string]interface{})["asn"] = 65002
+	diff, err = r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(local), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Errorf("changing the asn should not replace the gateway: %v", diff)
+	}
+}
+
+func TestUnitV2NutanixBgpGatewayResource_CreateTaskFailure(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(gateways)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := networkingv2.ResourceNutanixBgpGatewayV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "tf-bgp-gateway",
+		"local_bgp_service": []interface{}{map[string]interface{}{
+			"asn": 65001,
+		}},
+	})
+	pc.FailNextTask("ASN 65001 is already used by the VPC")
+	diags := r.CreateContext(context.Background(), d, meta)
+	if !diags.HasError() || !regexp.MustCompile("ASN 65001 is already used").MatchString(diags[0].Summary) {
+		t.Errorf("diags = %v, expected the task error", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("id = %q, expected no gateway in the state", d.Id())
+	}
+}
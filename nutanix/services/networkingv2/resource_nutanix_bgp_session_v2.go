@@ -0,0 +1,337 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func ResourceNutanixBgpSessionV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixBgpSessionV2Create,
+		ReadContext:   ResourceNutanixBgpSessionV2Read,
+		UpdateContext: ResourceNutanixBgpSessionV2Update,
+		DeleteContext: ResourceNutanixBgpSessionV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"local_gateway_reference": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"remote_gateway_reference": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"local_gateway_interface_ip_address": schemaForGatewayIPAddress(false),
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"dynamic_route_priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"should_advertise_all_externally_routable_prefixes": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"externally_routable_prefixes_to_advertise": schemaForVpnPrefixes(true),
+			"advertised_routes_communities": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"autonomous_system_number": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+						"community_value": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+					},
+				},
+			},
+			"prepended_autonomous_system_path": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+			"status": schemaForVpnStatus(),
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+func ResourceNutanixBgpSessionV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	inputSpec := import1.BgpSession{}
+	inputSpec.Name = utils.StringPtr(d.Get("name").(string))
+	inputSpec.LocalGatewayReference = utils.StringPtr(d.Get("local_gateway_reference").(string))
+	inputSpec.RemoteGatewayReference = utils.StringPtr(d.Get("remote_gateway_reference").(string))
+
+	if desc, ok := d.GetOk("description"); ok {
+		inputSpec.Description = utils.StringPtr(desc.(string))
+	}
+	if ip, ok := d.GetOk("local_gateway_interface_ip_address"); ok {
+		inputSpec.LocalGatewayInterfaceIpAddress = expandIPAddressMap(ip)
+	}
+	if password, ok := d.GetOk("password"); ok {
+		inputSpec.Password = utils.StringPtr(password.(string))
+	}
+	if priority, ok := d.GetOk("dynamic_route_priority"); ok {
+		inputSpec.DynamicRoutePriority = utils.IntPtr(priority.(int))
+	}
+	if advertiseAll, ok := d.GetOk("should_advertise_all_externally_routable_prefixes"); ok {
+		inputSpec.ShouldAdvertiseAllExternallyRoutablePrefixes = utils.BoolPtr(advertiseAll.(bool))
+	}
+	if prefixes, ok := d.GetOk("externally_routable_prefixes_to_advertise"); ok {
+		inputSpec.ExternallyRoutablePrefixesToAdvertise = expandIPSubnet(prefixes.([]interface{}))
+	}
+	if communities, ok := d.GetOk("advertised_routes_communities"); ok {
+		inputSpec.AdvertisedRoutesCommunities = expandBgpCommunities(communities.([]interface{}))
+	}
+	if path, ok := d.GetOk("prepended_autonomous_system_path"); ok {
+		inputSpec.PrependedAutonomousSystemPath = expandAutonomousSystemPath(path.([]interface{}))
+	}
+
+	resp, err := conn.BgpSessionAPIInstance.CreateBgpSession(&inputSpec)
+	if err != nil {
+		return diag.Errorf("error while creating BGP session : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the BGP session to be created
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
+		return diag.Errorf("error waiting for BGP session (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	extID, err := taskDetails.EntityExtID(utils.RelEntityTypeBgpSession)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(extID)
+	return ResourceNutanixBgpSessionV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixBgpSessionV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.BgpSessionAPIInstance.GetBgpSessionById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching BGP session : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.BgpSession)
+
+	session := flattenBgpSession(getResp)
+	// the password is not returned by the API, keep the configured one
+	session["password"] = d.Get("password")
+	for attr, value := range session {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func ResourceNutanixBgpSessionV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	readResp, err := conn.BgpSessionAPIInstance.GetBgpSessionById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching BGP session : %v", err)
+	}
+
+	updateSpec := readResp.Data.GetValue().(import1.BgpSession)
+	// Extract E-Tag Header
+	etagValue := conn.BgpSessionAPIInstance.ApiClient.GetEtag(readResp)
+
+	args := make(map[string]interface{})
+	args["If-Match"] = utils.StringPtr(etagValue)
+
+	if d.HasChange("name") {
+		updateSpec.Name = utils.StringPtr(d.Get("name").(string))
+	}
+	if d.HasChange("description") {
+		updateSpec.Description = utils.StringPtr(d.Get("description").(string))
+	}
+	if d.HasChange("local_gateway_interface_ip_address") {
+		updateSpec.LocalGatewayInterfaceIpAddress = nil
+		if ip := d.Get("local_gateway_interface_ip_address").([]interface{}); len(ip) > 0 {
+			updateSpec.LocalGatewayInterfaceIpAddress = expandIPAddressMap(ip)
+		}
+	}
+	// the password is not read back, so it is always sent as configured
+	if password := d.Get("password").(string); password != "" {
+		updateSpec.Password = utils.StringPtr(password)
+	}
+	if d.HasChange("dynamic_route_priority") {
+		updateSpec.DynamicRoutePriority = utils.IntPtr(d.Get("dynamic_route_priority").(int))
+	}
+	if d.HasChange("should_advertise_all_externally_routable_prefixes") {
+		updateSpec.ShouldAdvertiseAllExternallyRoutablePrefixes = utils.BoolPtr(d.Get("should_advertise_all_externally_routable_prefixes").(bool))
+	}
+	if d.HasChange("externally_routable_prefixes_to_advertise") {
+		updateSpec.ExternallyRoutablePrefixesToAdvertise = expandIPSubnet(d.Get("externally_routable_prefixes_to_advertise").([]interface{}))
+	}
+	if d.HasChange("advertised_routes_communities") {
+		updateSpec.AdvertisedRoutesCommunities = expandBgpCommunities(d.Get("advertised_routes_communities").([]interface{}))
+	}
+	if d.HasChange("prepended_autonomous_system_path") {
+		updateSpec.PrependedAutonomousSystemPath = expandAutonomousSystemPath(d.Get("prepended_autonomous_system_path").([]interface{}))
+	}
+
+	resp, err := conn.BgpSessionAPIInstance.UpdateBgpSessionById(utils.StringPtr(d.Id()), &updateSpec, args)
+	if err != nil {
+		return diag.Errorf("error while updating BGP session : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the BGP session to be updated
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for BGP session (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return ResourceNutanixBgpSessionV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixBgpSessionV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.BgpSessionAPIInstance.DeleteBgpSessionById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while deleting BGP session : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the BGP session to be deleted
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
+		return diag.Errorf("error waiting for BGP session (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func expandBgpCommunities(pr []interface{}) []import1.BgpCommunity {
+	communities := make([]import1.BgpCommunity, 0, len(pr))
+	for _, v := range pr {
+		val := v.(map[string]interface{})
+		community := import1.NewBgpCommunity()
+		community.AutonomousSystemNumber = utils.IntPtr(val["autonomous_system_number"].(int))
+		community.CommunityValue = utils.IntPtr(val["community_value"].(int))
+		communities = append(communities, *community)
+	}
+	return communities
+}
+
+func expandAutonomousSystemPath(pr []interface{}) []int64 {
+	path := make([]int64, 0, len(pr))
+	for _, v := range pr {
+		path = append(path, int64(v.(int)))
+	}
+	return path
+}
+
+func flattenBgpSession(pr import1.BgpSession) map[string]interface{} {
+	return map[string]interface{}{
+		"ext_id":                             pr.ExtId,
+		"name":                               pr.Name,
+		"description":                        pr.Description,
+		"local_gateway_reference":            pr.LocalGatewayReference,
+		"remote_gateway_reference":           pr.RemoteGatewayReference,
+		"local_gateway_interface_ip_address": flattenIPAddress(pr.LocalGatewayInterfaceIpAddress),
+		"dynamic_route_priority":             pr.DynamicRoutePriority,
+		"should_advertise_all_externally_routable_prefixes": pr.ShouldAdvertiseAllExternallyRoutablePrefixes,
+		"externally_routable_prefixes_to_advertise":         flattenExternallyRoutablePrefixes(pr.ExternallyRoutablePrefixesToAdvertise),
+		"advertised_routes_communities":                     flattenBgpCommunities(pr.AdvertisedRoutesCommunities),
+		"prepended_autonomous_system_path":                  pr.PrependedAutonomousSystemPath,
+		"status":                                            flattenVpnStatus(pr.Status),
+		"links":                                             flattenLinks(pr.Links),
+		"tenant_id":                                         pr.TenantId,
+		"metadata":                                          flattenMetadata(pr.Metadata),
+	}
+}
+
+func flattenBgpCommunities(pr []import1.BgpCommunity) []map[string]interface{} {
+	communities := make([]map[string]interface{}, len(pr))
+	for i, v := range pr {
+		communities[i] = map[string]interface{}{
+			"autonomous_system_number": v.AutonomousSystemNumber,
+			"community_value":          v.CommunityValue,
+		}
+	}
+	return communities
+}
@@ -0,0 +1,133 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const resourceNameBgpSession = "nutanix_bgp_session_v2.test"
+
+var bgpSessions = mockpc.Collection{
+	Path:       "networking/config/bgp-sessions",
+	ObjectType: "networking.v4.config.BgpSession",
+	Rel:        "networking:config:bgp-session",
+}
+
+func TestAccV2NutanixBgpSessionResource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-bgp-session-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBgpSessionConfig(name, vlanID, 150),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameBgpSession, "name", name),
+					resource.TestCheckResourceAttrPair(resourceNameBgpSession, "local_gateway_reference", resourceNameBgpLocalGateway, "id"),
+					resource.TestCheckResourceAttrPair(resourceNameBgpSession, "remote_gateway_reference", resourceNameBgpRemoteGateway, "id"),
+					resource.TestCheckResourceAttr(resourceNameBgpSession, "dynamic_route_priority", "150"),
+					resource.TestCheckResourceAttr(resourceNameBgpSession, "password", "tf-test-bgp-secret"),
+					resource.TestCheckResourceAttr(resourceNameBgpSession, "externally_routable_prefixes_to_advertise.0.ipv4.0.ip.0.value", "172.16.0.0"),
+					resource.TestCheckResourceAttr(resourceNameBgpSession, "externally_routable_prefixes_to_advertise.0.ipv4.0.prefix_length", "16"),
+					resource.TestCheckResourceAttrSet(resourceNameBgpSession, "status.#"),
+				),
+			},
+			{
+				Config: testBgpSessionConfig(name, vlanID, 200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameBgpSession, "name", name),
+					resource.TestCheckResourceAttr(resourceNameBgpSession, "dynamic_route_priority", "200"),
+					resource.TestCheckResourceAttr(resourceNameBgpSession, "password", "tf-test-bgp-secret"),
+				),
+			},
+		},
+	})
+}
+
+func testBgpSessionConfig(name string, vlanID, priority int) string {
+	return testBgpGatewayConfig(name, vlanID, 65001) + fmt.Sprintf(`
+	resource "nutanix_bgp_session_v2" "test" {
+		name = "%[1]s"
+		description = "bgp session with the top of rack router"
+		local_gateway_reference = nutanix_bgp_gateway_v2.local.id
+		remote_gateway_reference = nutanix_bgp_gateway_v2.remote.id
+		password = "tf-test-bgp-secret"
+		dynamic_route_priority = %[2]d
+		externally_routable_prefixes_to_advertise {
+			ipv4 {
+				ip {
+					value = "172.16.0.0"
+				}
+				prefix_length = 16
+			}
+		}
+	}
+`, name, priority)
+}
+
+func TestUnitV2NutanixBgpSessionResource_PasswordKeptOnUpdate(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(bgpSessions)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := networkingv2.ResourceNutanixBgpSessionV2()
+	cfg := map[string]interface{}{
+		"name":                     "tf-bgp-session",
+		"local_gateway_reference":  "local-gateway",
+		"remote_gateway_reference": "remote-gateway",
+		"password":                 "bgp-secret",
+		"dynamic_route_priority":   150,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	// the password is not returned by Prism Central
+	session, _ := pc.Get(bgpSessions.Path, d.Id())
+	delete(session, "password")
+	pc.Add(bgpSessions.Path, session)
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if got := d.Get("password"); got != "bgp-secret" {
+		t.Errorf("password = %v, expected the configured password", got)
+	}
+
+	cfg["dynamic_route_priority"] = 200
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(d.State(), diff)
+	if err != nil {
+		t.Fatalf("data: %v", err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	for _, req := range pc.Requests() {
+		if req.Method == http.MethodPut && !strings.Contains(string(req.Body), `"bgp-secret"`) {
+			t.Errorf("bgp session updated without its password: %s", req.Body)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
 	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
@@ -235,14 +234,7 @@ func ResourceNutanixFloatingIPv2Create(ctx context.Context, d *schema.ResourceDa
 	taskconn := meta.(*conns.Client).PrismAPI
 
 	// Wait for the floating IP to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 		return diag.Errorf("error waiting for floating IP (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
@@ -388,14 +380,7 @@ func ResourceNutanixFloatingIPv2Update(ctx context.Context, d *schema.ResourceDa
 	taskconn := meta.(*conns.Client).PrismAPI
 
 	// Wait for the floating IP to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 		return diag.Errorf("error waiting for floating IP (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 	return nil
@@ -415,14 +400,7 @@ func ResourceNutanixFloatingIPv2Delete(ctx context.Context, d *schema.ResourceDa
 	taskconn := meta.(*conns.Client).PrismAPI
 
 	// Wait for the floating IP to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 		return diag.Errorf("error waiting for floating IP (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 	return nil
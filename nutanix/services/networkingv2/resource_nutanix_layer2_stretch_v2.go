@@ -0,0 +1,391 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func ResourceNutanixLayer2StretchV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixLayer2StretchV2Create,
+		ReadContext:   ResourceNutanixLayer2StretchV2Read,
+		UpdateContext: ResourceNutanixLayer2StretchV2Update,
+		DeleteContext: ResourceNutanixLayer2StretchV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"connection_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: common.ValidateEnum[import1.StretchConnectionType](),
+			},
+			"mtu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"vni": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntBetween(0, 16777215),
+			},
+			"local_site_params":     schemaForStretchSiteParams(),
+			"remote_site_params":    schemaForStretchSiteParams(),
+			"stretch_status":        schemaForStretchStatus(),
+			"remote_stretch_status": schemaForRemoteStretchStatus(),
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+// schemaForStretchSiteParams is one end of a stretch. The connection is a VPN connection for VPN stretches and
+// a gateway running a VTEP service for VXLAN stretches.
+func schemaForStretchSiteParams() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"stretch_subnet_reference": {
+					Type:     schema.TypeString,
+					Required: true,
+					ForceNew: true,
+				},
+				"connection_reference": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"pc_cluster_reference": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+				},
+				"default_gateway_ip_address":   schemaForGatewayIPAddress(false),
+				"stretch_interface_ip_address": schemaForGatewayIPAddress(false),
+				"vpn_interface_ip_address":     schemaForGatewayIPAddress(false),
+			},
+		},
+	}
+}
+
+func schemaForStretchStatus() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"detail": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"interface_state": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"tunnel_state": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"round_trip_time_millis": {
+					Type:     schema.TypeFloat,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func schemaForRemoteStretchStatus() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"address": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"ipv4": SchemaForValuePrefixLength(),
+							"ipv6": SchemaForValuePrefixLength(),
+						},
+					},
+				},
+				"status": schemaForStretchStatus(),
+			},
+		},
+	}
+}
+
+func ResourceNutanixLayer2StretchV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	inputSpec := import1.Layer2Stretch{}
+	inputSpec.Name = utils.StringPtr(d.Get("name").(string))
+	inputSpec.LocalSiteParams = expandStretchSiteParams(d.Get("local_site_params").([]interface{}))
+	inputSpec.RemoteSiteParams = expandStretchSiteParams(d.Get("remote_site_params").([]interface{}))
+
+	if desc, ok := d.GetOk("description"); ok {
+		inputSpec.Description = utils.StringPtr(desc.(string))
+	}
+	if connectionType, ok := d.GetOk("connection_type"); ok {
+		inputSpec.ConnectionType = common.ExpandEnum[import1.StretchConnectionType](connectionType)
+	}
+	if mtu, ok := d.GetOk("mtu"); ok {
+		inputSpec.Mtu = utils.IntPtr(mtu.(int))
+	}
+	if vni, ok := d.GetOk("vni"); ok {
+		inputSpec.Vni = utils.IntPtr(vni.(int))
+	}
+
+	resp, err := conn.Layer2StretchAPIInstance.CreateLayer2Stretch(&inputSpec)
+	if err != nil {
+		return diag.Errorf("error while creating Layer2 stretch : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the Layer2 stretch to be created
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
+		return diag.Errorf("error waiting for Layer2 stretch (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	extID, err := taskDetails.EntityExtID(utils.RelEntityTypeLayer2Stretch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(extID)
+	return ResourceNutanixLayer2StretchV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixLayer2StretchV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.Layer2StretchAPIInstance.GetLayer2StretchById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching Layer2 stretch : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.Layer2Stretch)
+
+	for attr, value := range flattenLayer2Stretch(getResp) {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func ResourceNutanixLayer2StretchV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	readResp, err := conn.Layer2StretchAPIInstance.GetLayer2StretchById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching Layer2 stretch : %v", err)
+	}
+
+	updateSpec := readResp.Data.GetValue().(import1.Layer2Stretch)
+	// Extract E-Tag Header
+	etagValue := conn.Layer2StretchAPIInstance.ApiClient.GetEtag(readResp)
+
+	args := make(map[string]interface{})
+	args["If-Match"] = utils.StringPtr(etagValue)
+
+	if d.HasChange("name") {
+		updateSpec.Name = utils.StringPtr(d.Get("name").(string))
+	}
+	if d.HasChange("description") {
+		updateSpec.Description = utils.StringPtr(d.Get("description").(string))
+	}
+	if d.HasChange("mtu") {
+		updateSpec.Mtu = utils.IntPtr(d.Get("mtu").(int))
+	}
+	if d.HasChange("local_site_params") {
+		updateSpec.LocalSiteParams = expandStretchSiteParams(d.Get("local_site_params").([]interface{}))
+	}
+	if d.HasChange("remote_site_params") {
+		updateSpec.RemoteSiteParams = expandStretchSiteParams(d.Get("remote_site_params").([]interface{}))
+	}
+
+	resp, err := conn.Layer2StretchAPIInstance.UpdateLayer2StretchById(utils.StringPtr(d.Id()), &updateSpec, args)
+	if err != nil {
+		return diag.Errorf("error while updating Layer2 stretch : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the Layer2 stretch to be updated
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for Layer2 stretch (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return ResourceNutanixLayer2StretchV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixLayer2StretchV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.Layer2StretchAPIInstance.DeleteLayer2StretchById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while deleting Layer2 stretch : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the Layer2 stretch to be deleted
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
+		return diag.Errorf("error waiting for Layer2 stretch (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func expandStretchSiteParams(pr []interface{}) *import1.SiteParams {
+	if len(pr) == 0 || pr[0] == nil {
+		return nil
+	}
+	val := pr[0].(map[string]interface{})
+
+	site := import1.NewSiteParams()
+	site.StretchSubnetReference = utils.StringPtr(val["stretch_subnet_reference"].(string))
+	if connectionRef, ok := val["connection_reference"].(string); ok && connectionRef != "" {
+		site.ConnectionReference = utils.StringPtr(connectionRef)
+	}
+	if pcRef, ok := val["pc_cluster_reference"].(string); ok && pcRef != "" {
+		site.PcClusterReference = utils.StringPtr(pcRef)
+	}
+	if ip, ok := val["default_gateway_ip_address"].([]interface{}); ok && len(ip) > 0 {
+		site.DefaultGatewayIPAddress = expandIPAddressMap(ip)
+	}
+	if ip, ok := val["stretch_interface_ip_address"].([]interface{}); ok && len(ip) > 0 {
+		site.StretchInterfaceIpAddress = expandIPAddressMap(ip)
+	}
+	if ip, ok := val["vpn_interface_ip_address"].([]interface{}); ok && len(ip) > 0 {
+		site.VpnInterfaceIPAddress = expandIPAddressMap(ip)
+	}
+	return site
+}
+
+func flattenLayer2Stretch(pr import1.Layer2Stretch) map[string]interface{} {
+	remoteStatus := make([]map[string]interface{}, len(pr.RemoteStretchStatus))
+	for i, v := range pr.RemoteStretchStatus {
+		remoteStatus[i] = map[string]interface{}{
+			"address": flattenIPAddress(v.Address),
+			"status":  flattenStretchStatus(v.Status),
+		}
+	}
+
+	stretch := map[string]interface{}{
+		"ext_id":                pr.ExtId,
+		"name":                  pr.Name,
+		"description":           pr.Description,
+		"mtu":                   pr.Mtu,
+		"vni":                   pr.Vni,
+		"local_site_params":     flattenStretchSiteParams(pr.LocalSiteParams),
+		"remote_site_params":    flattenStretchSiteParams(pr.RemoteSiteParams),
+		"stretch_status":        flattenStretchStatus(pr.StretchStatus),
+		"remote_stretch_status": remoteStatus,
+		"links":                 flattenLinks(pr.Links),
+		"tenant_id":             pr.TenantId,
+		"metadata":              flattenMetadata(pr.Metadata),
+	}
+	if pr.ConnectionType != nil {
+		stretch["connection_type"] = pr.ConnectionType.GetName()
+	}
+	return stretch
+}
+
+func flattenStretchSiteParams(pr *import1.SiteParams) []map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+	return []map[string]interface{}{{
+		"stretch_subnet_reference":     pr.StretchSubnetReference,
+		"connection_reference":         pr.ConnectionReference,
+		"pc_cluster_reference":         pr.PcClusterReference,
+		"default_gateway_ip_address":   flattenIPAddress(pr.DefaultGatewayIPAddress),
+		"stretch_interface_ip_address": flattenIPAddress(pr.StretchInterfaceIpAddress),
+		"vpn_interface_ip_address":     flattenIPAddress(pr.VpnInterfaceIPAddress),
+	}}
+}
+
+func flattenStretchStatus(pr *import1.StretchStatus) []map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+	status := map[string]interface{}{
+		"detail": pr.Detail,
+	}
+	if pr.InterfaceState != nil {
+		status["interface_state"] = pr.InterfaceState.GetName()
+	}
+	if pr.TunnelState != nil {
+		status["tunnel_state"] = pr.TunnelState.GetName()
+	}
+	if pr.RoundTripTimeMillis != nil {
+		status["round_trip_time_millis"] = float64(*pr.RoundTripTimeMillis)
+	}
+	return []map[string]interface{}{status}
+}
@@ -0,0 +1,154 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const resourceNameLayer2Stretch = "nutanix_layer2_stretch_v2.test"
+
+var layer2Stretches = mockpc.Collection{
+	Path:       "networking/config/layer2-stretches",
+	ObjectType: "networking.v4.config.Layer2Stretch",
+	Rel:        "networking:config:layer2-stretch",
+}
+
+func TestAccV2NutanixLayer2StretchResource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-layer2-stretch-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testLayer2StretchConfig(name, vlanID, 1400),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameLayer2Stretch, "name", name),
+					resource.TestCheckResourceAttr(resourceNameLayer2Stretch, "connection_type", "VPN"),
+					resource.TestCheckResourceAttr(resourceNameLayer2Stretch, "mtu", "1400"),
+					resource.TestCheckResourceAttrSet(resourceNameLayer2Stretch, "vni"),
+					resource.TestCheckResourceAttrPair(resourceNameLayer2Stretch, "local_site_params.0.stretch_subnet_reference", "nutanix_subnet_v2.stretch", "id"),
+					resource.TestCheckResourceAttrPair(resourceNameLayer2Stretch, "local_site_params.0.connection_reference", resourceNameVpnConnection, "id"),
+					resource.TestCheckResourceAttr(resourceNameLayer2Stretch, "local_site_params.0.stretch_interface_ip_address.0.ipv4.0.value", "10.10.0.250"),
+					resource.TestCheckResourceAttr(resourceNameLayer2Stretch, "remote_site_params.0.stretch_subnet_reference", testVars.Networking.Layer2Stretch.RemoteSubnetExtID),
+					resource.TestCheckResourceAttr(resourceNameLayer2Stretch, "remote_site_params.0.pc_cluster_reference", testVars.Networking.Layer2Stretch.RemotePcExtID),
+					resource.TestCheckResourceAttrSet(resourceNameLayer2Stretch, "stretch_status.#"),
+				),
+			},
+			{
+				Config: testLayer2StretchConfig(name, vlanID, 1350),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameLayer2Stretch, "name", name),
+					resource.TestCheckResourceAttr(resourceNameLayer2Stretch, "mtu", "1350"),
+				),
+			},
+		},
+	})
+}
+
+// testLayer2StretchConfig stretches an overlay subnet of the VPC over its VPN connection, to a subnet
+// of the remote Prism Central from the test config
+func testLayer2StretchConfig(name string, vlanID, mtu int) string {
+	return testVpnConnectionConfig(name, vlanID, false, 150) + fmt.Sprintf(`
+	resource "nutanix_subnet_v2" "stretch" {
+		name = "%[1]s-subnet"
+		description = "subnet stretched to the remote site"
+		vpc_reference = nutanix_vpc_v2.test.id
+		subnet_type = "OVERLAY"
+		ip_config {
+			ipv4 {
+				ip_subnet {
+					ip {
+						value = "10.10.0.0"
+					}
+					prefix_length = 24
+				}
+				default_gateway_ip {
+					value = "10.10.0.1"
+				}
+			}
+		}
+	}
+
+	resource "nutanix_layer2_stretch_v2" "test" {
+		name = "%[1]s"
+		description = "layer2 stretch over vpn"
+		connection_type = "VPN"
+		mtu = %[2]d
+		local_site_params {
+			stretch_subnet_reference = nutanix_subnet_v2.stretch.id
+			connection_reference = nutanix_vpn_connection_v2.test.id
+			stretch_interface_ip_address {
+				ipv4 {
+					value = "10.10.0.250"
+				}
+			}
+		}
+		remote_site_params {
+			stretch_subnet_reference = "%[3]s"
+			pc_cluster_reference = "%[4]s"
+		}
+	}
+`, name, mtu, testVars.Networking.Layer2Stretch.RemoteSubnetExtID, testVars.Networking.Layer2Stretch.RemotePcExtID)
+}
+
+func TestUnitV2NutanixLayer2StretchResource_StretchedSubnetChange(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(layer2Stretches)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := networkingv2.ResourceNutanixLayer2StretchV2()
+	cfg := map[string]interface{}{
+		"name":            "tf-stretch",
+		"connection_type": "VPN",
+		"mtu":             1400,
+		"local_site_params": []interface{}{map[string]interface{}{
+			"stretch_subnet_reference": "local-subnet",
+			"connection_reference":     "local-vpn-connection",
+		}},
+		"remote_site_params": []interface{}{map[string]interface{}{
+			"stretch_subnet_reference": "remote-subnet",
+			"pc_cluster_reference":     "remote-pc",
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	// the mtu is updated in place
+	cfg["mtu"] = 1350
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Errorf("changing the mtu should not replace the stretch: %v", diff)
+	}
+
+	// a stretch of another subnet is a new stretch
+	cfg["local_site_params"].([]interface{})[0].(map[string]interface{})["stretch_subnet_reference"] = "other-subnet"
+	diff, err = r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Errorf("changing a stretched subnet should replace the stretch: %v", diff)
+	}
+}
@@ -6,13 +6,10 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	config "github.com/nutanix/ntnx-api-golang-clients/microseg-go-client/v4/models/common/v1/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/microseg-go-client/v4/models/microseg/v4/config"
 	import4 "github.com/nutanix/ntnx-api-golang-clients/microseg-go-client/v4/models/prism/v4/config"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
@@ -37,7 +34,7 @@ func ResourceNutanixNetworkSecurityPolicyV2() *schema.Resource {
 			"type": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringInSlice([]string{"QUARANTINE", "ISOLATION", "APPLICATION"}, false),
+				ValidateFunc: common.ValidateEnum[import1.SecurityPolicyType](),
 			},
 			"description": {
 				Type:     schema.TypeString,
@@ -46,7 +43,7 @@ func ResourceNutanixNetworkSecurityPolicyV2() *schema.Resource {
 			"state": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ValidateFunc: validation.StringInSlice([]string{"SAVE", "MONITOR", "ENFORCE"}, false),
+				ValidateFunc: common.ValidateEnum[import1.SecurityPolicyState](),
 			},
 			"rules": {
 				Type:     schema.TypeList,
@@ -65,7 +62,7 @@ func ResourceNutanixNetworkSecurityPolicyV2() *schema.Resource {
 						"type": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"QUARANTINE", "TWO_ENV_ISOLATION", "APPLICATION", "INTRA_GROUP", "MULTI_ENV_ISOLATION"}, false),
+							ValidateFunc: common.ValidateEnum[import1.RuleType](),
 						},
 						"spec": {
 							Type:     schema.TypeList,
@@ -110,13 +107,13 @@ func ResourceNutanixNetworkSecurityPolicyV2() *schema.Resource {
 													Type:         schema.TypeString,
 													Optional:     true,
 													Computed:     true,
-													ValidateFunc: validation.StringInSlice([]string{"ALL", "NONE"}, false),
+													ValidateFunc: common.ValidateEnum[import1.AllowType](),
 												},
 												"dest_allow_spec": {
 													Type:         schema.TypeString,
 													Optional:     true,
 													Computed:     true,
-													ValidateFunc: validation.StringInSlice([]string{"ALL", "NONE"}, false),
+													ValidateFunc: common.ValidateEnum[import1.AllowType](),
 												},
 												"src_category_references": {
 													Type:     schema.TypeList,
@@ -271,9 +268,10 @@ func ResourceNutanixNetworkSecurityPolicyV2() *schema.Resource {
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"secured_group_action": {
-													Type:     schema.TypeString,
-													Optional: true,
-													Computed: true,
+													Type:         schema.TypeString,
+													Optional:     true,
+													Computed:     true,
+													ValidateFunc: common.ValidateEnum[import1.IntraEntityGroupRuleAction](),
 												},
 												"secured_group_category_references": {
 													Type:     schema.TypeList,
@@ -362,7 +360,7 @@ func ResourceNutanixNetworkSecurityPolicyV2() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
-				ValidateFunc: validation.StringInSlice([]string{"ALL_VLAN", "ALL_VPC", "VPC_LIST", "GLOBAL"}, false),
+				ValidateFunc: common.ValidateEnum[import1.SecurityPolicyScope](),
 			},
 			"vpc_reference": {
 				Type:     schema.TypeList,
@@ -470,27 +468,15 @@ func ResourceNutanixNetworkSecurityPolicyV2Create(ctx context.Context, d *schema
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the network security policy to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for network security policy (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching network security policy task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Create Network Security Policy Task Details: %s", string(aJSON))
 
-	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeSecurityPolicy, "Network security policy")
+	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeSecurityPolicy, "Network security policy")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -662,14 +648,7 @@ func ResourceNutanixNetworkSecurityPolicyV2Update(ctx context.Context, d *schema
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the network security policy to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 		return diag.Errorf("error waiting for network security policy (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 	return ResourceNutanixNetworkSecurityPolicyV2Read(ctx, d, meta)
@@ -690,14 +669,7 @@ func ResourceNutanixNetworkSecurityPolicyV2Delete(ctx context.Context, d *schema
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the network security policy to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 		return diag.Errorf("error waiting for network security policy (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 	return nil
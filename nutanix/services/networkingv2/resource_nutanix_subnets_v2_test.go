@@ -1,12 +1,17 @@
 package networkingv2_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
 )
 
 const resourceNameSubnet = "nutanix_subnet_v2.test"
@@ -299,3 +304,120 @@ resource "nutanix_subnet_v2" "test" {
 }
 `, name, desc)
 }
+
+func TestUnitV2NutanixSubnetResource_MockPC(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.TaskPolls = 1
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := networkingv2.ResourceNutanixSubnetV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":        "tf-test-subnet",
+		"description": "test subnet description",
+		"subnet_type": "VLAN",
+		"network_id":  112,
+	})
+
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	subnet, ok := pc.Get("networking/config/subnets", d.Id())
+	if !ok {
+		t.Fatalf("subnet %s not created", d.Id())
+	}
+	if subnet["name"] != "tf-test-subnet" || subnet["subnetType"] != "VLAN" {
+		t.Errorf("unexpected subnet created: %v", subnet)
+	}
+	if d.Get("network_id").(int) != 112 {
+		t.Errorf("network_id = %d, expected 112", d.Get("network_id").(int))
+	}
+
+	if err := d.Set("name", "updated-name"); err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if subnet, _ := pc.Get("networking/config/subnets", d.Id()); subnet["name"] != "updated-name" {
+		t.Errorf("subnet name = %v, expected updated-name", subnet["name"])
+	}
+
+	if diags := r.DeleteContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if _, ok := pc.Get("networking/config/subnets", d.Id()); ok {
+		t.Error("subnet not deleted")
+	}
+}
+
+// TestUnitV2NutanixSubnetResource_MockPCLifecycle applies a subnet with terraform against the fake Prism Central,
+// so that the schema, the ETag of the update and the task polling are exercised as on a real apply
+func TestUnitV2NutanixSubnetResource_MockPCLifecycle(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.TaskPolls = 1
+
+	subnetsPath := "networking/config/subnets"
+	config := func(name string) string {
+		return pc.ProviderConfig() + fmt.Sprintf(`
+resource "nutanix_subnet_v2" "test" {
+  name        = %q
+  description = "test subnet description"
+  subnet_type = "VLAN"
+  network_id  = 112
+}
+`, name)
+	}
+	checkSubnet := func(name string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			rs, ok := s.RootModule().Resources[resourceNameSubnet]
+			if !ok {
+				return fmt.Errorf("%s not found in the state", resourceNameSubnet)
+			}
+			subnet, ok := pc.Get(subnetsPath, rs.Primary.ID)
+			if !ok {
+				return fmt.Errorf("subnet %s not created on Prism Central", rs.Primary.ID)
+			}
+			if subnet["name"] != name {
+				return fmt.Errorf("subnet name = %v, expected %s", subnet["name"], name)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:  func() { mockpc.UnitTestPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		CheckDestroy: func(s *terraform.State) error {
+			if subnets := pc.List(subnetsPath); len(subnets) != 0 {
+				return fmt.Errorf("subnets left on Prism Central: %v", subnets)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("tf-test-subnet"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameSubnet, "name", "tf-test-subnet"),
+					resource.TestCheckResourceAttr(resourceNameSubnet, "subnet_type", "VLAN"),
+					resource.TestCheckResourceAttr(resourceNameSubnet, "network_id", "112"),
+					checkSubnet("tf-test-subnet"),
+				),
+			},
+			// the fake Prism Central rejects an update without the ETag of the subnet
+			{
+				Config: config("tf-test-subnet-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameSubnet, "name", "tf-test-subnet-updated"),
+					checkSubnet("tf-test-subnet-updated"),
+				),
+			},
+		},
+	})
+}
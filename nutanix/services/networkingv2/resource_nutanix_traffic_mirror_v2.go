@@ -0,0 +1,375 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// Prism limits a session to 4 source ports and 2 destination ports
+const (
+	trafficMirrorMaxSources      = 4
+	trafficMirrorMaxDestinations = 2
+)
+
+func ResourceNutanixTrafficMirrorV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixTrafficMirrorV2Create,
+		ReadContext:   ResourceNutanixTrafficMirrorV2Read,
+		UpdateContext: ResourceNutanixTrafficMirrorV2Update,
+		DeleteContext: ResourceNutanixTrafficMirrorV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"is_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"sources": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: trafficMirrorMaxSources,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nic_uuid": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"nic_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "VIRTUAL_NIC",
+							ValidateFunc: common.ValidateEnum[import1.TrafficMirrorPortNicType](),
+						},
+						"direction": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: common.ValidateEnum[import1.TrafficMirrorSourcePortDirection](),
+						},
+						"is_up": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"destinations": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: trafficMirrorMaxDestinations,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nic_uuid": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						// traffic is only mirrored to VM NICs
+						"nic_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "VIRTUAL_NIC",
+							ValidateFunc: validation.StringInSlice([]string{"VIRTUAL_NIC"}, false),
+						},
+						"is_up": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"cluster_reference_list": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"host_reference_list": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"virtual_switch_reference": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"state_message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+func ResourceNutanixTrafficMirrorV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	inputSpec := import1.TrafficMirror{}
+	inputSpec.Name = utils.StringPtr(d.Get("name").(string))
+	inputSpec.IsEnabled = utils.BoolPtr(d.Get("is_enabled").(bool))
+	inputSpec.SourceList = expandTrafficMirrorSources(d.Get("sources").([]interface{}))
+	inputSpec.DestinationList = expandTrafficMirrorDestinations(d.Get("destinations").([]interface{}))
+
+	if desc, ok := d.GetOk("description"); ok {
+		inputSpec.Description = utils.StringPtr(desc.(string))
+	}
+	if clusters, ok := d.GetOk("cluster_reference_list"); ok {
+		inputSpec.ClusterReferenceList = common.ExpandListOfString(clusters.([]interface{}))
+	}
+	if hosts, ok := d.GetOk("host_reference_list"); ok {
+		inputSpec.HostReferenceList = common.ExpandListOfString(hosts.([]interface{}))
+	}
+	if vs, ok := d.GetOk("virtual_switch_reference"); ok {
+		inputSpec.VirtualSwitchReference = utils.StringPtr(vs.(string))
+	}
+
+	resp, err := conn.TrafficMirrorAPIInstance.CreateTrafficMirror(&inputSpec)
+	if err != nil {
+		return diag.Errorf("error while creating traffic mirror : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the traffic mirror to be created
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
+		return diag.Errorf("error waiting for traffic mirror (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	extID, err := taskDetails.EntityExtID(utils.RelEntityTypeTrafficMirror)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(extID)
+	return ResourceNutanixTrafficMirrorV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixTrafficMirrorV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.TrafficMirrorAPIInstance.GetTrafficMirrorById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching traffic mirror : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.TrafficMirror)
+
+	for attr, value := range flattenTrafficMirror(getResp) {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func ResourceNutanixTrafficMirrorV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	readResp, err := conn.TrafficMirrorAPIInstance.GetTrafficMirrorById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching traffic mirror : %v", err)
+	}
+
+	updateSpec := readResp.Data.GetValue().(import1.TrafficMirror)
+	// Extract E-Tag Header
+	etagValue := conn.TrafficMirrorAPIInstance.ApiClient.GetEtag(readResp)
+
+	args := make(map[string]interface{})
+	args["If-Match"] = utils.StringPtr(etagValue)
+
+	if d.HasChange("name") {
+		updateSpec.Name = utils.StringPtr(d.Get("name").(string))
+	}
+	if d.HasChange("description") {
+		updateSpec.Description = utils.StringPtr(d.Get("description").(string))
+	}
+	if d.HasChange("is_enabled") {
+		updateSpec.IsEnabled = utils.BoolPtr(d.Get("is_enabled").(bool))
+	}
+	if d.HasChange("sources") {
+		updateSpec.SourceList = expandTrafficMirrorSources(d.Get("sources").([]interface{}))
+	}
+	if d.HasChange("destinations") {
+		updateSpec.DestinationList = expandTrafficMirrorDestinations(d.Get("destinations").([]interface{}))
+	}
+	if d.HasChange("cluster_reference_list") {
+		updateSpec.ClusterReferenceList = common.ExpandListOfString(d.Get("cluster_reference_list").([]interface{}))
+	}
+	if d.HasChange("host_reference_list") {
+		updateSpec.HostReferenceList = common.ExpandListOfString(d.Get("host_reference_list").([]interface{}))
+	}
+	if d.HasChange("virtual_switch_reference") {
+		updateSpec.VirtualSwitchReference = utils.StringPtr(d.Get("virtual_switch_reference").(string))
+	}
+
+	resp, err := conn.TrafficMirrorAPIInstance.UpdateTrafficMirrorById(utils.StringPtr(d.Id()), &updateSpec, args)
+	if err != nil {
+		return diag.Errorf("error while updating traffic mirror : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the traffic mirror to be updated
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for traffic mirror (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return ResourceNutanixTrafficMirrorV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixTrafficMirrorV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.TrafficMirrorAPIInstance.DeleteTrafficMirrorById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while deleting traffic mirror : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the traffic mirror to be deleted
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
+		return diag.Errorf("error waiting for traffic mirror (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func expandTrafficMirrorSources(pr []interface{}) []import1.TrafficMirrorSourcePort {
+	sources := make([]import1.TrafficMirrorSourcePort, 0, len(pr))
+	for _, v := range pr {
+		val := v.(map[string]interface{})
+
+		source := import1.NewTrafficMirrorSourcePort()
+		source.NicUuid = utils.StringPtr(val["nic_uuid"].(string))
+		source.NicType = common.ExpandEnum[import1.TrafficMirrorPortNicType](val["nic_type"])
+		source.Direction = common.ExpandEnum[import1.TrafficMirrorSourcePortDirection](val["direction"])
+		sources = append(sources, *source)
+	}
+	return sources
+}
+
+func expandTrafficMirrorDestinations(pr []interface{}) []import1.TrafficMirrorPort {
+	destinations := make([]import1.TrafficMirrorPort, 0, len(pr))
+	for _, v := range pr {
+		val := v.(map[string]interface{})
+
+		destination := import1.NewTrafficMirrorPort()
+		destination.NicUuid = utils.StringPtr(val["nic_uuid"].(string))
+		destination.NicType = common.ExpandEnum[import1.TrafficMirrorPortNicType](val["nic_type"])
+		destinations = append(destinations, *destination)
+	}
+	return destinations
+}
+
+func flattenTrafficMirror(pr import1.TrafficMirror) map[string]interface{} {
+	sources := make([]map[string]interface{}, len(pr.SourceList))
+	for i, v := range pr.SourceList {
+		sources[i] = map[string]interface{}{
+			"nic_uuid": v.NicUuid,
+			"is_up":    v.IsUp,
+		}
+		if v.NicType != nil {
+			sources[i]["nic_type"] = v.NicType.GetName()
+		}
+		if v.Direction != nil {
+			sources[i]["direction"] = v.Direction.GetName()
+		}
+	}
+	destinations := make([]map[string]interface{}, len(pr.DestinationList))
+	for i, v := range pr.DestinationList {
+		destinations[i] = map[string]interface{}{
+			"nic_uuid": v.NicUuid,
+			"is_up":    v.IsUp,
+		}
+		if v.NicType != nil {
+			destinations[i]["nic_type"] = v.NicType.GetName()
+		}
+	}
+
+	trafficMirror := map[string]interface{}{
+		"ext_id":                   pr.ExtId,
+		"name":                     pr.Name,
+		"description":              pr.Description,
+		"is_enabled":               pr.IsEnabled,
+		"sources":                  sources,
+		"destinations":             destinations,
+		"cluster_reference_list":   pr.ClusterReferenceList,
+		"host_reference_list":      pr.HostReferenceList,
+		"virtual_switch_reference": pr.VirtualSwitchReference,
+		"state_message":            pr.StateMessage,
+		"links":                    flattenLinks(pr.Links),
+		"tenant_id":                pr.TenantId,
+		"metadata":                 flattenMetadata(pr.Metadata),
+	}
+	if pr.State != nil {
+		trafficMirror["state"] = pr.State.GetName()
+	}
+	return trafficMirror
+}
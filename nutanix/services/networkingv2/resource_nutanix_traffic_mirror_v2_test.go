@@ -0,0 +1,179 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const resourceNameTrafficMirror = "nutanix_traffic_mirror_v2.test"
+
+var trafficMirrors = mockpc.Collection{
+	Path:       "networking/config/traffic-mirrors",
+	ObjectType: "networking.v4.config.TrafficMirror",
+	Rel:        "networking:config:traffic-mirror",
+}
+
+func TestAccV2NutanixTrafficMirrorResource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-traffic-mirror-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testTrafficMirrorConfig(name, vlanID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameTrafficMirror, "name", name),
+					resource.TestCheckResourceAttr(resourceNameTrafficMirror, "is_enabled", "true"),
+					resource.TestCheckResourceAttrPair(resourceNameTrafficMirror, "sources.0.nic_uuid", "nutanix_virtual_machine_v2.source", "nics.0.ext_id"),
+					resource.TestCheckResourceAttr(resourceNameTrafficMirror, "sources.0.nic_type", "VIRTUAL_NIC"),
+					resource.TestCheckResourceAttr(resourceNameTrafficMirror, "sources.0.direction", "BIDIRECTIONAL"),
+					resource.TestCheckResourceAttrPair(resourceNameTrafficMirror, "destinations.0.nic_uuid", "nutanix_virtual_machine_v2.destination", "nics.0.ext_id"),
+					resource.TestCheckResourceAttr(resourceNameTrafficMirror, "destinations.0.nic_type", "VIRTUAL_NIC"),
+					resource.TestCheckResourceAttrSet(resourceNameTrafficMirror, "state"),
+				),
+			},
+			{
+				Config: testTrafficMirrorConfig(name, vlanID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameTrafficMirror, "is_enabled", "false"),
+					resource.TestCheckResourceAttr(resourceNameTrafficMirror, "sources.#", "1"),
+					resource.TestCheckResourceAttr(resourceNameTrafficMirror, "destinations.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// testTrafficMirrorConfig mirrors the traffic of a VM NIC to the NIC of a second VM, such as an IDS appliance
+func testTrafficMirrorConfig(name string, vlanID int, isEnabled bool) string {
+	return fmt.Sprintf(`
+	data "nutanix_clusters_v2" "clusters" {}
+
+	locals {
+		cluster0 =  [
+			  for cluster in data.nutanix_clusters_v2.clusters.cluster_entities :
+			  cluster.ext_id if cluster.config[0].cluster_function[0] != "PRISM_CENTRAL"
+		][0]
+	}
+
+	resource "nutanix_subnet_v2" "test" {
+		name = "%[1]s-subnet"
+		description = "subnet of the mirrored vms"
+		cluster_reference = local.cluster0
+		subnet_type = "VLAN"
+		network_id = %[2]d
+	}
+
+	resource "nutanix_virtual_machine_v2" "source" {
+		name = "%[1]s-source"
+		num_cores_per_socket = 1
+		num_sockets = 1
+		cluster {
+			ext_id = local.cluster0
+		}
+		nics {
+			network_info {
+				nic_type = "NORMAL_NIC"
+				subnet {
+					ext_id = nutanix_subnet_v2.test.id
+				}
+				vlan_mode = "ACCESS"
+			}
+		}
+		power_state = "ON"
+	}
+
+	resource "nutanix_virtual_machine_v2" "destination" {
+		name = "%[1]s-ids"
+		num_cores_per_socket = 1
+		num_sockets = 1
+		cluster {
+			ext_id = local.cluster0
+		}
+		nics {
+			network_info {
+				nic_type = "NORMAL_NIC"
+				subnet {
+					ext_id = nutanix_subnet_v2.test.id
+				}
+				vlan_mode = "ACCESS"
+			}
+		}
+		power_state = "ON"
+	}
+
+	resource "nutanix_traffic_mirror_v2" "test" {
+		name = "%[1]s"
+		description = "traffic mirror to the ids vm"
+		is_enabled = %[3]t
+		sources {
+			nic_uuid = nutanix_virtual_machine_v2.source.nics.0.ext_id
+			direction = "BIDIRECTIONAL"
+		}
+		destinations {
+			nic_uuid = nutanix_virtual_machine_v2.destination.nics.0.ext_id
+		}
+	}
+`, name, vlanID, isEnabled)
+}
+
+func TestUnitV2NutanixTrafficMirrorResource_HostNicSource(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(trafficMirrors)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := networkingv2.ResourceNutanixTrafficMirrorV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "tf-ids-tap",
+		"sources": []interface{}{
+			map[string]interface{}{"nic_uuid": "vm-nic-1", "direction": "BIDIRECTIONAL"},
+			map[string]interface{}{"nic_uuid": "host-nic-1", "nic_type": "HOST_NIC", "direction": "INGRESS"},
+		},
+		"destinations": []interface{}{
+			map[string]interface{}{"nic_uuid": "ids-vm-nic"},
+		},
+	})
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	// host NICs are only mirrored from, the destination is always a VM NIC
+	mirror, _ := pc.Get(trafficMirrors.Path, d.Id())
+	sources := mirror["sourceList"].([]interface{})
+	if source := sources[0].(map[string]interface{}); source["nicType"] != "VIRTUAL_NIC" {
+		t.Errorf("unexpected VM NIC source: %v", source)
+	}
+	if source := sources[1].(map[string]interface{}); source["nicType"] != "HOST_NIC" || source["direction"] != "INGRESS" {
+		t.Errorf("unexpected host NIC source: %v", source)
+	}
+	if destination := mirror["destinationList"].([]interface{})[0].(map[string]interface{}); destination["nicType"] != "VIRTUAL_NIC" {
+		t.Errorf("unexpected destination: %v", destination)
+	}
+}
+
+func TestUnitV2NutanixTrafficMirrorResource_DestinationNicType(t *testing.T) {
+	destination := networkingv2.ResourceNutanixTrafficMirrorV2().Schema["destinations"].Elem.(*schema.Resource)
+	validate := destination.Schema["nic_type"].ValidateFunc
+	if _, errs := validate("VIRTUAL_NIC", "nic_type"); len(errs) != 0 {
+		t.Errorf("VIRTUAL_NIC destination rejected: %v", errs)
+	}
+	if _, errs := validate("HOST_NIC", "nic_type"); len(errs) == 0 {
+		t.Error("expected HOST_NIC destination to be rejected")
+	}
+}
@@ -0,0 +1,510 @@
+package networkingv2
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// virtual switch changes are rolled out host by host, each host going through maintenance mode
+const virtualSwitchTimeout = 60 * time.Minute
+
+func ResourceNutanixVirtualSwitchV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixVirtualSwitchV2Create,
+		ReadContext:   ResourceNutanixVirtualSwitchV2Read,
+		UpdateContext: ResourceNutanixVirtualSwitchV2Update,
+		DeleteContext: ResourceNutanixVirtualSwitchV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(virtualSwitchTimeout),
+			Update: schema.DefaultTimeout(virtualSwitchTimeout),
+			Delete: schema.DefaultTimeout(virtualSwitchTimeout),
+		},
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"bond_mode": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: common.ValidateEnum[import1.BondModeType](),
+			},
+			"mtu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1500, 9000),
+			},
+			"is_quick_mode": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"igmp_spec": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"is_snooping_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"snooping_timeout": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"querier_spec": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"is_querier_enabled": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										Computed: true,
+									},
+									"vlan_id_list": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type:         schema.TypeInt,
+											ValidateFunc: validation.IntBetween(0, 4095),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"clusters": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ext_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"vlan_identifier": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntBetween(0, 4095),
+						},
+						"gateway_ip_address": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"value": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"prefix_length": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"hosts": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ext_id": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"host_nics": {
+										Type:     schema.TypeSet,
+										Required: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+									"ip_address": {
+										Type:     schema.TypeList,
+										Optional: true,
+										Computed: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"ip": SchemaForValuePrefixLength(),
+												"prefix_length": {
+													Type:     schema.TypeInt,
+													Optional: true,
+													Computed: true,
+												},
+											},
+										},
+									},
+									"route_table": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Computed: true,
+									},
+									"active_uplink": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"internal_bridge_name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"is_default": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"owner_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"has_deployment_error": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"has_update_in_progress": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"has_delete_in_progress": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+func ResourceNutanixVirtualSwitchV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	inputSpec := import1.VirtualSwitch{}
+	inputSpec.Name = utils.StringPtr(d.Get("name").(string))
+	inputSpec.BondMode = common.ExpandEnum[import1.BondModeType](d.Get("bond_mode"))
+	inputSpec.Clusters = expandVirtualSwitchClusters(d.Get("clusters").([]interface{}))
+	inputSpec.IsQuickMode = utils.BoolPtr(d.Get("is_quick_mode").(bool))
+
+	if desc, ok := d.GetOk("description"); ok {
+		inputSpec.Description = utils.StringPtr(desc.(string))
+	}
+	if mtu, ok := d.GetOk("mtu"); ok {
+		inputSpec.Mtu = utils.Int64Ptr(int64(mtu.(int)))
+	}
+	if igmp, ok := d.GetOk("igmp_spec"); ok {
+		inputSpec.IgmpSpec = expandVirtualSwitchIgmpSpec(igmp.([]interface{}))
+	}
+
+	resp, err := conn.VirtualSwitchAPIInstance.CreateVirtualSwitch(&inputSpec, nil)
+	if err != nil {
+		return diag.Errorf("error while creating virtual switch : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the virtual switch to be created
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
+		return diag.Errorf("error waiting for virtual switch (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	extID, err := taskDetails.EntityExtID(utils.RelEntityTypeVirtualSwitch)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(extID)
+	return ResourceNutanixVirtualSwitchV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixVirtualSwitchV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.VirtualSwitchAPIInstance.GetVirtualSwitchById(utils.StringPtr(d.Id()), nil)
+	if err != nil {
+		return diag.Errorf("error while fetching virtual switch : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.VirtualSwitch)
+
+	for attr, value := range flattenVirtualSwitchEntity(getResp) {
+		// quick mode only applies to the operation being run, it is not a property of the switch
+		if attr == "is_quick_mode" {
+			continue
+		}
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func ResourceNutanixVirtualSwitchV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	readResp, err := conn.VirtualSwitchAPIInstance.GetVirtualSwitchById(utils.StringPtr(d.Id()), nil)
+	if err != nil {
+		return diag.Errorf("error while fetching virtual switch : %v", err)
+	}
+
+	updateSpec := readResp.Data.GetValue().(import1.VirtualSwitch)
+	// Extract E-Tag Header
+	etagValue := conn.VirtualSwitchAPIInstance.ApiClient.GetEtag(readResp)
+
+	args := make(map[string]interface{})
+	args["If-Match"] = utils.StringPtr(etagValue)
+
+	updateSpec.IsQuickMode = utils.BoolPtr(d.Get("is_quick_mode").(bool))
+	if d.HasChange("name") {
+		updateSpec.Name = utils.StringPtr(d.Get("name").(string))
+	}
+	if d.HasChange("description") {
+		updateSpec.Description = utils.StringPtr(d.Get("description").(string))
+	}
+	if d.HasChange("bond_mode") {
+		updateSpec.BondMode = common.ExpandEnum[import1.BondModeType](d.Get("bond_mode"))
+	}
+	if d.HasChange("mtu") {
+		updateSpec.Mtu = utils.Int64Ptr(int64(d.Get("mtu").(int)))
+	}
+	if d.HasChange("igmp_spec") {
+		updateSpec.IgmpSpec = expandVirtualSwitchIgmpSpec(d.Get("igmp_spec").([]interface{}))
+	}
+	if d.HasChange("clusters") {
+		updateSpec.Clusters = expandVirtualSwitchClusters(d.Get("clusters").([]interface{}))
+	}
+
+	resp, err := conn.VirtualSwitchAPIInstance.UpdateVirtualSwitchById(utils.StringPtr(d.Id()), &updateSpec, nil, args)
+	if err != nil {
+		return diag.Errorf("error while updating virtual switch : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the virtual switch to be updated
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for virtual switch (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return ResourceNutanixVirtualSwitchV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixVirtualSwitchV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	// the default virtual switch (vs0) can't be deleted, it is only removed from the state
+	if d.Get("is_default").(bool) {
+		log.Printf("[WARN] virtual switch %s is the default virtual switch of its cluster, removing it from the state only", d.Id())
+		return nil
+	}
+
+	resp, err := conn.VirtualSwitchAPIInstance.DeleteVirtualSwitchById(utils.StringPtr(d.Id()), nil)
+	if err != nil {
+		return diag.Errorf("error while deleting virtual switch : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the virtual switch to be deleted
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
+		return diag.Errorf("error waiting for virtual switch (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func expandVirtualSwitchClusters(pr []interface{}) []import1.Cluster {
+	clusters := make([]import1.Cluster, 0, len(pr))
+	for _, v := range pr {
+		val := v.(map[string]interface{})
+
+		cluster := import1.NewCluster()
+		cluster.ExtId = utils.StringPtr(val["ext_id"].(string))
+		if vlan, ok := val["vlan_identifier"].(int); ok && vlan > 0 {
+			cluster.VlanIdentifier = utils.IntPtr(vlan)
+		}
+		if gateway, ok := val["gateway_ip_address"].([]interface{}); ok && len(gateway) > 0 {
+			cluster.GatewayIpAddress = expandIPv4AddressMap(gateway)
+		}
+		for _, h := range val["hosts"].([]interface{}) {
+			hostVal := h.(map[string]interface{})
+
+			host := import1.NewHost()
+			host.ExtId = utils.StringPtr(hostVal["ext_id"].(string))
+			host.HostNics = common.ExpandListOfString(hostVal["host_nics"].(*schema.Set).List())
+			if ip, ok := hostVal["ip_address"].([]interface{}); ok && len(ip) > 0 {
+				host.IpAddress = expandIPv4Subnet(ip)
+			}
+			if routeTable, ok := hostVal["route_table"].(int); ok && routeTable > 0 {
+				host.RouteTable = utils.IntPtr(routeTable)
+			}
+			cluster.Hosts = append(cluster.Hosts, *host)
+		}
+		clusters = append(clusters, *cluster)
+	}
+	return clusters
+}
+
+func expandVirtualSwitchIgmpSpec(pr []interface{}) *import1.IgmpSpec {
+	if len(pr) == 0 || pr[0] == nil {
+		return nil
+	}
+	val := pr[0].(map[string]interface{})
+
+	igmp := import1.NewIgmpSpec()
+	if snooping, ok := val["is_snooping_enabled"].(bool); ok {
+		igmp.IsSnoopingEnabled = utils.BoolPtr(snooping)
+	}
+	if timeout, ok := val["snooping_timeout"].(int); ok && timeout > 0 {
+		igmp.SnoopingTimeout = utils.Int64Ptr(int64(timeout))
+	}
+	if querier, ok := val["querier_spec"].([]interface{}); ok && len(querier) > 0 && querier[0] != nil {
+		querierVal := querier[0].(map[string]interface{})
+		igmp.QuerierSpec = import1.NewQuerierSpec()
+		if enabled, ok := querierVal["is_querier_enabled"].(bool); ok {
+			igmp.QuerierSpec.IsQuerierEnabled = utils.BoolPtr(enabled)
+		}
+		for _, vlan := range querierVal["vlan_id_list"].([]interface{}) {
+			igmp.QuerierSpec.VlanIdList = append(igmp.QuerierSpec.VlanIdList, vlan.(int))
+		}
+	}
+	return igmp
+}
+
+func flattenVirtualSwitchEntity(pr import1.VirtualSwitch) map[string]interface{} {
+	virtualSwitch := map[string]interface{}{
+		"ext_id":                 pr.ExtId,
+		"name":                   pr.Name,
+		"description":            pr.Description,
+		"mtu":                    utils.Int64Value(pr.Mtu),
+		"is_quick_mode":          pr.IsQuickMode,
+		"igmp_spec":              flattenVirtualSwitchIgmpSpec(pr.IgmpSpec),
+		"clusters":               flattenVirtualSwitchClusters(pr.Clusters),
+		"is_default":             pr.IsDefault,
+		"has_deployment_error":   pr.HasDeploymentError,
+		"has_update_in_progress": pr.HasUpdateInProgress,
+		"has_delete_in_progress": pr.HasDeleteInProgress,
+		"links":                  flattenLinks(pr.Links),
+		"tenant_id":              pr.TenantId,
+		"metadata":               flattenMetadata(pr.Metadata),
+	}
+	if pr.BondMode != nil {
+		virtualSwitch["bond_mode"] = pr.BondMode.GetName()
+	}
+	if pr.OwnerType != nil {
+		virtualSwitch["owner_type"] = pr.OwnerType.GetName()
+	}
+	return virtualSwitch
+}
+
+func flattenVirtualSwitchClusters(pr []import1.Cluster) []map[string]interface{} {
+	clusters := make([]map[string]interface{}, len(pr))
+	for i, v := range pr {
+		hosts := make([]map[string]interface{}, len(v.Hosts))
+		for j, h := range v.Hosts {
+			hosts[j] = map[string]interface{}{
+				"ext_id":               h.ExtId,
+				"host_nics":            h.HostNics,
+				"ip_address":           flattenIPv4Subnet(h.IpAddress),
+				"route_table":          h.RouteTable,
+				"active_uplink":        h.ActiveUplink,
+				"internal_bridge_name": h.InternalBridgeName,
+			}
+		}
+		clusters[i] = map[string]interface{}{
+			"ext_id":             v.ExtId,
+			"vlan_identifier":    v.VlanIdentifier,
+			"gateway_ip_address": flattenIPv4Address(v.GatewayIpAddress),
+			"hosts":              hosts,
+		}
+	}
+	return clusters
+}
+
+func flattenVirtualSwitchIgmpSpec(pr *import1.IgmpSpec) []map[string]interface{} {
+	if pr == nil {
+		return nil
+	}
+	igmp := map[string]interface{}{
+		"is_snooping_enabled": pr.IsSnoopingEnabled,
+		"snooping_timeout":    utils.Int64Value(pr.SnoopingTimeout),
+	}
+	if pr.QuerierSpec != nil {
+		igmp["querier_spec"] = []map[string]interface{}{{
+			"is_querier_enabled": pr.QuerierSpec.IsQuerierEnabled,
+			"vlan_id_list":       pr.QuerierSpec.VlanIdList,
+		}}
+	}
+	return []map[string]interface{}{igmp}
+}
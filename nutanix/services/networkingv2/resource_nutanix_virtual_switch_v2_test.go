@@ -0,0 +1,173 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const resourceNameVirtualSwitch = "nutanix_virtual_switch_v2.test"
+
+var virtualSwitches = mockpc.Collection{
+	Path:       "networking/config/virtual-switches",
+	ObjectType: "networking.v4.config.VirtualSwitch",
+	Rel:        "networking:config:virtual-switch",
+}
+
+func TestAccV2NutanixVirtualSwitchResource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vs-%d", r)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVirtualSwitchConfig(name, "ACTIVE_BACKUP", 1500),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "name", name),
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "bond_mode", "ACTIVE_BACKUP"),
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "mtu", "1500"),
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "is_default", "false"),
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "igmp_spec.0.is_snooping_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "clusters.0.ext_id", testVars.Networking.VirtualSwitch.ClusterExtID),
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "clusters.0.hosts.0.ext_id", testVars.Networking.VirtualSwitch.HostExtID),
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "clusters.0.hosts.0.host_nics.#", fmt.Sprint(len(testVars.Networking.VirtualSwitch.HostNics))),
+					resource.TestCheckResourceAttrSet(resourceNameVirtualSwitch, "clusters.0.hosts.0.internal_bridge_name"),
+				),
+			},
+			{
+				Config: testVirtualSwitchConfig(name, "BALANCE_SLB", 9000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "name", name),
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "bond_mode", "BALANCE_SLB"),
+					resource.TestCheckResourceAttr(resourceNameVirtualSwitch, "mtu", "9000"),
+				),
+			},
+		},
+	})
+}
+
+// testVirtualSwitchConfig is a virtual switch on the free uplinks of a host from the test config
+func testVirtualSwitchConfig(name, bondMode string, mtu int) string {
+	vs := testVars.Networking.VirtualSwitch
+	return fmt.Sprintf(`
+	resource "nutanix_virtual_switch_v2" "test" {
+		name = "%[1]s"
+		description = "virtual switch of the test host"
+		bond_mode = "%[2]s"
+		mtu = %[3]d
+		igmp_spec {
+			is_snooping_enabled = true
+			snooping_timeout = 300
+		}
+		clusters {
+			ext_id = "%[4]s"
+			hosts {
+				ext_id = "%[5]s"
+				host_nics = ["%[6]s"]
+			}
+		}
+	}
+`, name, bondMode, mtu, vs.ClusterExtID, vs.HostExtID, strings.Join(vs.HostNics, `", "`))
+}
+
+func TestUnitV2NutanixVirtualSwitchResource_HostNicsDrift(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(virtualSwitches)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := networkingv2.ResourceNutanixVirtualSwitchV2()
+	cfg := map[string]interface{}{
+		"name":      "tf-vs1",
+		"bond_mode": "ACTIVE_BACKUP",
+		"clusters": []interface{}{map[string]interface{}{
+			"ext_id": "cluster-1",
+			"hosts": []interface{}{map[string]interface{}{
+				"ext_id":    "host-1",
+				"host_nics": []interface{}{"nic-1", "nic-2"},
+			}},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	// a NIC swapped out of band is reported as drift
+	vs, _ := pc.Get(virtualSwitches.Path, d.Id())
+	host := vs["clusters"].([]interface{})[0].(map[string]interface{})["hosts"].([]interface{})[0].(map[string]interface{})
+	host["hostNics"] = []interface{}{"nic-2", "nic-3"}
+	pc.Add(virtualSwitches.Path, vs)
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if diff == nil || diff.Empty() {
+		t.Fatal("host NICs changed outside of terraform should produce a diff")
+	}
+
+	d, err = schema.InternalMap(r.Schema).Data(d.State(), diff)
+	if err != nil {
+		t.Fatalf("data: %v", err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if nics := d.Get("clusters.0.hosts.0.host_nics").(*schema.Set); !nics.Contains("nic-1") || nics.Contains("nic-3") {
+		t.Errorf("host_nics = %v, expected nic-1 and nic-2", nics.List())
+	}
+}
+
+func TestUnitV2NutanixVirtualSwitchResource_DefaultNotDeleted(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(virtualSwitches)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	pc.Add(virtualSwitches.Path, map[string]interface{}{
+		"extId":     "vs0",
+		"name":      "vs0",
+		"bondMode":  "ACTIVE_BACKUP",
+		"isDefault": true,
+		"clusters": []interface{}{map[string]interface{}{
+			"extId": "cluster-1",
+			"hosts": []interface{}{map[string]interface{}{"extId": "host-1", "hostNics": []interface{}{"nic-1"}}},
+		}},
+	})
+
+	r := networkingv2.ResourceNutanixVirtualSwitchV2()
+	d := r.TestResourceData()
+	d.SetId("vs0")
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if diags := r.DeleteContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if _, ok := pc.Get(virtualSwitches.Path, "vs0"); !ok {
+		t.Error("the default virtual switch should not be deleted")
+	}
+}
@@ -0,0 +1,452 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func ResourceNutanixVpnConnectionV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixVpnConnectionV2Create,
+		ReadContext:   ResourceNutanixVpnConnectionV2Read,
+		UpdateContext: ResourceNutanixVpnConnectionV2Update,
+		DeleteContext: ResourceNutanixVpnConnectionV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"local_gateway_reference": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"remote_gateway_reference": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"local_gateway_role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: common.ValidateEnum[import1.GatewayRole](),
+			},
+			"ipsec_config": {
+				Type:     schema.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pre_shared_key": {
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+						"ike_authentication_algorithm": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: common.ValidateEnum[import1.AuthenticationAlgorithm](),
+						},
+						"ike_encryption_algorithm": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: common.ValidateEnum[import1.EncryptionAlgorithm](),
+						},
+						"ike_lifetime_secs": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"ipsec_authentication_algorithm": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: common.ValidateEnum[import1.AuthenticationAlgorithm](),
+						},
+						"ipsec_encryption_algorithm": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: common.ValidateEnum[import1.EncryptionAlgorithm](),
+						},
+						"ipsec_lifetime_secs": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"esp_pfs_dh_group_number": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validation.IntInSlice([]int{14, 19, 20}),
+						},
+						"local_authentication_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"remote_authentication_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"local_vti_ip":  schemaForGatewayIPAddress(false),
+						"remote_vti_ip": schemaForGatewayIPAddress(false),
+					},
+				},
+			},
+			"dpd_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"operation": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: common.ValidateEnum[import1.DpdOperation](),
+						},
+						"interval_secs": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"timeout_secs": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"qos_config": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ingress_limit_mbps": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"egress_limit_mbps": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"dynamic_route_priority": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"advertised_prefixes": schemaForVpnPrefixes(true),
+			"learned_prefixes":    schemaForVpnPrefixes(false),
+			"ipsec_tunnel_status": schemaForVpnStatus(),
+			"ebgp_status":         schemaForVpnStatus(),
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+// schemaForVpnPrefixes is a list of IP prefixes exchanged over eBGP, in the shape of the VPC externally
+// routable prefixes
+func schemaForVpnPrefixes(optional bool) *schema.Schema {
+	prefix := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: optional,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"ip": SchemaForValuePrefixLength(),
+					"prefix_length": {
+						Type:     schema.TypeInt,
+						Required: optional,
+						Computed: !optional,
+					},
+				},
+			},
+		}
+	}
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: optional,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ipv4": prefix(),
+				"ipv6": prefix(),
+			},
+		},
+	}
+}
+
+func ResourceNutanixVpnConnectionV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	inputSpec := import1.VpnConnection{}
+	inputSpec.Name = utils.StringPtr(d.Get("name").(string))
+	inputSpec.LocalGatewayReference = utils.StringPtr(d.Get("local_gateway_reference").(string))
+	inputSpec.RemoteGatewayReference = utils.StringPtr(d.Get("remote_gateway_reference").(string))
+	inputSpec.LocalGatewayRole = common.ExpandEnum[import1.GatewayRole](d.Get("local_gateway_role"))
+	inputSpec.IpsecConfig = expandVpnIpsecConfig(d.Get("ipsec_config").([]interface{}))
+
+	if desc, ok := d.GetOk("description"); ok {
+		inputSpec.Description = utils.StringPtr(desc.(string))
+	}
+	if dpd, ok := d.GetOk("dpd_config"); ok {
+		inputSpec.DpdConfig = expandVpnDpdConfig(dpd.([]interface{}))
+	}
+	if qos, ok := d.GetOk("qos_config"); ok {
+		inputSpec.QosConfig = expandVpnQosConfig(qos.([]interface{}))
+	}
+	if priority, ok := d.GetOk("dynamic_route_priority"); ok {
+		inputSpec.DynamicRoutePriority = utils.IntPtr(priority.(int))
+	}
+	if prefixes, ok := d.GetOk("advertised_prefixes"); ok {
+		inputSpec.AdvertisedPrefixes = expandIPSubnet(prefixes.([]interface{}))
+	}
+
+	resp, err := conn.VpnConnectionAPIInstance.CreateVpnConnection(&inputSpec)
+	if err != nil {
+		return diag.Errorf("error while creating VPN connection : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the VPN connection to be created
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
+		return diag.Errorf("error waiting for VPN connection (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	extID, err := taskDetails.EntityExtID(utils.RelEntityTypeVpnConnection)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(extID)
+	return ResourceNutanixVpnConnectionV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixVpnConnectionV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.VpnConnectionAPIInstance.GetVpnConnectionById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching VPN connection : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.VpnConnection)
+
+	connection := flattenVpnConnection(getResp)
+	// the pre-shared key is not returned by the API, keep the configured one
+	if ipsec := connection["ipsec_config"].([]map[string]interface{}); len(ipsec) > 0 {
+		ipsec[0]["pre_shared_key"] = d.Get("ipsec_config.0.pre_shared_key")
+	}
+	for attr, value := range connection {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func ResourceNutanixVpnConnectionV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	readResp, err := conn.VpnConnectionAPIInstance.GetVpnConnectionById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching VPN connection : %v", err)
+	}
+
+	updateSpec := readResp.Data.GetValue().(import1.VpnConnection)
+	// Extract E-Tag Header
+	etagValue := conn.VpnConnectionAPIInstance.ApiClient.GetEtag(readResp)
+
+	args := make(map[string]interface{})
+	args["If-Match"] = utils.StringPtr(etagValue)
+
+	if d.HasChange("name") {
+		updateSpec.Name = utils.StringPtr(d.Get("name").(string))
+	}
+	if d.HasChange("description") {
+		updateSpec.Description = utils.StringPtr(d.Get("description").(string))
+	}
+	if d.HasChange("local_gateway_role") {
+		updateSpec.LocalGatewayRole = common.ExpandEnum[import1.GatewayRole](d.Get("local_gateway_role"))
+	}
+	// the pre-shared key is not read back, so the IPSec configuration is always sent as configured
+	updateSpec.IpsecConfig = expandVpnIpsecConfig(d.Get("ipsec_config").([]interface{}))
+	if d.HasChange("dpd_config") {
+		updateSpec.DpdConfig = expandVpnDpdConfig(d.Get("dpd_config").([]interface{}))
+	}
+	if d.HasChange("qos_config") {
+		updateSpec.QosConfig = expandVpnQosConfig(d.Get("qos_config").([]interface{}))
+	}
+	if d.HasChange("dynamic_route_priority") {
+		updateSpec.DynamicRoutePriority = utils.IntPtr(d.Get("dynamic_route_priority").(int))
+	}
+	if d.HasChange("advertised_prefixes") {
+		updateSpec.AdvertisedPrefixes = expandIPSubnet(d.Get("advertised_prefixes").([]interface{}))
+	}
+
+	resp, err := conn.VpnConnectionAPIInstance.UpdateVpnConnectionById(utils.StringPtr(d.Id()), &updateSpec, args)
+	if err != nil {
+		return diag.Errorf("error while updating VPN connection : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the VPN connection to be updated
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for VPN connection (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return ResourceNutanixVpnConnectionV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixVpnConnectionV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.VpnConnectionAPIInstance.DeleteVpnConnectionById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while deleting VPN connection : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the VPN connection to be deleted
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
+		return diag.Errorf("error waiting for VPN connection (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func expandVpnIpsecConfig(pr []interface{}) *import1.IpsecConfig {
+	if len(pr) == 0 || pr[0] == nil {
+		return nil
+	}
+	val := pr[0].(map[string]interface{})
+
+	ipsec := import1.NewIpsecConfig()
+	ipsec.PreSharedKey = utils.StringPtr(val["pre_shared_key"].(string))
+	ipsec.IkeAuthenticationAlgorithm = common.ExpandEnum[import1.AuthenticationAlgorithm](val["ike_authentication_algorithm"])
+	ipsec.IkeEncryptionAlgorithm = common.ExpandEnum[import1.EncryptionAlgorithm](val["ike_encryption_algorithm"])
+	ipsec.IpsecAuthenticationAlgorithm = common.ExpandEnum[import1.AuthenticationAlgorithm](val["ipsec_authentication_algorithm"])
+	ipsec.IpsecEncryptionAlgorithm = common.ExpandEnum[import1.EncryptionAlgorithm](val["ipsec_encryption_algorithm"])
+
+	if lifetime, ok := val["ike_lifetime_secs"].(int); ok && lifetime > 0 {
+		ipsec.IkeLifetimeSecs = utils.Int64Ptr(int64(lifetime))
+	}
+	if lifetime, ok := val["ipsec_lifetime_secs"].(int); ok && lifetime > 0 {
+		ipsec.IpsecLifetimeSecs = utils.Int64Ptr(int64(lifetime))
+	}
+	if group, ok := val["esp_pfs_dh_group_number"].(int); ok && group > 0 {
+		ipsec.EspPfsDhGroupNumber = utils.IntPtr(group)
+	}
+	if localID, ok := val["local_authentication_id"].(string); ok && localID != "" {
+		ipsec.LocalAuthenticationId = utils.StringPtr(localID)
+	}
+	if remoteID, ok := val["remote_authentication_id"].(string); ok && remoteID != "" {
+		ipsec.RemoteAuthenticationId = utils.StringPtr(remoteID)
+	}
+	if vti, ok := val["local_vti_ip"].([]interface{}); ok && len(vti) > 0 {
+		ipsec.LocalVtiIp = expandIPAddressMap(vti)
+	}
+	if vti, ok := val["remote_vti_ip"].([]interface{}); ok && len(vti) > 0 {
+		ipsec.RemoteVtiIp = expandIPAddressMap(vti)
+	}
+	return ipsec
+}
+
+func expandVpnDpdConfig(pr []interface{}) *import1.DpdConfig {
+	if len(pr) == 0 || pr[0] == nil {
+		return nil
+	}
+	val := pr[0].(map[string]interface{})
+
+	dpd := import1.NewDpdConfig()
+	dpd.Operation = common.ExpandEnum[import1.DpdOperation](val["operation"])
+	if interval, ok := val["interval_secs"].(int); ok && interval > 0 {
+		dpd.IntervalSecs = utils.Int64Ptr(int64(interval))
+	}
+	if timeout, ok := val["timeout_secs"].(int); ok && timeout > 0 {
+		dpd.TimeoutSecs = utils.Int64Ptr(int64(timeout))
+	}
+	return dpd
+}
+
+func expandVpnQosConfig(pr []interface{}) *import1.QosConfig {
+	if len(pr) == 0 || pr[0] == nil {
+		return nil
+	}
+	val := pr[0].(map[string]interface{})
+
+	qos := import1.NewQosConfig()
+	if ingress, ok := val["ingress_limit_mbps"].(int); ok && ingress > 0 {
+		qos.IngressLimitMbps = utils.Int64Ptr(int64(ingress))
+	}
+	if egress, ok := val["egress_limit_mbps"].(int); ok && egress > 0 {
+		qos.EgressLimitMbps = utils.Int64Ptr(int64(egress))
+	}
+	return qos
+}
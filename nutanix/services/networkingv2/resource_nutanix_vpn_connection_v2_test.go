@@ -0,0 +1,195 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const resourceNameVpnConnection = "nutanix_vpn_connection_v2.test"
+
+var vpnConnections = mockpc.Collection{
+	Path:       "networking/config/vpn-connections",
+	ObjectType: "networking.v4.config.VpnConnection",
+	Rel:        "networking:config:vpn-connection",
+}
+
+func TestAccV2NutanixVpnConnectionResource_StaticRouting(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vpn-connection-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVpnConnectionConfig(name, vlanID, false, 150),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "name", name),
+					resource.TestCheckResourceAttrPair(resourceNameVpnConnection, "local_gateway_reference", resourceNameVpnLocalGateway, "id"),
+					resource.TestCheckResourceAttrPair(resourceNameVpnConnection, "remote_gateway_reference", resourceNameVpnRemoteGateway, "id"),
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "local_gateway_role", "INITIATOR"),
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "ipsec_config.0.pre_shared_key", "tf-test-psk-secret"),
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "ipsec_config.0.ike_encryption_algorithm", "AES256"),
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "ipsec_config.0.esp_pfs_dh_group_number", "19"),
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "dpd_config.0.operation", "RESTART"),
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "dynamic_route_priority", "150"),
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "advertised_prefixes.0.ipv4.0.ip.0.value", "172.16.0.0"),
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "advertised_prefixes.0.ipv4.0.prefix_length", "16"),
+					resource.TestCheckResourceAttrSet(resourceNameVpnConnection, "ipsec_tunnel_status.#"),
+				),
+			},
+			{
+				Config: testVpnConnectionConfig(name, vlanID, false, 200),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "dynamic_route_priority", "200"),
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "ipsec_config.0.pre_shared_key", "tf-test-psk-secret"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccV2NutanixVpnConnectionResource_Ebgp(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vpn-connection-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVpnConnectionConfig(name, vlanID, true, 150),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameVpnConnection, "name", name),
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "local_vpn_service.0.ebgp_config.0.asn", "65001"),
+					resource.TestCheckResourceAttrSet(resourceNameVpnConnection, "ebgp_status.#"),
+					resource.TestCheckResourceAttrSet(resourceNameVpnConnection, "learned_prefixes.#"),
+				),
+			},
+		},
+	})
+}
+
+func testVpnConnectionConfig(name string, vlanID int, ebgp bool, priority int) string {
+	return testVpnGatewayConfig(name, vlanID, ebgp) + fmt.Sprintf(`
+	resource "nutanix_vpn_connection_v2" "test" {
+		name = "%[1]s"
+		description = "ipsec tunnel to the on-premises appliance"
+		local_gateway_reference = nutanix_vpn_gateway_v2.local.id
+		remote_gateway_reference = nutanix_vpn_gateway_v2.remote.id
+		local_gateway_role = "INITIATOR"
+		ipsec_config {
+			pre_shared_key = "tf-test-psk-secret"
+			ike_encryption_algorithm = "AES256"
+			ike_authentication_algorithm = "SHA256"
+			ipsec_encryption_algorithm = "AES256"
+			ipsec_authentication_algorithm = "SHA256"
+			esp_pfs_dh_group_number = 19
+		}
+		dpd_config {
+			operation = "RESTART"
+			interval_secs = 30
+			timeout_secs = 120
+		}
+		dynamic_route_priority = %[2]d
+		advertised_prefixes {
+			ipv4 {
+				ip {
+					value = "172.16.0.0"
+				}
+				prefix_length = 16
+			}
+		}
+	}
+`, name, priority)
+}
+
+func TestUnitV2NutanixVpnConnectionResource_PreSharedKeyKeptOnUpdate(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(vpnConnections)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := networkingv2.ResourceNutanixVpnConnectionV2()
+	cfg := map[string]interface{}{
+		"name":                     "tf-vpn-connection",
+		"local_gateway_reference":  "local-gateway",
+		"remote_gateway_reference": "remote-gateway",
+		"local_gateway_role":       "INITIATOR",
+		"ipsec_config": []interface{}{map[string]interface{}{
+			"pre_shared_key": "psk-secret",
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	// the pre-shared key is not returned by Prism Central
+	connection, _ := pc.Get(vpnConnections.Path, d.Id())
+	delete(connection["ipsecConfig"].(map[string]interface{}), "preSharedKey")
+	pc.Add(vpnConnections.Path, connection)
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if got := d.Get("ipsec_config.0.pre_shared_key"); got != "psk-secret" {
+		t.Errorf("pre_shared_key = %v, expected the configured key", got)
+	}
+
+	cfg["dynamic_route_priority"] = 200
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(d.State(), diff)
+	if err != nil {
+		t.Fatalf("data: %v", err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	for _, req := range pc.Requests() {
+		if req.Method == http.MethodPut && !strings.Contains(string(req.Body), `"psk-secret"`) {
+			t.Errorf("vpn connection updated without its pre-shared key: %s", req.Body)
+		}
+	}
+}
+
+func TestUnitV2NutanixVpnConnectionResource_Validation(t *testing.T) {
+	r := networkingv2.ResourceNutanixVpnConnectionV2()
+	for attr, value := range map[string]interface{}{
+		"local_gateway_role": "RESPONDER",
+	} {
+		if _, errs := r.Schema[attr].ValidateFunc(value, attr); len(errs) == 0 {
+			t.Errorf("%s = %v, expected a validation error", attr, value)
+		}
+	}
+	ipsec := r.Schema["ipsec_config"].Elem.(*schema.Resource).Schema
+	if !ipsec["pre_shared_key"].Sensitive {
+		t.Error("pre_shared_key is not sensitive")
+	}
+	for attr, value := range map[string]interface{}{
+		"esp_pfs_dh_group_number":  5,
+		"ike_encryption_algorithm": "DES",
+	} {
+		if _, errs := ipsec[attr].ValidateFunc(value, attr); len(errs) == 0 {
+			t.Errorf("%s = %v, expected a validation error", attr, value)
+		}
+	}
+}
@@ -0,0 +1,485 @@
+package networkingv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/networking/v4/config"
+	import4 "github.com/nutanix/ntnx-api-golang-clients/networking-go-client/v4/models/prism/v4/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+func ResourceNutanixVpnGatewayV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixVpnGatewayV2Create,
+		ReadContext:   ResourceNutanixVpnGatewayV2Read,
+		UpdateContext: ResourceNutanixVpnGatewayV2Update,
+		DeleteContext: ResourceNutanixVpnGatewayV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		// a gateway is either deployed locally or describes a remote peer, it can't be switched in place
+		CustomizeDiff: customdiff.ForceNewIfChange("local_vpn_service", func(ctx context.Context, old, new, meta interface{}) bool {
+			return len(old.([]interface{})) != len(new.([]interface{}))
+		}),
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vpc_reference": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"gateway_device_vendor": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"deployment": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				RequiredWith: []string{"local_vpn_service"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"cluster_reference": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+						"should_synchronize_system_dns_servers": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"should_synchronize_system_ntp_servers": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+						"vcenter_datastore_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"interfaces": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"subnet_reference": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"ip_address":              schemaForGatewayIPAddress(false),
+									"default_gateway_address": schemaForGatewayIPAddress(false),
+									"mtu": {
+										Type:     schema.TypeInt,
+										Optional: true,
+										Computed: true,
+									},
+									"mac_address": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"local_vpn_service": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"local_vpn_service", "remote_vpn_service"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ebgp_config": schemaForVpnEbgpConfig(),
+					},
+				},
+			},
+			"remote_vpn_service": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_address": schemaForGatewayIPAddress(true),
+						"ebgp_config":     schemaForVpnEbgpConfig(),
+						"should_install_xi_route": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"service_address": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ipv4": SchemaForValuePrefixLength(),
+						"ipv6": SchemaForValuePrefixLength(),
+					},
+				},
+			},
+			"status": schemaForVpnStatus(),
+			"installed_software_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"supported_software_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"vm_reference": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"href": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rel": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"metadata": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: DatasourceMetadataSchemaV2(),
+				},
+			},
+		},
+	}
+}
+
+func schemaForGatewayIPAddress(required bool) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: required,
+		Optional: !required,
+		Computed: !required,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ipv4": SchemaForValuePrefixLength(),
+				"ipv6": SchemaForValuePrefixLength(),
+			},
+		},
+	}
+}
+
+// schemaForVpnEbgpConfig is the eBGP session run over the VPN connections of a gateway. Without it the
+// connections use static routing.
+func schemaForVpnEbgpConfig() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"asn": {
+					Type:         schema.TypeInt,
+					Required:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"password": {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+				},
+				"should_redistribute_routes": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func schemaForVpnStatus() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"state": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"message": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func ResourceNutanixVpnGatewayV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	inputSpec := import1.Gateway{}
+	if name, ok := d.GetOk("name"); ok {
+		inputSpec.Name = utils.StringPtr(name.(string))
+	}
+	if desc, ok := d.GetOk("description"); ok {
+		inputSpec.Description = utils.StringPtr(desc.(string))
+	}
+	if vpcRef, ok := d.GetOk("vpc_reference"); ok {
+		inputSpec.VpcReference = utils.StringPtr(vpcRef.(string))
+	}
+	if vendor, ok := d.GetOk("gateway_device_vendor"); ok {
+		inputSpec.GatewayDeviceVendor = utils.StringPtr(vendor.(string))
+	}
+	if deployment, ok := d.GetOk("deployment"); ok {
+		inputSpec.Deployment = expandGatewayDeployment(deployment.([]interface{}))
+	}
+	services, err := expandVpnGatewayServices(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	inputSpec.Services = services
+
+	resp, err := conn.GatewayAPIInstance.CreateGateway(&inputSpec)
+	if err != nil {
+		return diag.Errorf("error while creating VPN gateway : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the VPN gateway to be created
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
+		return diag.Errorf("error waiting for VPN gateway (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	extID, err := taskDetails.EntityExtID(utils.RelEntityTypeGateway)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(extID)
+	return ResourceNutanixVpnGatewayV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixVpnGatewayV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.GatewayAPIInstance.GetGatewayById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching VPN gateway : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(import1.Gateway)
+
+	gateway := flattenVpnGateway(getResp)
+	// the eBGP password is not returned by the API, keep the configured one
+	for _, service := range []string{"local_vpn_service", "remote_vpn_service"} {
+		keepEbgpPassword(gateway[service].([]map[string]interface{}), d.Get(service+".0.ebgp_config.0.password").(string))
+	}
+	for attr, value := range gateway {
+		if err := d.Set(attr, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func ResourceNutanixVpnGatewayV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	readResp, err := conn.GatewayAPIInstance.GetGatewayById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching VPN gateway : %v", err)
+	}
+
+	updateSpec := readResp.Data.GetValue().(import1.Gateway)
+	// Extract E-Tag Header
+	etagValue := conn.GatewayAPIInstance.ApiClient.GetEtag(readResp)
+
+	args := make(map[string]interface{})
+	args["If-Match"] = utils.StringPtr(etagValue)
+
+	if d.HasChange("name") {
+		updateSpec.Name = utils.StringPtr(d.Get("name").(string))
+	}
+	if d.HasChange("description") {
+		updateSpec.Description = utils.StringPtr(d.Get("description").(string))
+	}
+	if d.HasChange("gateway_device_vendor") {
+		updateSpec.GatewayDeviceVendor = utils.StringPtr(d.Get("gateway_device_vendor").(string))
+	}
+	if d.HasChanges("local_vpn_service", "remote_vpn_service") {
+		services, err := expandVpnGatewayServices(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		updateSpec.Services = services
+	}
+
+	resp, err := conn.GatewayAPIInstance.UpdateGatewayById(utils.StringPtr(d.Id()), &updateSpec, args)
+	if err != nil {
+		return diag.Errorf("error while updating VPN gateway : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the VPN gateway to be updated
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for VPN gateway (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return ResourceNutanixVpnGatewayV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixVpnGatewayV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).NetworkingAPI
+
+	resp, err := conn.GatewayAPIInstance.DeleteGatewayById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while deleting VPN gateway : %v", err)
+	}
+
+	TaskRef := resp.Data.GetValue().(import4.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+
+	// Wait for the VPN gateway to be deleted
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
+		return diag.Errorf("error waiting for VPN gateway (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func expandVpnGatewayServices(d *schema.ResourceData) (*import1.OneOfGatewayServices, error) {
+	services := import1.NewOneOfGatewayServices()
+
+	if local, ok := d.GetOk("local_vpn_service"); ok {
+		localServices := import1.NewLocalNetworkServices()
+		localServices.LocalVpnService = import1.NewLocalVpnService()
+		if val, ok := local.([]interface{})[0].(map[string]interface{}); ok {
+			localServices.LocalVpnService.EbgpConfig = expandVpnBgpConfig(val["ebgp_config"])
+		}
+		return services, services.SetValue(*localServices)
+	}
+
+	remoteServices := import1.NewRemoteNetworkServices()
+	remoteServices.RemoteVpnService = import1.NewRemoteVpnService()
+	if remote, ok := d.GetOk("remote_vpn_service"); ok {
+		val := remote.([]interface{})[0].(map[string]interface{})
+		remoteServices.RemoteVpnService.ServiceAddress = expandIPAddressMap(val["service_address"])
+		remoteServices.RemoteVpnService.EbgpConfig = expandVpnBgpConfig(val["ebgp_config"])
+		if installRoute, ok := val["should_install_xi_route"].(bool); ok {
+			remoteServices.RemoteVpnService.ShouldInstallXiRoute = utils.BoolPtr(installRoute)
+		}
+	}
+	return services, services.SetValue(*remoteServices)
+}
+
+func expandVpnBgpConfig(pr interface{}) *import1.BgpConfig {
+	prI, ok := pr.([]interface{})
+	if !ok || len(prI) == 0 || prI[0] == nil {
+		return nil
+	}
+	val := prI[0].(map[string]interface{})
+
+	bgp := import1.NewBgpConfig()
+	bgp.Asn = utils.Int64Ptr(int64(val["asn"].(int)))
+	if password := val["password"].(string); password != "" {
+		bgp.Password = utils.StringPtr(password)
+	}
+	if redistribute, ok := val["should_redistribute_routes"].(bool); ok {
+		bgp.ShouldRedistributeRoutes = utils.BoolPtr(redistribute)
+	}
+	return bgp
+}
+
+func expandGatewayDeployment(pr []interface{}) *import1.GatewayDeployment {
+	if len(pr) == 0 || pr[0] == nil {
+		return nil
+	}
+	val := pr[0].(map[string]interface{})
+
+	deployment := import1.NewGatewayDeployment()
+	if clusterRef, ok := val["cluster_reference"]; ok && clusterRef.(string) != "" {
+		deployment.ClusterReference = utils.StringPtr(clusterRef.(string))
+	}
+	if syncDNS, ok := val["should_synchronize_system_dns_servers"]; ok {
+		deployment.ShouldSynchronizeSystemDnsServers = utils.BoolPtr(syncDNS.(bool))
+	}
+	if syncNtp, ok := val["should_synchronize_system_ntp_servers"]; ok {
+		deployment.ShouldSynchronizeSystemNtpServers = utils.BoolPtr(syncNtp.(bool))
+	}
+	if datastore, ok := val["vcenter_datastore_name"]; ok && datastore.(string) != "" {
+		deployment.VcenterDatastoreName = utils.StringPtr(datastore.(string))
+	}
+	if interfaces, ok := val["interfaces"]; ok {
+		for _, v := range interfaces.([]interface{}) {
+			iface := v.(map[string]interface{})
+			gatewayInterface := import1.NewGatewayInterface()
+			gatewayInterface.SubnetReference = utils.StringPtr(iface["subnet_reference"].(string))
+			if ip, ok := iface["ip_address"]; ok && len(ip.([]interface{})) > 0 {
+				gatewayInterface.IpAddress = expandIPAddressMap(ip)
+			}
+			if defaultGateway, ok := iface["default_gateway_address"]; ok && len(defaultGateway.([]interface{})) > 0 {
+				gatewayInterface.DefaultGatewayAddress = expandIPAddressMap(defaultGateway)
+			}
+			if mtu, ok := iface["mtu"]; ok && mtu.(int) > 0 {
+				gatewayInterface.Mtu = utils.IntPtr(mtu.(int))
+			}
+			deployment.Interfaces = append(deployment.Interfaces, *gatewayInterface)
+		}
+	}
+	return deployment
+}
+
+// keepEbgpPassword sets the configured eBGP password on a flattened VPN service
+func keepEbgpPassword(service []map[string]interface{}, password string) {
+	if len(service) == 0 || password == "" {
+		return
+	}
+	if ebgp, ok := service[0]["ebgp_config"].([]map[string]interface{}); ok && len(ebgp) > 0 {
+		ebgp[0]["password"] = password
+	}
+}
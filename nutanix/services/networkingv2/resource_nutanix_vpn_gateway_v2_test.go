@@ -0,0 +1,222 @@
+package networkingv2_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/networkingv2"
+)
+
+const (
+	resourceNameVpnLocalGateway  = "nutanix_vpn_gateway_v2.local"
+	resourceNameVpnRemoteGateway = "nutanix_vpn_gateway_v2.remote"
+)
+
+var gateways = mockpc.Collection{
+	Path:       "networking/config/gateways",
+	ObjectType: "networking.v4.config.Gateway",
+	Rel:        "networking:config:gateway",
+}
+
+func TestAccV2NutanixVpnGatewayResource_StaticRouting(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vpn-gateway-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVpnGatewayConfig(name, vlanID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "name", name),
+					resource.TestCheckResourceAttrPair(resourceNameVpnLocalGateway, "vpc_reference", "nutanix_vpc_v2.test", "id"),
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "local_vpn_service.#", "1"),
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "local_vpn_service.0.ebgp_config.#", "0"),
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "remote_vpn_service.#", "0"),
+					resource.TestCheckResourceAttrSet(resourceNameVpnLocalGateway, "status.#"),
+					resource.TestCheckResourceAttrSet(resourceNameVpnLocalGateway, "service_address.#"),
+					resource.TestCheckResourceAttr(resourceNameVpnRemoteGateway, "remote_vpn_service.0.service_address.0.ipv4.0.value", "203.0.113.10"),
+					resource.TestCheckResourceAttr(resourceNameVpnRemoteGateway, "remote_vpn_service.0.ebgp_config.#", "0"),
+				),
+			},
+			{
+				Config: testVpnGatewayConfig(name+"-updated", vlanID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "name", name+"-updated"),
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "local_vpn_service.0.ebgp_config.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccV2NutanixVpnGatewayResource_Ebgp(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vpn-gateway-%d", r)
+	vlanID := acctest.RandIntRange(1, 999)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVpnGatewayConfig(name, vlanID, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "local_vpn_service.0.ebgp_config.0.asn", "65001"),
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "local_vpn_service.0.ebgp_config.0.password", "tf-test-ebgp-secret"),
+					resource.TestCheckResourceAttr(resourceNameVpnRemoteGateway, "remote_vpn_service.0.ebgp_config.0.asn", "65100"),
+				),
+			},
+			{
+				// removing the eBGP config goes back to static routing
+				Config: testVpnGatewayConfig(name, vlanID, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceNameVpnLocalGateway, "local_vpn_service.0.ebgp_config.#", "0"),
+					resource.TestCheckResourceAttr(resourceNameVpnRemoteGateway, "remote_vpn_service.0.ebgp_config.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccV2NutanixVpnGatewayResource_LocalAndRemoteService(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "nutanix_vpn_gateway_v2" "test" {
+					name = "tf-test-vpn-gateway-invalid"
+					local_vpn_service {}
+					remote_vpn_service {
+						service_address {
+							ipv4 {
+								value = "203.0.113.10"
+							}
+						}
+					}
+				}`,
+				ExpectError: regexp.MustCompile("only one of `local_vpn_service,remote_vpn_service` can be specified"),
+			},
+		},
+	})
+}
+
+// testVpnEbgpConfig is the ebgp_config block of a gateway, the gateways without it use static routing
+func testVpnEbgpConfig(asn int) string {
+	return fmt.Sprintf(`
+			ebgp_config {
+				asn = %d
+				password = "tf-test-ebgp-secret"
+			}`, asn)
+}
+
+func testVpnGatewayConfig(name string, vlanID int, ebgp bool) string {
+	localEbgp, remoteEbgp := "", ""
+	if ebgp {
+		localEbgp, remoteEbgp = testVpnEbgpConfig(65001), testVpnEbgpConfig(65100)
+	}
+	return testAccVpcWithExternalSubnetConfig(vlanID) + fmt.Sprintf(`
+	resource "nutanix_vpn_gateway_v2" "local" {
+		name = "%[1]s"
+		description = "vpn gateway of the vpc"
+		vpc_reference = nutanix_vpc_v2.test.id
+		deployment {
+			cluster_reference = local.cluster0
+		}
+		local_vpn_service {%[2]s
+		}
+	}
+
+	resource "nutanix_vpn_gateway_v2" "remote" {
+		name = "%[1]s-remote"
+		description = "on-premises vpn appliance"
+		gateway_device_vendor = "Juniper"
+		remote_vpn_service {
+			service_address {
+				ipv4 {
+					value = "203.0.113.10"
+				}
+			}%[3]s
+		}
+	}
+`, name, localEbgp, remoteEbgp)
+}
+
+func TestUnitV2NutanixVpnGatewayResource_StaticToEbgpRouting(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(gateways)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := networkingv2.ResourceNutanixVpnGatewayV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":              "tf-vpn-gateway",
+		"vpc_reference":     "vpc-1",
+		"local_vpn_service": []interface{}{map[string]interface{}{}},
+	})
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	gateway, _ := pc.Get(gateways.Path, d.Id())
+	if service := gateway["services"].(map[string]interface{})["localVpnService"].(map[string]interface{}); service["ebgpConfig"] != nil {
+		t.Errorf("local vpn service = %v, expected static routing", service)
+	}
+
+	// eBGP is enabled in place, with its password
+	if err := d.Set("local_vpn_service", []interface{}{map[string]interface{}{
+		"ebgp_config": []interface{}{map[string]interface{}{"asn": 65001, "password": "bgp-secret"}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	gateway, _ = pc.Get(gateways.Path, d.Id())
+	ebgp := gateway["services"].(map[string]interface{})["localVpnService"].(map[string]interface{})["ebgpConfig"].(map[string]interface{})
+	if ebgp["asn"] != float64(65001) || ebgp["password"] != "bgp-secret" {
+		t.Errorf("ebgp config = %v, expected asn 65001 with its password", ebgp)
+	}
+
+	// the password is not returned by Prism Central
+	delete(ebgp, "password")
+	pc.Add(gateways.Path, gateway)
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if got := d.Get("local_vpn_service.0.ebgp_config.0.password"); got != "bgp-secret" {
+		t.Errorf("ebgp password = %v, expected the configured password", got)
+	}
+
+	// removing the eBGP config goes back to static routing
+	if err := d.Set("local_vpn_service", []interface{}{map[string]interface{}{}}); err != nil {
+		t.Fatal(err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	var update string
+	for _, req := range pc.Requests() {
+		if req.Method == http.MethodPut {
+			update = string(req.Body)
+		}
+	}
+	if strings.Contains(update, "ebgpConfig") {
+		t.Errorf("last update = %s, expected no ebgp config", update)
+	}
+}
@@ -32,9 +32,9 @@ func dataSourceNutanixKarbonClusterRead(ctx context.Context, d *schema.ResourceD
 	var resp *karbon.ClusterIntentResponse
 
 	if iok {
-		resp, err = conn.Cluster.GetKarbonCluster(karbonClusterID.(string))
+		resp, err = conn.Cluster.GetKarbonCluster(ctx, karbonClusterID.(string))
 	} else {
-		resp, err = conn.Cluster.GetKarbonCluster(karbonClusterNameInput.(string))
+		resp, err = conn.Cluster.GetKarbonCluster(ctx, karbonClusterNameInput.(string))
 	}
 
 	if err != nil {
@@ -43,15 +43,15 @@ func dataSourceNutanixKarbonClusterRead(ctx context.Context, d *schema.ResourceD
 	}
 
 	karbonClusterName := *resp.Name
-	flattenedEtcdNodepool, err := flattenNodePools(d, conn, "etcd_node_pool", karbonClusterName, resp.ETCDConfig.NodePools)
+	flattenedEtcdNodepool, err := flattenNodePools(ctx, d, conn, "etcd_node_pool", karbonClusterName, resp.ETCDConfig.NodePools)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	flattenedWorkerNodepool, err := flattenNodePools(d, conn, "worker_node_pool", karbonClusterName, resp.WorkerConfig.NodePools)
+	flattenedWorkerNodepool, err := flattenNodePools(ctx, d, conn, "worker_node_pool", karbonClusterName, resp.WorkerConfig.NodePools)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	flattenedMasterNodepool, err := flattenNodePools(d, conn, "master_node_pool", karbonClusterName, resp.MasterConfig.NodePools)
+	flattenedMasterNodepool, err := flattenNodePools(ctx, d, conn, "master_node_pool", karbonClusterName, resp.MasterConfig.NodePools)
 	if err != nil {
 		return diag.FromErr(err)
 	}
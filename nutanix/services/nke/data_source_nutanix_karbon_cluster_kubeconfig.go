@@ -63,9 +63,9 @@ func dataSourceNutanixKarbonClusterKubeconfigRead(ctx context.Context, d *schema
 	var resp *karbon.ClusterKubeconfig
 
 	if iok {
-		resp, err = GetKubeConfigForCluster(conn, karbonClusterID.(string))
+		resp, err = GetKubeConfigForCluster(ctx, conn, karbonClusterID.(string))
 	} else {
-		resp, err = GetKubeConfigForCluster(conn, karbonClusterName.(string))
+		resp, err = GetKubeConfigForCluster(ctx, conn, karbonClusterName.(string))
 	}
 
 	if err != nil {
@@ -96,8 +96,8 @@ func dataSourceNutanixKarbonClusterKubeconfigRead(ctx context.Context, d *schema
 	return nil
 }
 
-func GetKubeConfigForCluster(con *karbon.Client, karbonClusterName string) (*karbon.ClusterKubeconfig, error) {
-	kubeconfig, err := con.Cluster.GetKubeConfigForKarbonCluster(karbonClusterName)
+func GetKubeConfigForCluster(ctx context.Context, con *karbon.Client, karbonClusterName string) (*karbon.ClusterKubeconfig, error) {
+	kubeconfig, err := con.Cluster.GetKubeConfigForKarbonCluster(ctx, karbonClusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -33,7 +33,7 @@ func dataSourceNutanixKarbonClusterSSHRead(ctx context.Context, d *schema.Resour
 	var karbonClusterName string
 	if iok {
 		var c *karbon.ClusterIntentResponse
-		c, err = conn.Cluster.GetKarbonCluster(karbonClusterID.(string))
+		c, err = conn.Cluster.GetKarbonCluster(ctx, karbonClusterID.(string))
 		if err != nil {
 			return diag.Errorf("unable to find cluster with id %s: %s", karbonClusterID, err)
 		}
@@ -42,7 +42,7 @@ func dataSourceNutanixKarbonClusterSSHRead(ctx context.Context, d *schema.Resour
 		karbonClusterName = karbonClusterNameInput.(string)
 	}
 
-	resp, err = conn.Cluster.GetSSHConfigForKarbonCluster(karbonClusterName)
+	resp, err = conn.Cluster.GetSSHConfigForKarbonCluster(ctx, karbonClusterName)
 	if err != nil {
 		d.SetId("")
 		return diag.FromErr(err)
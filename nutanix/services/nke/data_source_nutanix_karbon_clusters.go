@@ -31,7 +31,7 @@ func dataSourceNutanixKarbonClustersRead(ctx context.Context, d *schema.Resource
 	conn := meta.(*conns.Client).KarbonAPI
 	setTimeout(meta)
 	// Make request to the API
-	resp, err := conn.Cluster.ListKarbonClusters()
+	resp, err := conn.Cluster.ListKarbonClusters(ctx)
 	if err != nil {
 		d.SetId("")
 		return nil
@@ -45,15 +45,15 @@ func dataSourceNutanixKarbonClustersRead(ctx context.Context, d *schema.Resource
 			return diag.Errorf("error searching for cluster via legacy API: %s", err)
 		}
 		karbonClusterName := *v.Name
-		flattenedEtcdNodepool, err := flattenNodePools(d, conn, "etcd_node_pool", karbonClusterName, v.ETCDConfig.NodePools)
+		flattenedEtcdNodepool, err := flattenNodePools(ctx, d, conn, "etcd_node_pool", karbonClusterName, v.ETCDConfig.NodePools)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		flattenedWorkerNodepool, err := flattenNodePools(d, conn, "worker_node_pool", karbonClusterName, v.WorkerConfig.NodePools)
+		flattenedWorkerNodepool, err := flattenNodePools(ctx, d, conn, "worker_node_pool", karbonClusterName, v.WorkerConfig.NodePools)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		flattenedMasterNodepool, err := flattenNodePools(d, conn, "master_node_pool", karbonClusterName, v.MasterConfig.NodePools)
+		flattenedMasterNodepool, err := flattenNodePools(ctx, d, conn, "master_node_pool", karbonClusterName, v.MasterConfig.NodePools)
 		if err != nil {
 			return diag.FromErr(err)
 		}
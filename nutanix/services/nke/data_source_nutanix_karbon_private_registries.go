@@ -31,7 +31,7 @@ func dataSourceNutanixKarbonPrivateRegistriesRead(ctx context.Context, d *schema
 	conn := meta.(*conns.Client).KarbonAPI
 	setTimeout(meta)
 	// Make request to the API
-	resp, err := conn.PrivateRegistry.ListKarbonPrivateRegistries()
+	resp, err := conn.PrivateRegistry.ListKarbonPrivateRegistries(ctx)
 	if err != nil {
 		d.SetId("")
 		return nil
@@ -33,9 +33,9 @@ func dataSourceNutanixKarbonPrivateRegistryRead(ctx context.Context, d *schema.R
 	var resp *karbon.PrivateRegistryResponse
 
 	if iok {
-		resp, err = findPrivateRegistryByUUID(conn, karbonPrivateRegistryID.(string))
+		resp, err = findPrivateRegistryByUUID(ctx, conn, karbonPrivateRegistryID.(string))
 	} else {
-		resp, err = findPrivateRegistryByName(conn, karbonPrivateRegistryName.(string))
+		resp, err = findPrivateRegistryByName(ctx, conn, karbonPrivateRegistryName.(string))
 	}
 
 	if err != nil {
@@ -57,12 +57,12 @@ func dataSourceNutanixKarbonPrivateRegistryRead(ctx context.Context, d *schema.R
 	return nil
 }
 
-func findPrivateRegistryByName(conn *karbon.Client, name string) (*karbon.PrivateRegistryResponse, error) {
-	return conn.PrivateRegistry.GetKarbonPrivateRegistry(name)
+func findPrivateRegistryByName(ctx context.Context, conn *karbon.Client, name string) (*karbon.PrivateRegistryResponse, error) {
+	return conn.PrivateRegistry.GetKarbonPrivateRegistry(ctx, name)
 }
 
-func findPrivateRegistryByUUID(conn *karbon.Client, uuid string) (*karbon.PrivateRegistryResponse, error) {
-	resp, err := conn.PrivateRegistry.ListKarbonPrivateRegistries()
+func findPrivateRegistryByUUID(ctx context.Context, conn *karbon.Client, uuid string) (*karbon.PrivateRegistryResponse, error) {
+	resp, err := conn.PrivateRegistry.ListKarbonPrivateRegistries(ctx)
 	if err != nil {
 		return nil, err
 	}
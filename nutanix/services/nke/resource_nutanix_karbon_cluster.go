@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strings"
 	"time"
 
@@ -100,7 +101,6 @@ func KarbonClusterResourceMap() map[string]*schema.Schema {
 		"version": {
 			Type:     schema.TypeString,
 			Required: true,
-			ForceNew: true,
 		},
 		"status": {
 			Type:     schema.TypeString,
@@ -341,7 +341,6 @@ func nodePoolSchema(defaultNodepoolName string, forceNewNodes bool, cpuDefault i
 				"node_os_version": {
 					Type:     schema.TypeString,
 					Required: true,
-					ForceNew: true,
 				},
 				"num_instances": {
 					Type:         schema.TypeInt,
@@ -415,7 +414,7 @@ func resourceNutanixKarbonClusterCreate(ctx context.Context, d *schema.ResourceD
 	setTimeout(meta)
 	// Node pools
 	var err error
-	karbonVersion, err := conn.Meta.GetSemanticVersion()
+	karbonVersion, err := conn.Meta.GetSemanticVersion(ctx)
 	if err != nil {
 		return diag.Errorf("unable to get karbon version during cluster create: %s", err)
 	}
@@ -517,7 +516,7 @@ func resourceNutanixKarbonClusterCreate(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	createClusterResponse, err := conn.Cluster.CreateKarbonCluster(karbonCluster)
+	createClusterResponse, err := conn.Cluster.CreateKarbonCluster(ctx, karbonCluster)
 	if err != nil {
 		return diag.Errorf("error occurred during cluster creation:\n %s", err)
 	}
@@ -540,7 +539,7 @@ func resourceNutanixKarbonClusterCreate(ctx context.Context, d *schema.ResourceD
 			return diag.FromErr(err)
 		}
 		for _, newP := range *newPrivateRegistries {
-			conn.Cluster.AddPrivateRegistry(karbonClusterName, newP)
+			conn.Cluster.AddPrivateRegistry(ctx, karbonClusterName, newP)
 		}
 	}
 	return resourceNutanixKarbonClusterRead(ctx, d, meta)
@@ -553,21 +552,21 @@ func resourceNutanixKarbonClusterRead(ctx context.Context, d *schema.ResourceDat
 	setTimeout(meta)
 	// Make request to the API
 	var err error
-	resp, err := conn.Cluster.GetKarbonCluster(d.Id())
+	resp, err := conn.Cluster.GetKarbonCluster(ctx, d.Id())
 	if err != nil {
 		d.SetId("")
 		return nil
 	}
 	karbonClusterName := *resp.Name
-	flattenedEtcdNodepool, err := flattenNodePools(d, conn, "etcd_node_pool", karbonClusterName, resp.ETCDConfig.NodePools)
+	flattenedEtcdNodepool, err := flattenNodePools(ctx, d, conn, "etcd_node_pool", karbonClusterName, resp.ETCDConfig.NodePools)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	flattenedWorkerNodepool, err := flattenNodePools(d, conn, "worker_node_pool", karbonClusterName, resp.WorkerConfig.NodePools)
+	flattenedWorkerNodepool, err := flattenNodePools(ctx, d, conn, "worker_node_pool", karbonClusterName, resp.WorkerConfig.NodePools)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	flattenedMasterNodepool, err := flattenNodePools(d, conn, "master_node_pool", karbonClusterName, resp.MasterConfig.NodePools)
+	flattenedMasterNodepool, err := flattenNodePools(ctx, d, conn, "master_node_pool", karbonClusterName, resp.MasterConfig.NodePools)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -579,7 +578,7 @@ func resourceNutanixKarbonClusterRead(ctx context.Context, d *schema.ResourceDat
 	}
 
 	// Must use know version because GA API reports different version
-	karbonVersion, er := conn.Meta.GetSemanticVersion()
+	karbonVersion, er := conn.Meta.GetSemanticVersion(ctx)
 	if er != nil {
 		return diag.Errorf("error getting karbon version")
 	}
@@ -604,7 +603,7 @@ func resourceNutanixKarbonClusterRead(ctx context.Context, d *schema.ResourceDat
 	if err = d.Set("master_node_pool", flattenedMasterNodepool); err != nil {
 		return diag.Errorf("error setting worker_node_pool for Karbon Cluster %s: %s", d.Id(), err)
 	}
-	flattenedPrivateRegistries, err := flattenPrivateRegisties(conn, karbonClusterName)
+	flattenedPrivateRegistries, err := flattenPrivateRegisties(ctx, conn, karbonClusterName)
 	if err != nil {
 		return diag.Errorf("error getting flat private_registry for Karbon Cluster %s: %s", d.Id(), err)
 	}
@@ -628,15 +627,28 @@ func resourceNutanixKarbonClusterUpdate(ctx context.Context, d *schema.ResourceD
 	setTimeout(meta)
 
 	// Make request to the API
-	resp, err := conn.Cluster.GetKarbonCluster(d.Id())
+	resp, err := conn.Cluster.GetKarbonCluster(ctx, d.Id())
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	karbonVersion, err := conn.Meta.GetSemanticVersion()
+	karbonVersion, err := conn.Meta.GetSemanticVersion(ctx)
 	if err != nil {
 		return diag.Errorf("unable to get karbon version during cluster update: %s", err)
 	}
 	karbonClusterName := *resp.Name
+
+	// keep the prior state if a step fails before changing the cluster
+	d.Partial(true)
+	if d.HasChange("version") {
+		if err := upgradeKarbonClusterK8s(ctx, client, d, karbonClusterName); err != nil {
+			return karbonClusterUpdateFailed(ctx, d, meta, err)
+		}
+	}
+	if d.HasChanges("etcd_node_pool", "master_node_pool", "worker_node_pool") {
+		if err := upgradeKarbonClusterNodeOS(ctx, client, d, karbonClusterName); err != nil {
+			return karbonClusterUpdateFailed(ctx, d, meta, err)
+		}
+	}
 	if d.HasChange("worker_node_pool") {
 		timeout, timeoutErr := getTimeout(d)
 		if timeoutErr != nil {
@@ -647,18 +659,18 @@ func resourceNutanixKarbonClusterUpdate(ctx context.Context, d *schema.ResourceD
 		if err != nil {
 			return diag.Errorf("error occurred while expanding new worker node pool: %s", err)
 		}
-		currentNodePool, err := GetNodePoolsForCluster(conn, karbonClusterName, resp.WorkerConfig.NodePools)
+		currentNodePool, err := GetNodePoolsForCluster(ctx, conn, karbonClusterName, resp.WorkerConfig.NodePools)
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		taskUUID, err := determineNodepoolsScaling(client, karbonClusterName, currentNodePool, newWorkerNodePool)
+		taskUUID, err := determineNodepoolsScaling(ctx, client, karbonClusterName, currentNodePool, newWorkerNodePool)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		if taskUUID != "" {
 			err = WaitForKarbonCluster(ctx, client, timeout, taskUUID, d.Timeout(schema.TimeoutUpdate))
 			if err != nil {
-				return diag.FromErr(err)
+				return karbonClusterUpdateFailed(ctx, d, meta, err)
 			}
 		}
 	}
@@ -668,23 +680,153 @@ func resourceNutanixKarbonClusterUpdate(ctx context.Context, d *schema.ResourceD
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		currentPrivateRegistriesList, err := conn.Cluster.ListPrivateRegistries(karbonClusterName)
+		currentPrivateRegistriesList, err := conn.Cluster.ListPrivateRegistries(ctx, karbonClusterName)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		currentPrivateRegistries := convertKarbonPrivateRegistriesIntentInputToOperations(*currentPrivateRegistriesList)
 		toAdd := diffFlatPrivateRegistrySlices(*newPrivateRegistries, currentPrivateRegistries)
 		for _, a := range toAdd {
-			conn.Cluster.AddPrivateRegistry(karbonClusterName, a)
+			conn.Cluster.AddPrivateRegistry(ctx, karbonClusterName, a)
 		}
 		toRemove := diffFlatPrivateRegistrySlices(currentPrivateRegistries, *newPrivateRegistries)
 		for _, r := range toRemove {
-			conn.Cluster.DeletePrivateRegistry(karbonClusterName, *r.RegistryName)
+			conn.Cluster.DeletePrivateRegistry(ctx, karbonClusterName, *r.RegistryName)
 		}
 	}
+	d.Partial(false)
 	return resourceNutanixKarbonClusterRead(ctx, d, meta)
 }
 
+// karbonClusterUpdateFailed refreshes the state from the cluster after a failed update step, so that
+// it records the steps done before it, e.g. the kubernetes upgrade before a failed node OS upgrade.
+// The prior state is kept if the cluster cannot be read.
+func karbonClusterUpdateFailed(ctx context.Context, d *schema.ResourceData, meta interface{}, err error) diag.Diagnostics {
+	d.Partial(false)
+	if diags := resourceNutanixKarbonClusterRead(ctx, d, meta); diags.HasError() {
+		d.Partial(true)
+		return append(diag.FromErr(err), diags...)
+	}
+	return diag.FromErr(err)
+}
+
+// upgradeKarbonClusterK8s upgrades the kubernetes version of a cluster to the configured version,
+// once the upgrade prechecks passed
+func upgradeKarbonClusterK8s(ctx context.Context, client *conns.Client, d *schema.ResourceData, karbonClusterName string) error {
+	conn := client.KarbonAPI
+	version := d.Get("version").(string)
+	upgradeRequest := &karbon.ClusterK8sUpgradeIntentInput{
+		PackageVersion: version,
+	}
+
+	precheckResponse, err := conn.Cluster.PrecheckKarbonClusterK8sUpgrade(ctx, karbonClusterName, upgradeRequest)
+	if err != nil {
+		return fmt.Errorf("error occurred while running prechecks for the upgrade of karbon cluster %s to %s: %s", karbonClusterName, version, err)
+	}
+	failedChecks := make([]string, 0)
+	for _, c := range precheckResponse.Checks {
+		if !c.Passed {
+			failedChecks = append(failedChecks, fmt.Sprintf("%s: %s", c.Name, c.Message))
+		}
+	}
+	if len(failedChecks) > 0 {
+		return fmt.Errorf("prechecks for the upgrade of karbon cluster %s to %s failed: %s", karbonClusterName, version, strings.Join(failedChecks, "; "))
+	}
+
+	log.Printf("[DEBUG] upgrading karbon cluster %s to kubernetes %s", karbonClusterName, version)
+	upgradeResponse, err := conn.Cluster.UpgradeKarbonClusterK8s(ctx, karbonClusterName, upgradeRequest)
+	if err != nil {
+		return fmt.Errorf("error occurred while upgrading karbon cluster %s to %s: %s", karbonClusterName, version, err)
+	}
+
+	timeout, err := getTimeout(d)
+	if err != nil {
+		return err
+	}
+	if err := WaitForKarbonCluster(ctx, client, timeout, upgradeResponse.TaskUUID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		resp, getErr := conn.Cluster.GetKarbonCluster(ctx, karbonClusterName)
+		if getErr != nil || resp.Version == nil {
+			return fmt.Errorf("upgrade of karbon cluster %s to kubernetes %s failed, its version could not be read: %s", karbonClusterName, version, err)
+		}
+		return fmt.Errorf("upgrade of karbon cluster %s to kubernetes %s failed, the cluster is at kubernetes %s: %s", karbonClusterName, version, *resp.Version, err)
+	}
+	return nil
+}
+
+// upgradeKarbonClusterNodeOS upgrades the node pools whose node_os_version changed. Node pools
+// upgraded to the same version are upgraded together.
+func upgradeKarbonClusterNodeOS(ctx context.Context, client *conns.Client, d *schema.ResourceData, karbonClusterName string) error {
+	conn := client.KarbonAPI
+	upgrades := nodePoolsToUpgrade(d, "etcd_node_pool", "master_node_pool", "worker_node_pool")
+	if len(upgrades) == 0 {
+		return nil
+	}
+	timeout, err := getTimeout(d)
+	if err != nil {
+		return err
+	}
+
+	versions := make([]string, 0, len(upgrades))
+	for version := range upgrades {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	for _, version := range versions {
+		nodePoolNames := upgrades[version]
+		log.Printf("[DEBUG] upgrading node pools %v of karbon cluster %s to node OS %s", nodePoolNames, karbonClusterName, version)
+		upgradeResponse, err := conn.Cluster.UpgradeKarbonClusterNodeOS(ctx, karbonClusterName, &karbon.ClusterNodeOSUpgradeIntentInput{
+			NodeOSVersion: version,
+			NodePoolNames: nodePoolNames,
+		})
+		if err != nil {
+			return fmt.Errorf("error occurred while upgrading node pools %v of karbon cluster %s to %s: %s", nodePoolNames, karbonClusterName, version, err)
+		}
+
+		if err := WaitForKarbonCluster(ctx, client, timeout, upgradeResponse.TaskUUID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			current := make([]string, 0, len(nodePoolNames))
+			for _, np := range nodePoolNames {
+				nodepool, getErr := conn.Cluster.GetKarbonClusterNodePool(ctx, karbonClusterName, np)
+				if getErr != nil || nodepool.NodeOSVersion == nil {
+					current = append(current, fmt.Sprintf("%s at an unknown version", np))
+					continue
+				}
+				current = append(current, fmt.Sprintf("%s at %s", np, *nodepool.NodeOSVersion))
+			}
+			return fmt.Errorf("upgrade of node pools %v of karbon cluster %s to node OS %s failed, node pools are %s: %s",
+				nodePoolNames, karbonClusterName, version, strings.Join(current, ", "), err)
+		}
+	}
+	return nil
+}
+
+// nodePoolsToUpgrade returns the names of the node pools whose node_os_version changed, by new version
+func nodePoolsToUpgrade(d *schema.ResourceData, nodePoolKeys ...string) map[string][]string {
+	upgrades := make(map[string][]string)
+	for _, key := range nodePoolKeys {
+		o, n := d.GetChange(key)
+		addNodePoolOSUpgrades(upgrades, o.([]interface{}), n.([]interface{}))
+	}
+	return upgrades
+}
+
+// addNodePoolOSUpgrades adds to upgrades the node pools present in both oldNodePools and newNodePools
+// with a different node_os_version
+func addNodePoolOSUpgrades(upgrades map[string][]string, oldNodePools, newNodePools []interface{}) {
+	oldVersions := make(map[string]string)
+	for _, np := range oldNodePools {
+		nodepool := np.(map[string]interface{})
+		oldVersions[nodepool["name"].(string)] = nodepool["node_os_version"].(string)
+	}
+	for _, np := range newNodePools {
+		nodepool := np.(map[string]interface{})
+		name, version := nodepool["name"].(string), nodepool["node_os_version"].(string)
+		if oldVersion, ok := oldVersions[name]; ok && oldVersion != version {
+			upgrades[version] = append(upgrades[version], name)
+		}
+	}
+}
+
 func resourceNutanixKarbonClusterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Print("[Debug] Entering resourceNutanixKarbonClusterDelete")
 	client := meta.(*conns.Client)
@@ -700,7 +842,7 @@ func resourceNutanixKarbonClusterDelete(ctx context.Context, d *schema.ResourceD
 	}
 	karbonClusterName := karbonClusterNameInput.(string)
 
-	clusterDeleteResponse, err := conn.Cluster.DeleteKarbonCluster(karbonClusterName)
+	clusterDeleteResponse, err := conn.Cluster.DeleteKarbonCluster(ctx, karbonClusterName)
 	if err != nil {
 		return diag.Errorf("error while deleting Karbon Cluster UUID(%s): %s", d.Id(), err)
 	}
@@ -716,15 +858,18 @@ func resourceNutanixKarbonClusterExists(d *schema.ResourceData, meta interface{}
 	log.Print("[DEBUG] Entering resourceNutanixKarbonClusterExists")
 	conn := meta.(*conns.Client).KarbonAPI
 	setTimeout(meta)
+	// Exists has no context, bound its calls by the read timeout
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
 	karbonClusterName, ok := d.GetOk("name")
 	var exists bool
 	var err error
 	// search by Name
 	if ok {
-		exists, err = checkNutanixKarbonClusterExistsByName(conn, karbonClusterName.(string))
+		exists, err = checkNutanixKarbonClusterExistsByName(ctx, conn, karbonClusterName.(string))
 	} else {
 		//search by uuid
-		exists, err = checkNutanixKarbonClusterExistsByUUID(conn, d.Id())
+		exists, err = checkNutanixKarbonClusterExistsByUUID(ctx, conn, d.Id())
 	}
 	if err != nil {
 		if strings.Contains(fmt.Sprint(err), "cluster not found") {
@@ -744,9 +889,9 @@ func getTimeout(d *schema.ResourceData) (int64, error) {
 	return int64(timeoutInput.(int)), nil
 }
 
-func checkNutanixKarbonClusterExistsByUUID(conn *karbon.Client, uuid string) (bool, error) {
+func checkNutanixKarbonClusterExistsByUUID(ctx context.Context, conn *karbon.Client, uuid string) (bool, error) {
 	// Make request to the API
-	karbonClusters, err := conn.Cluster.ListKarbonClusters()
+	karbonClusters, err := conn.Cluster.ListKarbonClusters(ctx)
 	if err != nil {
 		return false, err
 	}
@@ -759,9 +904,9 @@ func checkNutanixKarbonClusterExistsByUUID(conn *karbon.Client, uuid string) (bo
 }
 
 // "cluster not found"
-func checkNutanixKarbonClusterExistsByName(conn *karbon.Client, clusterName string) (bool, error) {
+func checkNutanixKarbonClusterExistsByName(ctx context.Context, conn *karbon.Client, clusterName string) (bool, error) {
 	// Make request to the API
-	_, err := conn.Cluster.GetKarbonCluster(clusterName)
+	_, err := conn.Cluster.GetKarbonCluster(ctx, clusterName)
 	if err != nil {
 		return false, err
 	}
@@ -868,9 +1013,9 @@ func expandPrivateRegistry(privateRegistry map[string]interface{}) (*karbon.Priv
 	return nil, fmt.Errorf("failed to retrieve registry_name for private registry")
 }
 
-func flattenPrivateRegisties(conn *karbon.Client, karbonClusterName string) ([]map[string]interface{}, error) {
+func flattenPrivateRegisties(ctx context.Context, conn *karbon.Client, karbonClusterName string) ([]map[string]interface{}, error) {
 	flatPrivReg := make([]map[string]interface{}, 0)
-	privRegList, err := conn.Cluster.ListPrivateRegistries(karbonClusterName)
+	privRegList, err := conn.Cluster.ListPrivateRegistries(ctx, karbonClusterName)
 	if err != nil {
 		return nil, err
 	}
@@ -916,11 +1061,11 @@ func flattenCalicoConfig(calicoConfig *karbon.ClusterCalicoConfig) []map[string]
 	return flatCalicoConfigList
 }
 
-func flattenNodePools(d *schema.ResourceData, conn *karbon.Client, nodePoolKey string, karbonClusterName string, nodepools []string) ([]map[string]interface{}, error) {
+func flattenNodePools(ctx context.Context, d *schema.ResourceData, conn *karbon.Client, nodePoolKey string, karbonClusterName string, nodepools []string) ([]map[string]interface{}, error) {
 	flatNodepools := make([]map[string]interface{}, 0)
 	// start workaround for disk_mib bug GA API
 	expandedUserDefinedNodePools := make([]karbon.ClusterNodePool, 0)
-	karbonVersion, err := conn.Meta.GetSemanticVersion()
+	karbonVersion, err := conn.Meta.GetSemanticVersion(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get karbon version during flattening: %s", err)
 	}
@@ -932,7 +1077,7 @@ func flattenNodePools(d *schema.ResourceData, conn *karbon.Client, nodePoolKey s
 	}
 	// end workaround for disk_mib bug GA API
 	for _, np := range nodepools {
-		nodepool, err := conn.Cluster.GetKarbonClusterNodePool(karbonClusterName, np)
+		nodepool, err := conn.Cluster.GetKarbonClusterNodePool(ctx, karbonClusterName, np)
 		if err != nil {
 			return nil, err
 		}
@@ -991,10 +1136,10 @@ func flattenNodePool(userDefinedNodePools *karbon.ClusterNodePool, nodepool *kar
 	return flatNodepool
 }
 
-func GetNodePoolsForCluster(conn *karbon.Client, karbonClusterName string, nodepools []string) ([]karbon.ClusterNodePool, error) {
+func GetNodePoolsForCluster(ctx context.Context, conn *karbon.Client, karbonClusterName string, nodepools []string) ([]karbon.ClusterNodePool, error) {
 	nodepoolStructs := make([]karbon.ClusterNodePool, 0)
 	for _, np := range nodepools {
-		nodepool, err := conn.Cluster.GetKarbonClusterNodePool(karbonClusterName, np)
+		nodepool, err := conn.Cluster.GetKarbonClusterNodePool(ctx, karbonClusterName, np)
 		if err != nil {
 			return nil, err
 		}
@@ -1192,7 +1337,7 @@ func expandNodePool(nodepoolsInput []interface{}, karbonVersion *karbon.MetaSema
 	return nodepools, nil
 }
 
-func determineNodepoolsScaling(client *conns.Client, karbonClusterName string, currentNodepools []karbon.ClusterNodePool, newNodepools []karbon.ClusterNodePool) (string, error) {
+func determineNodepoolsScaling(ctx context.Context, client *conns.Client, karbonClusterName string, currentNodepools []karbon.ClusterNodePool, newNodepools []karbon.ClusterNodePool) (string, error) {
 	var taskUUID string
 	for _, cnp := range currentNodepools {
 		for _, nnp := range newNodepools {
@@ -1203,7 +1348,7 @@ func determineNodepoolsScaling(client *conns.Client, karbonClusterName string, c
 					scaleUpRequest := &karbon.ClusterScaleUpIntentInput{
 						Count: amountOfNodes,
 					}
-					karbonClusterActionResponse, err := client.KarbonAPI.Cluster.ScaleUpKarbonCluster(
+					karbonClusterActionResponse, err := client.KarbonAPI.Cluster.ScaleUpKarbonCluster(ctx,
 						karbonClusterName,
 						*nnp.Name,
 						scaleUpRequest,
@@ -1218,7 +1363,7 @@ func determineNodepoolsScaling(client *conns.Client, karbonClusterName string, c
 					scaleDownRequest := &karbon.ClusterScaleDownIntentInput{
 						Count: amountOfNodes,
 					}
-					karbonClusterActionResponse, err := client.KarbonAPI.Cluster.ScaleDownKarbonCluster(
+					karbonClusterActionResponse, err := client.KarbonAPI.Cluster.ScaleDownKarbonCluster(ctx,
 						karbonClusterName,
 						*nnp.Name,
 						scaleDownRequest,
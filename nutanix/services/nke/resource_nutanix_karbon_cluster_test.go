@@ -1,6 +1,7 @@
 package nke_test
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
@@ -156,7 +157,7 @@ func testAccCheckNutanixKarbonClusterDestroy(s *terraform.State) error {
 			continue
 		}
 		for {
-			_, err := conn.KarbonAPI.Cluster.GetKarbonCluster(rs.Primary.ID)
+			_, err := conn.KarbonAPI.Cluster.GetKarbonCluster(context.Background(), rs.Primary.ID)
 			if err != nil {
 				if strings.Contains(fmt.Sprint(err), "Not Found:K8s cluster not found.") {
 					return nil
@@ -169,7 +169,7 @@ func resourceNutanixKarbonWorkerNodePoolCreate(ctx context.Context, d *schema.Re
 	if label, ok := d.GetOk("labels"); ok && label.(map[string]interface{}) != nil {
 		addworkerRequest.Labels = utils.ConvertMapString(label.(map[string]interface{}))
 	}
-	karbonClusterActionResponse, err := conn.Cluster.AddWorkerNodePool(
+	karbonClusterActionResponse, err := conn.Cluster.AddWorkerNodePool(ctx,
 		nkeName,
 		addworkerRequest,
 	)
@@ -191,14 +191,14 @@ func resourceNutanixKarbonWorkerNodePoolRead(ctx context.Context, d *schema.Reso
 	// Make request to the API
 	var err error
 	karbonClsName := d.Get("cluster_name")
-	resp, err := conn.Cluster.GetKarbonCluster(karbonClsName.(string))
+	resp, err := conn.Cluster.GetKarbonCluster(ctx, karbonClsName.(string))
 	if err != nil {
 		d.SetId("")
 		return nil
 	}
 	karbonClusterName := *resp.Name
 	workerName := d.Get("name")
-	nodepool, err := conn.Cluster.GetKarbonClusterNodePool(karbonClusterName, workerName.(string))
+	nodepool, err := conn.Cluster.GetKarbonClusterNodePool(ctx, karbonClusterName, workerName.(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -237,12 +237,12 @@ func resourceNutanixKarbonWorkerNodePoolUpdate(ctx context.Context, d *schema.Re
 
 	karbonClsName := d.Get("cluster_name")
 	workerName := d.Get("name")
-	resp, err := conn.Cluster.GetKarbonCluster(karbonClsName.(string))
+	resp, err := conn.Cluster.GetKarbonCluster(ctx, karbonClsName.(string))
 	if err != nil {
 		d.SetId("")
 		return nil
 	}
-	nodepool, err := conn.Cluster.GetKarbonClusterNodePool(*resp.Name, workerName.(string))
+	nodepool, err := conn.Cluster.GetKarbonClusterNodePool(ctx, *resp.Name, workerName.(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -255,7 +255,7 @@ func resourceNutanixKarbonWorkerNodePoolUpdate(ctx context.Context, d *schema.Re
 			scaleDownRequest := &karbon.ClusterScaleDownIntentInput{
 				Count: int64(amountOfNodes),
 			}
-			karbonClusterActionResponse, err := client.KarbonAPI.Cluster.ScaleDownKarbonCluster(
+			karbonClusterActionResponse, err := client.KarbonAPI.Cluster.ScaleDownKarbonCluster(ctx,
 				*resp.Name,
 				*nodepool.Name,
 				scaleDownRequest,
@@ -272,7 +272,7 @@ func resourceNutanixKarbonWorkerNodePoolUpdate(ctx context.Context, d *schema.Re
 			scaleUpRequest := &karbon.ClusterScaleUpIntentInput{
 				Count: int64(amountOfNodes),
 			}
-			karbonClusterActionResponse, err := client.KarbonAPI.Cluster.ScaleUpKarbonCluster(
+			karbonClusterActionResponse, err := client.KarbonAPI.Cluster.ScaleUpKarbonCluster(ctx,
 				*resp.Name,
 				*nodepool.Name,
 				scaleUpRequest,
@@ -315,7 +315,7 @@ func resourceNutanixKarbonWorkerNodePoolUpdate(ctx context.Context, d *schema.Re
 		updateLabelRequest.AddLabel = addLabelMap
 		updateLabelRequest.RemoveLabel = removeLabel
 
-		nodeLabelActionResponse, err := client.KarbonAPI.Cluster.UpdateWorkerNodeLables(
+		nodeLabelActionResponse, err := client.KarbonAPI.Cluster.UpdateWorkerNodeLables(ctx,
 			*resp.Name,
 			*nodepool.Name,
 			updateLabelRequest,
@@ -337,14 +337,14 @@ func resourceNutanixKarbonWorkerNodePoolDelete(ctx context.Context, d *schema.Re
 
 	var err error
 	karbonClsName := d.Get("cluster_name")
-	resp, err := conn.Cluster.GetKarbonCluster(karbonClsName.(string))
+	resp, err := conn.Cluster.GetKarbonCluster(ctx, karbonClsName.(string))
 	if err != nil {
 		d.SetId("")
 		return nil
 	}
 	karbonClusterName := *resp.Name
 	workerName := d.Get("name")
-	nodepool, err := conn.Cluster.GetKarbonClusterNodePool(karbonClusterName, workerName.(string))
+	nodepool, err := conn.Cluster.GetKarbonClusterNodePool(ctx, karbonClusterName, workerName.(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -355,7 +355,7 @@ func resourceNutanixKarbonWorkerNodePoolDelete(ctx context.Context, d *schema.Re
 	removeWorkerRequest := &karbon.RemoveWorkerNodeRequest{
 		NodeList: nodes,
 	}
-	workerPoolActionResponse, err := conn.Cluster.RemoveWorkerNodePool(
+	workerPoolActionResponse, err := conn.Cluster.RemoveWorkerNodePool(ctx,
 		karbonClusterName,
 		workerName.(string),
 		removeWorkerRequest,
@@ -368,7 +368,7 @@ func resourceNutanixKarbonWorkerNodePoolDelete(ctx context.Context, d *schema.Re
 		return diag.FromErr(err)
 	}
 
-	workerNodeDeleteResponse, er := client.KarbonAPI.Cluster.DeleteWorkerNodePool(
+	workerNodeDeleteResponse, er := client.KarbonAPI.Cluster.DeleteWorkerNodePool(ctx,
 		karbonClusterName,
 		workerName.(string),
 	)
@@ -104,7 +104,7 @@ func resourceNutanixKarbonPrivateRegistryCreate(ctx context.Context, d *schema.R
 		pw := password.(string)
 		karbonPrivateRegistry.Password = &pw
 	}
-	createPrivateRegistryResponse, err := conn.PrivateRegistry.CreateKarbonPrivateRegistry(karbonPrivateRegistry)
+	createPrivateRegistryResponse, err := conn.PrivateRegistry.CreateKarbonPrivateRegistry(ctx, karbonPrivateRegistry)
 	if err != nil {
 		return diag.Errorf("error occurred during private registry creation: %s", err)
 	}
@@ -125,7 +125,7 @@ func resourceNutanixKarbonPrivateRegistryRead(ctx context.Context, d *schema.Res
 	if name, ok = d.GetOk("name"); !ok {
 		return diag.Errorf("cannot read private registry without name")
 	}
-	resp, err := conn.PrivateRegistry.GetKarbonPrivateRegistry(name.(string))
+	resp, err := conn.PrivateRegistry.GetKarbonPrivateRegistry(ctx, name.(string))
 	if err != nil {
 		d.SetId("")
 		return nil
@@ -152,7 +152,7 @@ func resourceNutanixKarbonPrivateRegistryDelete(ctx context.Context, d *schema.R
 	setTimeout(meta)
 	karbonPrivateRegistryName := d.Get("name").(string)
 
-	_, err := conn.PrivateRegistry.DeleteKarbonPrivateRegistry(karbonPrivateRegistryName)
+	_, err := conn.PrivateRegistry.DeleteKarbonPrivateRegistry(ctx, karbonPrivateRegistryName)
 	if err != nil {
 		return diag.Errorf("error while deleting Karbon Private Registry UUID(%s): %s", d.Id(), err)
 	}
@@ -164,13 +164,16 @@ func resourceNutanixKarbonPrivateRegistryExists(d *schema.ResourceData, meta int
 	log.Print("[DEBUG] Entering resourceNutanixKarbonPrivateRegistryExists")
 	conn := meta.(*conns.Client).KarbonAPI
 	setTimeout(meta)
+	// Exists has no context, bound its calls by the read timeout
+	ctx, cancel := context.WithTimeout(context.Background(), d.Timeout(schema.TimeoutRead))
+	defer cancel()
 	// Make request to the API
 	var name interface{}
 	var ok bool
 	if name, ok = d.GetOk("name"); !ok {
 		return false, fmt.Errorf("cannot read private registry without name")
 	}
-	_, err := conn.PrivateRegistry.GetKarbonPrivateRegistry(name.(string))
+	_, err := conn.PrivateRegistry.GetKarbonPrivateRegistry(ctx, name.(string))
 	if err != nil {
 		d.SetId("")
 		return false, nil
@@ -0,0 +1,31 @@
+package nke
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddNodePoolOSUpgrades(t *testing.T) {
+	nodePool := func(name, version string) interface{} {
+		return map[string]interface{}{"name": name, "node_os_version": version}
+	}
+
+	upgrades := make(map[string][]string)
+	// etcd node pool unchanged
+	addNodePoolOSUpgrades(upgrades,
+		[]interface{}{nodePool("etcd", "ntnx-1.5")},
+		[]interface{}{nodePool("etcd", "ntnx-1.5")})
+	// master node pool upgraded
+	addNodePoolOSUpgrades(upgrades,
+		[]interface{}{nodePool("master", "ntnx-1.5")},
+		[]interface{}{nodePool("master", "ntnx-1.6")})
+	// worker node pool upgraded, new worker node pool created with its version
+	addNodePoolOSUpgrades(upgrades,
+		[]interface{}{nodePool("worker", "ntnx-1.5")},
+		[]interface{}{nodePool("worker", "ntnx-1.6"), nodePool("worker-2", "ntnx-1.7")})
+
+	expected := map[string][]string{"ntnx-1.6": {"master", "worker"}}
+	if !reflect.DeepEqual(upgrades, expected) {
+		t.Errorf("upgrades = %v, expected %v", upgrades, expected)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nutanix/ntnx-api-golang-clients/objects-go-client/v4/models/objects/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -26,6 +27,11 @@ func DatasourceNutanixObjectStoreCertificatesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -75,7 +81,9 @@ func DatasourceNutanixObjectStoreCertificatesV2Read(ctx context.Context, d *sche
 	objectStoreExtID := d.Get("object_store_ext_id").(string)
 
 	// list certificates
-	listResp, err := conn.ObjectStoresAPIInstance.ListCertificatesByObjectstoreId(utils.StringPtr(objectStoreExtID), page, limit, filter, selects)
+	listResp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*config.ListCertificatesApiResponse, error) {
+		return conn.ObjectStoresAPIInstance.ListCertificatesByObjectstoreId(utils.StringPtr(objectStoreExtID), page, limit, filter, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching object stores : %v", err)
 	}
@@ -26,6 +26,11 @@ func DatasourceNutanixObjectStoresV2() *schema.Resource {
 				Optional: true,
 				Default:  50, //nolint:gomnd
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -87,7 +92,9 @@ func DatasourceNutanixObjectStoresV2Read(ctx context.Context, d *schema.Resource
 	} else {
 		selects = nil
 	}
-	resp, err := conn.ObjectStoresAPIInstance.ListObjectstores(page, limit, filter, orderBy, expand, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*config.ListObjectstoresApiResponse, error) {
+		return conn.ObjectStoresAPIInstance.ListObjectstores(page, limit, filter, orderBy, expand, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching object stores : %v", err)
 	}
@@ -6,12 +6,10 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	objectsCommon "github.com/nutanix/ntnx-api-golang-clients/objects-go-client/v4/models/common/v1/config"
 	"github.com/nutanix/ntnx-api-golang-clients/objects-go-client/v4/models/objects/v4/config"
 	objectPrismConfig "github.com/nutanix/ntnx-api-golang-clients/objects-go-client/v4/models/prism/v4/config"
-	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
@@ -102,27 +100,16 @@ func ResourceNutanixObjectStoreCertificateV2Create(ctx context.Context, d *schem
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the object store certificate to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+	taskDetails, err := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
 		return diag.Errorf("error waiting for object store certificate (%s) to be created: %s", utils.StringValue(taskUUID), err)
 	}
 
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching object store certificate create task (%s): %s", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Object Store Certificate Create Task Details: %s", string(aJSON))
 
 	// Get created object store certificate extID from TASK API
-	objectStoreCertificateExtID, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeObjectStoreCertificate, "Object store certificate")
+	objectStoreCertificateExtID, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeObjectStoreCertificate, "Object store certificate")
 	if err != nil {
 		return diag.FromErr(err)
 	}
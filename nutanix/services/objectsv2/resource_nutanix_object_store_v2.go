@@ -8,7 +8,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	objectsCommon "github.com/nutanix/ntnx-api-golang-clients/objects-go-client/v4/models/common/v1/config"
@@ -267,25 +266,20 @@ func ResourceNutanixObjectsV2Create(ctx context.Context, d *schema.ResourceData,
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the object store to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
+	taskDetails, err := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
 		log.Printf("[DEBUG] deploy object store task error: %s", err)
 
+		// the failed task is not returned by WaitForTask, fetch it to find the object store it created
 		taskResp, taskErr := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
 		if taskErr != nil {
 			return diag.Errorf("error while fetching deploy object store task (%s): %s", utils.StringValue(taskUUID), taskErr)
 		}
 
-		taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
+		failedTask := taskResp.Data.GetValue().(prismConfig.Task)
 
 		// Get created object store extID from TASK API
-		objectStoreExtID, extractErr := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeObjects, "Object store")
+		objectStoreExtID, extractErr := common.ExtractEntityUUIDFromTask(failedTask, utils.RelEntityTypeObjects, "Object store")
 		if extractErr != nil {
 			return diag.FromErr(extractErr)
 		}
@@ -304,19 +298,15 @@ func ResourceNutanixObjectsV2Create(ctx context.Context, d *schema.ResourceData,
 			return diag.Errorf("error waiting for object store (%s) to be created: %s", utils.StringValue(taskUUID), err)
 		}
 		// else, the object store instance exists in the system
+		d.SetId(utils.StringValue(objectStoreExtID))
+		return ResourceNutanixObjectsV2Read(ctx, d, meta)
 	}
 
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching object store create task (%s): %s", utils.StringValue(taskUUID), err)
-	}
-
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Object Store Create Task Details: %s", string(aJSON))
 
 	// Get created object store extID from TASK API
-	objectStoreExtID, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeObjects, "Object store")
+	objectStoreExtID, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeObjects, "Object store")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -430,20 +420,10 @@ func ResourceNutanixObjectsV2Update(ctx context.Context, d *schema.ResourceData,
 	taskUUID := TaskRef.ExtId
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the object store to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
-		return diag.Errorf("error waiting for object store (%s) to be updated: %s", utils.StringValue(taskUUID), err)
-	}
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
+	taskDetails, err := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return diag.Errorf("error while fetching object store update task (%s): %s", utils.StringValue(taskUUID), err)
+		return diag.Errorf("error waiting for object store (%s) to be updated: %s", utils.StringValue(taskUUID), err)
 	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Object Store Update Task Details: %s", string(aJSON))
 
@@ -472,20 +452,10 @@ func ResourceNutanixObjectsV2Delete(ctx context.Context, d *schema.ResourceData,
 	taskUUID := TaskRef.ExtId
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the object store to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
-		return diag.Errorf("error waiting for object store (%s) to be deleted: %s", utils.StringValue(taskUUID), err)
-	}
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
+	taskDetails, err := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete))
 	if err != nil {
-		return diag.Errorf("error while fetching object store delete task (%s): %s", utils.StringValue(taskUUID), err)
+		return diag.Errorf("error waiting for object store (%s) to be deleted: %s", utils.StringValue(taskUUID), err)
 	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Object Store Delete Task Details: %s", string(aJSON))
 
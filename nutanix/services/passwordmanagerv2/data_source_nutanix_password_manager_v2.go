@@ -8,6 +8,7 @@ import (
 	clusterConfig "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/clustermgmt/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/common/v1/response"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -23,6 +24,11 @@ func DataSourceNutanixPasswordManagersV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -128,7 +134,9 @@ func dataSourceNutanixPasswordManagerV2Read(ctx context.Context, d *schema.Resou
 	}
 
 	var extraParam *string = nil
-	resp, err := conn.PasswordManagerAPI.ListSystemUserPasswords(page, limit, filter, orderBy, selects, extraParam)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*clusterConfig.ListSystemUserPasswordsApiResponse, error) {
+		return conn.PasswordManagerAPI.ListSystemUserPasswords(page, limit, filter, orderBy, selects, extraParam)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching system user passwords: %v", err)
 	}
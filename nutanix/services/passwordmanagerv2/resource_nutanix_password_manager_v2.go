@@ -8,11 +8,9 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	clusterConfig "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/clustermgmt/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/prism/v4/config"
-	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/client"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
@@ -121,23 +119,11 @@ func resourceNutanixPasswordManagerV2Create(ctx context.Context, d *schema.Resou
 			return diag.Errorf("error while fetching task by ID %s: %v", utils.StringValue(taskUUID), taskErr)
 		}
 		// Wait for the password change to complete
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, newPrismClient, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutCreate),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		taskDetails, errWaitTask := common.WaitForTask(ctx, newPrismClient, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+		if errWaitTask != nil {
 			return diag.Errorf("error waiting for password change (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 
-		// Get task details for logging
-		taskResp, err := newPrismClient.TaskRefAPI.GetTaskById(taskUUID, nil)
-		if err != nil {
-			return diag.Errorf("error while fetching password change task: %v", err)
-		}
-		taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
 		aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 		log.Printf("[DEBUG] Create Password Manager Task Details: %s", string(aJSON))
 
@@ -152,23 +138,11 @@ func resourceNutanixPasswordManagerV2Create(ctx context.Context, d *schema.Resou
 
 	// The password change is not for the user configured in the provider configuration
 	// Wait for the password change to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for password change (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get task details for logging
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching password change task: %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Create Password Manager Task Details: %s", string(aJSON))
 
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -23,6 +24,11 @@ func DatasourceNutanixCategoriesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -180,7 +186,9 @@ func DatasourceNutanixCategoriesV2Read(ctx context.Context, d *schema.ResourceDa
 	} else {
 		selects = nil
 	}
-	resp, err := conn.CategoriesAPIInstance.ListCategories(page, limit, filter, orderBy, expand, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListCategoriesApiResponse, error) {
+		return conn.CategoriesAPIInstance.ListCategories(page, limit, filter, orderBy, expand, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching categories : %v", err)
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/management"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -28,6 +29,11 @@ func DatasourceNutanixListRestorablePcsV2() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -58,7 +64,9 @@ func DatasourceNutanixListRestorablePcsV2Read(ctx context.Context, d *schema.Res
 
 	restoreSourceExtID := d.Get("restore_source_ext_id").(string)
 
-	resp, err := conn.DomainManagerBackupsAPIInstance.ListRestorableDomainManagers(utils.StringPtr(restoreSourceExtID), page, limit, filter)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*management.ListRestorableDomainManagersApiResponse, error) {
+		return conn.DomainManagerBackupsAPIInstance.ListRestorableDomainManagers(utils.StringPtr(restoreSourceExtID), page, limit, filter)
+	})
 	if err != nil {
 		return diag.Errorf("Error while Listing Restorable Domain Managers configurations Details: %v", err)
 	}
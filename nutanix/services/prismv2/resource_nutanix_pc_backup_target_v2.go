@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
@@ -245,23 +244,11 @@ func ResourceNutanixBackupTargetV2Create(ctx context.Context, d *schema.Resource
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the backup target to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
-		return diag.Errorf("error waiting for backup target (%s) to be created: %s", utils.StringValue(taskUUID), err)
-	}
-
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
+	taskDetails, err := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return diag.Errorf("error while fetching backup target create task (%s): %s", utils.StringValue(taskUUID), err)
+		return diag.Errorf("error waiting for backup target (%s) to be created: %s", utils.StringValue(taskUUID), err)
 	}
 
-	taskDetails := taskResp.Data.GetValue().(config.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Create backup target task details: %s", string(aJSON))
 
@@ -482,24 +469,11 @@ func ResourceNutanixBackupTargetV2Update(ctx context.Context, d *schema.Resource
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the backup target to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-
-	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
-		return diag.Errorf("error waiting for backup target (%s) to be updated: %s", utils.StringValue(taskUUID), err)
-	}
-
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
+	taskDetails, err := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return diag.Errorf("error while fetching backup target update task (%s): %s", utils.StringValue(taskUUID), err)
+		return diag.Errorf("error waiting for backup target (%s) to be updated: %s", utils.StringValue(taskUUID), err)
 	}
 
-	taskDetails := taskResp.Data.GetValue().(config.Task)
-
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Update backup target task details: %s", string(aJSON))
 
@@ -531,24 +505,11 @@ func ResourceNutanixBackupTargetV2Delete(ctx context.Context, d *schema.Resource
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the backup target to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-
-	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
-		return diag.Errorf("error waiting for backup target (%s) to be deleted: %s", utils.StringValue(taskUUID), err)
-	}
-
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
+	taskDetails, err := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete))
 	if err != nil {
-		return diag.Errorf("error while fetching backup target delete task (%s): %s", utils.StringValue(taskUUID), err)
+		return diag.Errorf("error waiting for backup target (%s) to be deleted: %s", utils.StringValue(taskUUID), err)
 	}
 
-	taskDetails := taskResp.Data.GetValue().(config.Task)
-
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Delete backup target task details: %s", string(aJSON))
 
@@ -7,7 +7,6 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	clustermgmtConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/clustermgmt/v4/config"
@@ -83,23 +82,11 @@ func ResourceNutanixDeployPcV2Create(ctx context.Context, d *schema.ResourceData
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the PC to be deployed
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, err = stateConf.WaitForStateContext(ctx); err != nil {
-		return diag.Errorf("error waiting for PC (%s) to be deployed: %s", utils.StringValue(taskUUID), err)
-	}
-
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
+	taskDetails, err := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return diag.Errorf("error while fetching PC deploy task (%s): %s", utils.StringValue(taskUUID), err)
+		return diag.Errorf("error waiting for PC (%s) to be deployed: %s", utils.StringValue(taskUUID), err)
 	}
 
-	taskDetails := taskResp.Data.GetValue().(config.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Deploy PC task details: %s", string(aJSON))
 
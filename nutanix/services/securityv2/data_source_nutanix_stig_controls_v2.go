@@ -27,6 +27,11 @@ func DatasourceNutanixStigsControlsV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -139,7 +144,9 @@ func DatasourceNutanixStigsControlsV2Read(ctx context.Context, d *schema.Resourc
 		selectQ = nil
 	}
 
-	resp, err := conn.STIGsAPI.ListStigs(page, limit, filter, orderBy, selectQ)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*report.ListStigsApiResponse, error) {
+		return conn.STIGsAPI.ListStigs(page, limit, filter, orderBy, selectQ)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching STIGs : %v", err)
 	}
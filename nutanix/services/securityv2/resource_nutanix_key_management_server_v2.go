@@ -10,10 +10,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	commonCfg "github.com/nutanix/ntnx-api-golang-clients/security-go-client/v4/models/common/v1/config"
 	securityPrism "github.com/nutanix/ntnx-api-golang-clients/security-go-client/v4/models/prism/v4/config"
 	"github.com/nutanix/ntnx-api-golang-clients/security-go-client/v4/models/security/v4/config"
@@ -202,32 +200,16 @@ func ResourceNutanixKeyManagementServerV2Create(ctx context.Context, d *schema.R
 	// calling group API to poll for completion of task
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the key management server to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for key management server (%s) to be created: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching key management server create task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetailsValue, ok := taskResp.Data.GetValue().(prismConfig.Task)
-	if !ok {
-		return diag.Errorf("error: unexpected response type from task API, expected Task")
-	}
-	taskDetails := taskDetailsValue
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Create Key Management Server Task Details: %s", string(aJSON))
 
 	// Extract UUID from task using entity type constant
-	kmsExtID, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeKMS, "Key management server")
+	kmsExtID, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeKMS, "Key management server")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -385,23 +367,11 @@ func ResourceNutanixKeyManagementServerV2Update(ctx context.Context, d *schema.R
 	// calling group API to poll for completion of task
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the key management server to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for key management server (%s) to be updated: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get task details for logging
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching key management server update task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Update Key Management Server Task Details: %s", string(aJSON))
 
@@ -425,23 +395,11 @@ func ResourceNutanixKeyManagementServerV2Delete(ctx context.Context, d *schema.R
 	// calling group API to poll for completion of task
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the key management server to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for key management server (%s) to be deleted: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get task details for logging
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching key management server delete task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Delete Key Management Server Task Details: %s", string(aJSON))
 
@@ -25,8 +25,16 @@ func TestAccNutanixCalmAppProvisionResource_Launch(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceNameApp, "app_name", name),
 					resource.TestCheckResourceAttr(resourceNameApp, "app_description", desc),
+					resource.TestCheckResourceAttrSet(resourceNameApp, "bp_uuid"),
 				),
 			},
+			{
+				ResourceName:      resourceNameApp,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// the launch spec is only known when the app is provisioned
+				ImportStateVerifyIgnore: []string{"spec"},
+			},
 		},
 	})
 }
@@ -29,15 +29,20 @@ func ResourceNutanixCalmAppProvision() *schema.Resource {
 		ReadContext:   resourceNutanixCalmAppProvisionRead,
 		UpdateContext: resourceNutanixCalmAppProvisionUpdate,
 		DeleteContext: resourceNutanixCalmAppProvisionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNutanixCalmAppProvisionImport,
+		},
 		Schema: map[string]*schema.Schema{
 			"bp_name": {
 				Type:          schema.TypeString,
 				Optional:      true,
+				Computed:      true,
 				ConflictsWith: []string{"bp_uuid"},
 			},
 			"bp_uuid": {
 				Type:          schema.TypeString,
 				Optional:      true,
+				Computed:      true,
 				ConflictsWith: []string{"bp_name"},
 			},
 			"app_name": {
@@ -465,6 +470,10 @@ func resourceNutanixCalmAppProvisionCreate(ctx context.Context, d *schema.Resour
 	if err := d.Set("spec", string(jsonData)); err != nil {
 		return diag.FromErr(err)
 	}
+	// the blueprint is recorded by its uuid, also when it is given by its name
+	if err := d.Set("bp_uuid", bpUUID); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// call the poll API to get the status of the task
 	taskUUID := output.Status.RequestID
@@ -560,6 +569,48 @@ func resourceNutanixCalmAppProvisionRead(ctx context.Context, d *schema.Resource
 	return nil
 }
 
+// resourceNutanixCalmAppProvisionImport imports an app by its uuid. The app and its blueprint are read
+// from the app, the runtime editables given at launch are not.
+func resourceNutanixCalmAppProvisionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*conns.Client).CalmAPI
+
+	resp, err := conn.Service.GetApp(ctx, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching app %s: %v", d.Id(), err)
+	}
+
+	var objStatus map[string]interface{}
+	if err := json.Unmarshal(resp.Status, &objStatus); err != nil {
+		return nil, fmt.Errorf("error reading app %s: %v", d.Id(), err)
+	}
+	if state, _ := objStatus["state"].(string); state == "deleted" {
+		return nil, fmt.Errorf("app %s is deleted", d.Id())
+	}
+
+	name, _ := objStatus["name"].(string)
+	description, _ := objStatus["description"].(string)
+	var bpName, bpUUID string
+	if resources, ok := objStatus["resources"].(map[string]interface{}); ok {
+		if bpReference, ok := resources["app_blueprint_reference"].(map[string]interface{}); ok {
+			bpName, _ = bpReference["name"].(string)
+			bpUUID, _ = bpReference["uuid"].(string)
+		}
+	}
+
+	for attr, value := range map[string]interface{}{
+		"app_name":        name,
+		"app_description": description,
+		"bp_name":         bpName,
+		"bp_uuid":         bpUUID,
+		"soft_delete":     false,
+	} {
+		if err := d.Set(attr, value); err != nil {
+			return nil, err
+		}
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
 func resourceNutanixCalmAppProvisionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn := meta.(*conns.Client).CalmAPI
 
@@ -10,6 +10,7 @@ import (
 	clsConfig "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/common/v1/config"
 	clsResponse "github.com/nutanix/ntnx-api-golang-clients/clustermgmt-go-client/v4/models/common/v1/response"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -25,6 +26,11 @@ func DatasourceNutanixStorageContainersV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -83,7 +89,9 @@ func DatasourceNutanixStorageContainersV2Read(ctx context.Context, d *schema.Res
 		selectQ = nil
 	}
 
-	resp, err := conn.StorageContainersAPI.ListStorageContainers(page, limit, filter, orderBy, selectQ)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*clustermgmt.ListStorageContainersApiResponse, error) {
+		return conn.StorageContainersAPI.ListStorageContainers(page, limit, filter, orderBy, selectQ)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching Storage Containers : %v", err)
 	}
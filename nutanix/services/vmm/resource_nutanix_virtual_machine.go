@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/spf13/cast"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	v3 "github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v3/prism"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
@@ -29,6 +30,17 @@ var (
 	useHotAdd    = true
 )
 
+// vmPowerCycleAttributes are the attributes which can not be changed while the VM is running
+var vmPowerCycleAttributes = []string{
+	"availability_zone_reference", "cluster_uuid", "parent_reference", "enable_cpu_passthrough", "is_vcpu_hard_pinned",
+	"num_vnuma_nodes", "guest_os_id", "num_vcpus_per_socket", "hardware_clock_timezone", "vga_console_enabled",
+	"guest_customization_is_overridable", "power_state_mechanism", "power_state_guest_transition_config",
+	"guest_customization_cloud_init_user_data", "guest_customization_cloud_init_meta_data",
+	"guest_customization_cloud_init_custom_key_values", "guest_customization_sysprep",
+	"guest_customization_sysprep_custom_key_values", "serial_port_list", "gpu_list", "machine_type",
+	"boot_device_order_list", "boot_type", "boot_device_disk_address", "boot_device_mac_address",
+}
+
 func ResourceNutanixVirtualMachine() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceNutanixVirtualMachineCreate,
@@ -315,6 +327,8 @@ func ResourceNutanixVirtualMachine() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"power_cycle_policy":          common.PowerCyclePolicySchema(),
+			"pending_power_cycle_reasons": common.PendingPowerCycleReasonsSchema(),
 			"nutanix_guest_tools": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -1203,9 +1217,30 @@ func resourceNutanixVirtualMachineUpdate(ctx context.Context, d *schema.Resource
 	}
 
 	// If there are non-hotPlug changes, then poweroff is needed
+	powerCycled := false
 	if !hotPlugChange {
+		mechanism := ""
+		if utils.StringValue(response.Status.Resources.PowerState) == "ON" {
+			powerCycled = true
+			policy := d.Get("power_cycle_policy").(string)
+			if policy == common.PowerCyclePolicyDeny {
+				reasons := vmPowerCycleReasons(d)
+				if len(reasons) == 0 {
+					reasons = []string{"the update"}
+				}
+				return diag.Errorf("Virtual Machine UUID(%s) must be powered off to apply %s, which power_cycle_policy %q does not allow",
+					d.Id(), strings.Join(reasons, ", "), policy)
+			}
+			if policy == common.PowerCyclePolicyGuestShutdownThenAllow {
+				log.Printf("[DEBUG] Shutting down the guest of Virtual Machine UUID(%s) before update", d.Id())
+				if err := changePowerStateWithMechanism(ctx, conn, d.Id(), "OFF", "ACPI"); err != nil {
+					log.Printf("[WARN] guest shutdown of Virtual Machine UUID(%s) failed, powering it off: %s", d.Id(), err)
+					mechanism = "HARD"
+				}
+			}
+		}
 		log.Printf("[DEBUG] Powering OFF Virtual Machine UUID(%s) before update", d.Id())
-		if err := changePowerState(ctx, conn, d.Id(), "OFF"); err != nil {
+		if err := changePowerStateWithMechanism(ctx, conn, d.Id(), "OFF", mechanism); err != nil {
 			return diag.Errorf("internal error: cannot shut down the VM with UUID(%s): %s", d.Id(), err)
 		}
 		// SpecVersion has changed due previous poweroff
@@ -1250,6 +1285,10 @@ func resourceNutanixVirtualMachineUpdate(ctx context.Context, d *schema.Resource
 			return diag.Errorf("internal error: cannot turn ON the VM with UUID(%s): %s", d.Id(), err)
 		}
 	}
+	if powerCycled {
+		// the power cycle is done, it is no longer pending
+		d.Set("pending_power_cycle_reasons", []string{})
+	}
 
 	return resourceNutanixVirtualMachineRead(ctx, d, meta)
 }
@@ -1313,6 +1352,12 @@ func bootConfigHasChange(boot *v3.VMBootConfig, d *schema.ResourceData) (*v3.VMB
 }
 
 func changePowerState(ctx context.Context, conn *v3.Client, id string, powerState string) error {
+	return changePowerStateWithMechanism(ctx, conn, id, powerState, "")
+}
+
+// changePowerStateWithMechanism changes the power state of the VM with the given mechanism
+// (ACPI/GUEST/HARD), or with the mechanism of the VM when it is empty
+func changePowerStateWithMechanism(ctx context.Context, conn *v3.Client, id string, powerState string, mechanism string) error {
 	request := &v3.VMIntentInput{}
 	metadata := &v3.Metadata{}
 	res := &v3.VMResources{}
@@ -1356,6 +1401,9 @@ func changePowerState(ctx context.Context, conn *v3.Client, id string, powerStat
 	spec.AvailabilityZoneReference = response.Status.AvailabilityZoneReference
 	spec.ClusterReference = response.Status.ClusterReference
 
+	if mechanism != "" {
+		pw.Mechanism = utils.StringPtr(mechanism)
+	}
 	res.PowerStateMechanism = pw
 	spec.Resources = res
 	request.Metadata = metadata
@@ -2064,6 +2112,15 @@ func setVMTimeout(meta interface{}) {
 }
 
 func resourceNutanixVirtualMachineDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// show in the plan the changes which power cycle a running VM
+	if o, _ := d.GetChange("power_state"); d.Id() != "" && o.(string) == "ON" {
+		if reasons := vmPowerCycleReasons(d); len(reasons) > 0 {
+			if err := d.SetNew("pending_power_cycle_reasons", reasons); err != nil {
+				return err
+			}
+		}
+	}
+
 	if cloudInitCdromUUID, ok := d.GetOk("cloud_init_cdrom_uuid"); !ok {
 		usesGuestCustomization := usesGuestCustomizationDiff(d)
 		if usesGuestCustomization {
@@ -2093,6 +2150,58 @@ func resourceNutanixVirtualMachineDiff(ctx context.Context, d *schema.ResourceDi
 	return nil
 }
 
+// resourceChangeReader is implemented by both schema.ResourceData and schema.ResourceDiff
+type resourceChangeReader interface {
+	Get(key string) interface{}
+	GetChange(key string) (interface{}, interface{})
+	HasChange(key string) bool
+}
+
+// vmPowerCycleReasons lists the changed attributes which need the VM powered off to be applied,
+// following the hot plug rules of the update
+func vmPowerCycleReasons(d resourceChangeReader) []string {
+	reasons := common.PowerCycleReasons(d.HasChange, vmPowerCycleAttributes)
+	// cpu and memory are only hot added, and only with use_hot_add
+	for _, attr := range []string{"num_sockets", "memory_size_mib"} {
+		if d.HasChange(attr) {
+			o, n := d.GetChange(attr)
+			if n.(int) < o.(int) || !d.Get("use_hot_add").(bool) {
+				reasons = append(reasons, attr)
+			}
+		}
+	}
+	if d.HasChange("nic_list") && nicQueuesChanged(d.GetChange("nic_list")) {
+		reasons = append(reasons, "nic_list")
+	}
+	if d.HasChange("disk_list") {
+		o, n := d.GetChange("disk_list")
+		if len(GetCdromDiskList(expandDiskListRaw(o))) != len(GetCdromDiskList(expandDiskListRaw(n))) {
+			reasons = append(reasons, "disk_list")
+		}
+	}
+	return reasons
+}
+
+// nicQueuesChanged reports whether the number of queues of an existing NIC changed
+func nicQueuesChanged(oldNics, newNics interface{}) bool {
+	queues := make(map[string]int)
+	for _, v := range oldNics.([]interface{}) {
+		if nic, ok := v.(map[string]interface{}); ok && cast.ToString(nic["uuid"]) != "" {
+			queues[cast.ToString(nic["uuid"])] = cast.ToInt(nic["num_queues"])
+		}
+	}
+	for _, v := range newNics.([]interface{}) {
+		nic, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if q, ok := queues[cast.ToString(nic["uuid"])]; ok && q != cast.ToInt(nic["num_queues"]) {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceNutanixVirtualMachineInstanceResourceV0() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
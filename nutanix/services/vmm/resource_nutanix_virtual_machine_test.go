@@ -1,6 +1,7 @@
 package vmm_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"regexp"
@@ -10,12 +11,55 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/spf13/cast"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmm"
 )
 
+func TestUnitNutanixVirtualMachine_PendingPowerCycleReasons(t *testing.T) {
+	r := vmm.ResourceNutanixVirtualMachine()
+	plan := func(powerState string, newConfig map[string]interface{}) []string {
+		t.Helper()
+		oldConfig := map[string]interface{}{
+			"name": "tf-test-vm", "power_state": powerState, "num_sockets": 1, "num_vnuma_nodes": 0, "use_hot_add": true,
+		}
+		old := schema.TestResourceDataRaw(t, r.Schema, oldConfig)
+		old.SetId("vm-uuid")
+
+		for k, v := range oldConfig {
+			if _, ok := newConfig[k]; !ok {
+				newConfig[k] = v
+			}
+		}
+		diff, err := r.Diff(context.Background(), old.State(), terraform.NewResourceConfigRaw(newConfig), nil)
+		if err != nil {
+			t.Fatalf("diff: %v", err)
+		}
+		reasons := make([]string, 0)
+		for i := 0; diff != nil; i++ {
+			attr, ok := diff.Attributes[fmt.Sprintf("pending_power_cycle_reasons.%d", i)]
+			if !ok {
+				break
+			}
+			reasons = append(reasons, attr.New)
+		}
+		return reasons
+	}
+
+	if got := plan("ON", map[string]interface{}{"num_sockets": 2}); len(got) != 0 {
+		t.Errorf("hot added socket: reasons = %v, expected none", got)
+	}
+	if got := strings.Join(plan("ON", map[string]interface{}{"num_sockets": 2, "num_vnuma_nodes": 2, "use_hot_add": false}), ","); got != "num_vnuma_nodes,num_sockets" {
+		t.Errorf("reasons = %s, expected num_vnuma_nodes,num_sockets", got)
+	}
+	if got := plan("OFF", map[string]interface{}{"num_vnuma_nodes": 2}); len(got) != 0 {
+		t.Errorf("powered off vm: reasons = %v, expected none", got)
+	}
+}
+
 func TestAccNutanixVirtualMachine_basic(t *testing.T) {
 	r := acctest.RandInt()
 	resourceName := "nutanix_virtual_machine.vm1"
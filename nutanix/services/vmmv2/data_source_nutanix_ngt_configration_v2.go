@@ -114,7 +114,7 @@ func DatasourceNutanixNGTConfigurationV4Read(ctx context.Context, d *schema.Reso
 	return nil
 }
 
-func flattenCapabilities(capabilities []vmmAhvConfig.NgtCapability) []string {
+func flattenCapabilities[T interface{ GetName() string }](capabilities []T) []string {
 	if len(capabilities) > 0 {
 		c := make([]string, len(capabilities))
 		for i, v := range capabilities {
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/content"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -24,6 +25,11 @@ func DatasourceNutanixOvasV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -77,7 +83,9 @@ func datasourceNutanixOvasV2Read(ctx context.Context, d *schema.ResourceData, me
 	} else {
 		selects = nil
 	}
-	resp, err := conn.OvasAPIInstance.ListOvas(page, limit, filter, orderBy, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import1.ListOvasApiResponse, error) {
+		return conn.OvasAPIInstance.ListOvas(page, limit, filter, orderBy, selects)
+	})
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error retrieving OVA list: %w", err))
 	}
@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	import5 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/content"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -23,6 +24,11 @@ func DatasourceNutanixTemplatesV2() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -76,7 +82,9 @@ func DatasourceNutanixTemplatesV2Read(ctx context.Context, d *schema.ResourceDat
 	} else {
 		selectQ = nil
 	}
-	resp, err := conn.TemplatesAPIInstance.ListTemplates(page, limit, filter, orderBy, selectQ)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*import5.ListTemplatesApiResponse, error) {
+		return conn.TemplatesAPIInstance.ListTemplates(page, limit, filter, orderBy, selectQ)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching templates : %v", err)
 	}
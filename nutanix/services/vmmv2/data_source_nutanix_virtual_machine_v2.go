@@ -224,8 +224,9 @@ func DatasourceNutanixVirtualMachineV4() *schema.Resource {
 																Elem: &schema.Resource{
 																	Schema: map[string]*schema.Schema{
 																		"value": {
-																			Type:     schema.TypeString,
-																			Computed: true,
+																			Type:      schema.TypeString,
+																			Computed:  true,
+																			Sensitive: true,
 																		},
 																	},
 																},
@@ -284,8 +285,9 @@ func DatasourceNutanixVirtualMachineV4() *schema.Resource {
 																Elem: &schema.Resource{
 																	Schema: map[string]*schema.Schema{
 																		"value": {
-																			Type:     schema.TypeString,
-																			Computed: true,
+																			Type:      schema.TypeString,
+																			Computed:  true,
+																			Sensitive: true,
 																		},
 																	},
 																},
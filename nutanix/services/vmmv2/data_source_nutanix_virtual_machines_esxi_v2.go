@@ -0,0 +1,376 @@
+package vmmv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	esxiConfig "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/esxi/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// DatasourceNutanixVirtualMachinesEsxiV2 lists the VMs running on the ESXi clusters registered to Prism Central
+func DatasourceNutanixVirtualMachinesEsxiV2() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: DatasourceNutanixVirtualMachinesEsxiV2Read,
+		Schema: map[string]*schema.Schema{
+			"page": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
+			"filter": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order_by": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"select": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"vms": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ext_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tenant_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"links": schemaForLinks(),
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"guest_os_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"memory_size_bytes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"num_cpus": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"num_cores_per_socket": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"virtual_hardware_version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"power_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"cluster": schemaForEsxiReference(),
+						"host":    schemaForEsxiReference(),
+						"categories": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ext_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"disks": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ext_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"bus_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"index": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"disk_size_bytes": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"storage_container": schemaForEsxiReference(),
+								},
+							},
+						},
+						"nics": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ext_id": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"adapter_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"is_connected": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"mac_address": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"nutanix_guest_tools": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"version": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"available_version": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"is_installed": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"is_enabled": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"is_reachable": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"capabilities": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func DatasourceNutanixVirtualMachinesEsxiV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	// initialize query params
+	var filter, orderBy, selects *string
+	var page, limit *int
+
+	if pagef, ok := d.GetOk("page"); ok {
+		page = utils.IntPtr(pagef.(int))
+	}
+	if limitf, ok := d.GetOk("limit"); ok {
+		limit = utils.IntPtr(limitf.(int))
+	}
+	if filterf, ok := d.GetOk("filter"); ok {
+		filter = utils.StringPtr(filterf.(string))
+	}
+	if order, ok := d.GetOk("order_by"); ok {
+		orderBy = utils.StringPtr(order.(string))
+	}
+	if selectf, ok := d.GetOk("select"); ok {
+		selects = utils.StringPtr(selectf.(string))
+	}
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*esxiConfig.ListVmsApiResponse, error) {
+		return conn.EsxiVMAPIInstance.ListVms(page, limit, filter, orderBy, selects)
+	})
+	if err != nil {
+		return diag.Errorf("error while fetching esxi vms : %v", err)
+	}
+
+	if resp.Data == nil {
+		if err := d.Set("vms", make([]interface{}, 0)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		d.SetId(resource.UniqueId())
+
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "🫙 No data found.",
+			Detail:   "The API returned an empty list of esxi virtual machines.",
+		}}
+	}
+
+	getResp := resp.Data.GetValue().([]esxiConfig.Vm)
+
+	if err := d.Set("vms", flattenEsxiVMEntities(getResp)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(resource.UniqueId())
+	return nil
+}
+
+func schemaForEsxiReference() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ext_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func flattenEsxiReference(extID *string) []map[string]interface{} {
+	if extID != nil {
+		return []map[string]interface{}{{"ext_id": utils.StringValue(extID)}}
+	}
+	return nil
+}
+
+func flattenEsxiVMEntities(vms []esxiConfig.Vm) []interface{} {
+	if len(vms) > 0 {
+		vmsList := make([]interface{}, len(vms))
+
+		for k, v := range vms {
+			vm := map[string]interface{}{
+				"ext_id":                   utils.StringValue(v.ExtId),
+				"tenant_id":                utils.StringValue(v.TenantId),
+				"links":                    flattenAPILink(v.Links),
+				"name":                     utils.StringValue(v.Name),
+				"description":              utils.StringValue(v.Description),
+				"guest_os_name":            utils.StringValue(v.GuestOsName),
+				"memory_size_bytes":        utils.Int64Value(v.MemorySizeBytes),
+				"num_cpus":                 utils.Int64Value(v.NumCpus),
+				"num_cores_per_socket":     utils.Int64Value(v.NumCoresPerSocket),
+				"virtual_hardware_version": utils.Int64Value(v.VirtualHardwareVersion),
+				"categories":               flattenEsxiCategoryReference(v.Categories),
+				"disks":                    flattenEsxiDisks(v.Disks),
+				"nics":                     flattenEsxiNics(v.Nics),
+			}
+			if v.PowerState != nil {
+				vm["power_state"] = v.PowerState.GetName()
+			}
+			if v.Cluster != nil {
+				vm["cluster"] = flattenEsxiReference(v.Cluster.ExtId)
+			}
+			if v.Host != nil {
+				vm["host"] = flattenEsxiReference(v.Host.ExtId)
+			}
+			if v.NutanixGuestTools != nil {
+				vm["nutanix_guest_tools"] = []map[string]interface{}{{
+					"version":           utils.StringValue(v.NutanixGuestTools.Version),
+					"available_version": utils.StringValue(v.NutanixGuestTools.AvailableVersion),
+					"is_installed":      utils.BoolValue(v.NutanixGuestTools.IsInstalled),
+					"is_enabled":        utils.BoolValue(v.NutanixGuestTools.IsEnabled),
+					"is_reachable":      utils.BoolValue(v.NutanixGuestTools.IsReachable),
+					"capabilities":      flattenCapabilities(v.NutanixGuestTools.Capabilities),
+				}}
+			}
+			vmsList[k] = vm
+		}
+		return vmsList
+	}
+	return nil
+}
+
+func flattenEsxiCategoryReference(ctg []esxiConfig.CategoryReference) []interface{} {
+	if len(ctg) > 0 {
+		ctgList := make([]interface{}, len(ctg))
+
+		for k, v := range ctg {
+			ctgList[k] = map[string]interface{}{"ext_id": utils.StringValue(v.ExtId)}
+		}
+		return ctgList
+	}
+	return nil
+}
+
+func flattenEsxiDisks(disks []esxiConfig.Disk) []interface{} {
+	if len(disks) > 0 {
+		diskList := make([]interface{}, len(disks))
+
+		for k, v := range disks {
+			disk := map[string]interface{}{
+				"ext_id": utils.StringValue(v.ExtId),
+			}
+			if v.DiskAddress != nil {
+				if v.DiskAddress.BusType != nil {
+					disk["bus_type"] = v.DiskAddress.BusType.GetName()
+				}
+				disk["index"] = utils.IntValue(v.DiskAddress.Index)
+			}
+			if v.BackingInfo != nil {
+				disk["disk_size_bytes"] = utils.Int64Value(v.BackingInfo.DiskSizeBytes)
+				if v.BackingInfo.StorageContainer != nil {
+					disk["storage_container"] = flattenEsxiReference(v.BackingInfo.StorageContainer.ExtId)
+				}
+			}
+			diskList[k] = disk
+		}
+		return diskList
+	}
+	return nil
+}
+
+func flattenEsxiNics(nics []esxiConfig.Nic) []interface{} {
+	if len(nics) > 0 {
+		nicList := make([]interface{}, len(nics))
+
+		for k, v := range nics {
+			nic := map[string]interface{}{
+				"ext_id": utils.StringValue(v.ExtId),
+			}
+			if v.BackingInfo != nil {
+				if v.BackingInfo.AdapterType != nil {
+					nic["adapter_type"] = v.BackingInfo.AdapterType.GetName()
+				}
+				nic["is_connected"] = utils.BoolValue(v.BackingInfo.IsConnected)
+				nic["mac_address"] = utils.StringValue(v.BackingInfo.MacAddress)
+			}
+			nicList[k] = nic
+		}
+		return nicList
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package vmmv2_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
+)
+
+var esxiVMs = mockpc.Collection{Path: "vmm/esxi/config/vms", ObjectType: "vmm.v4.esxi.config.Vm", Rel: "vmm:esxi:config:vm"}
+
+func TestUnitV2NutanixVmsEsxiDatasource_List(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(esxiVMs)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	pc.Add(esxiVMs.Path, map[string]interface{}{
+		"name":              "esxi-vm-1",
+		"guestOsName":       "Ubuntu Linux (64-bit)",
+		"memorySizeBytes":   4294967296,
+		"numCpus":           2,
+		"numCoresPerSocket": 1,
+		"powerState":        "ON",
+		"cluster":           map[string]interface{}{"extId": "cluster-1"},
+		"host":              map[string]interface{}{"extId": "host-1"},
+		"categories":        []interface{}{map[string]interface{}{"extId": "category-1"}},
+		"disks": []interface{}{map[string]interface{}{
+			"extId":       "disk-1",
+			"diskAddress": map[string]interface{}{"busType": "SCSI", "index": 0},
+			"backingInfo": map[string]interface{}{
+				"diskSizeBytes":    10737418240,
+				"storageContainer": map[string]interface{}{"extId": "container-1"},
+			},
+		}},
+		"nics": []interface{}{map[string]interface{}{
+			"extId":       "nic-1",
+			"backingInfo": map[string]interface{}{"adapterType": "VMXNET3", "isConnected": true, "macAddress": "50:6b:8d:00:00:01"},
+		}},
+		"nutanixGuestTools": map[string]interface{}{
+			"isInstalled":  true,
+			"version":      "4.1",
+			"capabilities": []interface{}{"VSS_SNAPSHOT", "SELF_SERVICE_RESTORE"},
+		},
+	})
+
+	r := vmmv2.DatasourceNutanixVirtualMachinesEsxiV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	for attr, expected := range map[string]interface{}{
+		"vms.#":                                      1,
+		"vms.0.name":                                 "esxi-vm-1",
+		"vms.0.guest_os_name":                        "Ubuntu Linux (64-bit)",
+		"vms.0.memory_size_bytes":                    4294967296,
+		"vms.0.num_cpus":                             2,
+		"vms.0.power_state":                          "ON",
+		"vms.0.cluster.0.ext_id":                     "cluster-1",
+		"vms.0.host.0.ext_id":                        "host-1",
+		"vms.0.categories.0.ext_id":                  "category-1",
+		"vms.0.disks.0.bus_type":                     "SCSI",
+		"vms.0.disks.0.disk_size_bytes":              10737418240,
+		"vms.0.disks.0.storage_container.0.ext_id":   "container-1",
+		"vms.0.nics.0.adapter_type":                  "VMXNET3",
+		"vms.0.nics.0.mac_address":                   "50:6b:8d:00:00:01",
+		"vms.0.nutanix_guest_tools.0.is_installed":   true,
+		"vms.0.nutanix_guest_tools.0.capabilities.0": "SELF_SERVICE_RESTORE",
+	} {
+		if got := d.Get(attr); got != expected {
+			t.Errorf("%s = %v, expected %v", attr, got, expected)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package vmmv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/stats"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// DatasourceNutanixVMDiskStatsV2 returns the time series of the I/O and latency stats of a VM disk
+func DatasourceNutanixVMDiskStatsV2() *schema.Resource {
+	s := schemaForStatsQuery()
+	s["vm_ext_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["ext_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["tenant_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["links"] = schemaForLinks()
+	s["stats"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"timestamp":                              {Type: schema.TypeString, Computed: true},
+				"controller_num_iops":                    {Type: schema.TypeInt, Computed: true},
+				"controller_num_read_iops":               {Type: schema.TypeInt, Computed: true},
+				"controller_num_io":                      {Type: schema.TypeInt, Computed: true},
+				"controller_num_read_io":                 {Type: schema.TypeInt, Computed: true},
+				"controller_num_write_io":                {Type: schema.TypeInt, Computed: true},
+				"controller_io_bandwidth_kbps":           {Type: schema.TypeInt, Computed: true},
+				"controller_read_io_bandwidth_kbps":      {Type: schema.TypeInt, Computed: true},
+				"controller_write_io_bandwidth_kbps":     {Type: schema.TypeInt, Computed: true},
+				"controller_avg_io_latency_micros":       {Type: schema.TypeInt, Computed: true},
+				"controller_avg_read_io_latency_micros":  {Type: schema.TypeInt, Computed: true},
+				"controller_avg_write_io_latency_micros": {Type: schema.TypeInt, Computed: true},
+				"controller_avg_read_io_size_kb":         {Type: schema.TypeInt, Computed: true},
+				"controller_avg_write_io_size_kb":        {Type: schema.TypeInt, Computed: true},
+				"controller_read_io_ppm":                 {Type: schema.TypeInt, Computed: true},
+				"controller_write_io_ppm":                {Type: schema.TypeInt, Computed: true},
+				"controller_seq_io_ppm":                  {Type: schema.TypeInt, Computed: true},
+				"controller_user_bytes":                  {Type: schema.TypeInt, Computed: true},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: DatasourceNutanixVMDiskStatsV2Read,
+		Schema:      s,
+	}
+}
+
+func DatasourceNutanixVMDiskStatsV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	q, diags := expandStatsQuery(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	vmExtID := d.Get("vm_ext_id").(string)
+	extID := d.Get("ext_id").(string)
+	resp, err := conn.StatsAPIInstance.GetDiskStatsById(utils.StringPtr(vmExtID), utils.StringPtr(extID), q.startTime, q.endTime, q.samplingInterval, q.statType, q.selects)
+	if err != nil {
+		return diag.Errorf("error while fetching vm disk stats : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(stats.VmDiskStats)
+
+	if err := d.Set("tenant_id", getResp.TenantId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("links", flattenAPILink(getResp.Links)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("stats", flattenVMDiskStatsTuples(getResp.Stats)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(extID)
+	return nil
+}
+
+func flattenVMDiskStatsTuples(tuples []stats.VmDiskStatsTuple) []map[string]interface{} {
+	if len(tuples) > 0 {
+		statsList := make([]map[string]interface{}, len(tuples))
+
+		for k, v := range tuples {
+			statsList[k] = map[string]interface{}{
+				"timestamp":                              flattenStatsTimestamp(v.Timestamp),
+				"controller_num_iops":                    utils.Int64Value(v.ControllerNumIops),
+				"controller_num_read_iops":               utils.Int64Value(v.ControllerNumReadIops),
+				"controller_num_io":                      utils.Int64Value(v.ControllerNumIo),
+				"controller_num_read_io":                 utils.Int64Value(v.ControllerNumReadIo),
+				"controller_num_write_io":                utils.Int64Value(v.ControllerNumWriteIo),
+				"controller_io_bandwidth_kbps":           utils.Int64Value(v.ControllerIoBandwidthKbps),
+				"controller_read_io_bandwidth_kbps":      utils.Int64Value(v.ControllerReadIoBandwidthKbps),
+				"controller_write_io_bandwidth_kbps":     utils.Int64Value(v.ControllerWriteIoBandwidthKbps),
+				"controller_avg_io_latency_micros":       utils.Int64Value(v.ControllerAvgIoLatencyMicros),
+				"controller_avg_read_io_latency_micros":  utils.Int64Value(v.ControllerAvgReadIoLatencyMicros),
+				"controller_avg_write_io_latency_micros": utils.Int64Value(v.ControllerAvgWriteIoLatencyMicros),
+				"controller_avg_read_io_size_kb":         utils.Int64Value(v.ControllerAvgReadIoSizeKb),
+				"controller_avg_write_io_size_kb":        utils.Int64Value(v.ControllerAvgWriteIoSizeKb),
+				"controller_read_io_ppm":                 utils.Int64Value(v.ControllerReadIoPpm),
+				"controller_write_io_ppm":                utils.Int64Value(v.ControllerWriteIoPpm),
+				"controller_seq_io_ppm":                  utils.Int64Value(v.ControllerSeqIoPpm),
+				"controller_user_bytes":                  utils.Int64Value(v.ControllerUserBytes),
+			}
+		}
+		return statsList
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package vmmv2_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
+)
+
+const datasourceNameVMDiskStats = "data.nutanix_vm_disk_stats_v2.test"
+
+func TestAccV2NutanixVMDiskStatsDatasource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vm-disk-stats-%d", r)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVMDiskStatsDatasourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceNameVMDiskStats, "ext_id", "nutanix_virtual_machine_v2.test", "disks.0.ext_id"),
+					resource.TestCheckResourceAttrSet(datasourceNameVMDiskStats, "stats.#"),
+				),
+			},
+		},
+	})
+}
+
+func testVMDiskStatsDatasourceConfig(name string) string {
+	now := time.Now().UTC()
+	return testVMStatsConfig(name) + fmt.Sprintf(`
+		data "nutanix_vm_disk_stats_v2" "test" {
+			vm_ext_id = nutanix_virtual_machine_v2.test.id
+			ext_id = nutanix_virtual_machine_v2.test.disks.0.ext_id
+			start_time = "%[1]s"
+			end_time = "%[2]s"
+			sampling_interval = 30
+		}
+`, now.Add(-time.Hour).Format(time.RFC3339), now.Format(time.RFC3339))
+}
+
+func TestUnitV2NutanixVMDiskStatsDatasource_UnknownDisk(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	pc.Handle(http.MethodGet, "vmm/ahv/stats/vms/{vmExtId}/disks/{extId}", func(w http.ResponseWriter, r *http.Request) {
+		mockpc.WriteError(w, http.StatusNotFound, "disk disk-1 not found")
+	})
+
+	r := vmmv2.DatasourceNutanixVMDiskStatsV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"vm_ext_id":  "vm-1",
+		"ext_id":     "disk-1",
+		"start_time": "2026-10-01T10:00:00Z",
+		"end_time":   "2026-10-01T11:00:00Z",
+	})
+	diags := r.ReadContext(context.Background(), d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "disk disk-1 not found") {
+		t.Errorf("diags = %v, expected the not found error", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("id = %q, expected no stats to be read", d.Id())
+	}
+}
@@ -0,0 +1,225 @@
+package vmmv2
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	import2 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/common/v1/stats"
+	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/stats"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// DatasourceNutanixVMStatsV2 returns the time series of the CPU, memory, I/O and latency stats of a VM
+func DatasourceNutanixVMStatsV2() *schema.Resource {
+	s := schemaForStatsQuery()
+	s["vm_ext_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	s["ext_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["tenant_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["links"] = schemaForLinks()
+	s["stats"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"timestamp":                                 {Type: schema.TypeString, Computed: true},
+				"cluster":                                   {Type: schema.TypeString, Computed: true},
+				"hypervisor_type":                           {Type: schema.TypeString, Computed: true},
+				"hypervisor_cpu_usage_ppm":                  {Type: schema.TypeInt, Computed: true},
+				"hypervisor_cpu_ready_time_ppm":             {Type: schema.TypeInt, Computed: true},
+				"num_vcpus_used_ppm":                        {Type: schema.TypeInt, Computed: true},
+				"memory_usage_bytes":                        {Type: schema.TypeInt, Computed: true},
+				"memory_usage_ppm":                          {Type: schema.TypeInt, Computed: true},
+				"memory_reserved_bytes":                     {Type: schema.TypeInt, Computed: true},
+				"guest_memory_usage_ppm":                    {Type: schema.TypeInt, Computed: true},
+				"hypervisor_memory_usage_ppm":               {Type: schema.TypeInt, Computed: true},
+				"hypervisor_memory_balloon_reclaimed_bytes": {Type: schema.TypeInt, Computed: true},
+				"hypervisor_swap_in_rate_kbps":              {Type: schema.TypeInt, Computed: true},
+				"hypervisor_swap_out_rate_kbps":             {Type: schema.TypeInt, Computed: true},
+				"controller_num_iops":                       {Type: schema.TypeInt, Computed: true},
+				"controller_num_read_iops":                  {Type: schema.TypeInt, Computed: true},
+				"controller_num_write_iops":                 {Type: schema.TypeInt, Computed: true},
+				"controller_io_bandwidth_kbps":              {Type: schema.TypeInt, Computed: true},
+				"controller_read_io_bandwidth_kbps":         {Type: schema.TypeInt, Computed: true},
+				"controller_write_io_bandwidth_kbps":        {Type: schema.TypeInt, Computed: true},
+				"controller_avg_io_latency_micros":          {Type: schema.TypeInt, Computed: true},
+				"controller_avg_read_io_latency_micros":     {Type: schema.TypeInt, Computed: true},
+				"controller_avg_write_io_latency_micros":    {Type: schema.TypeInt, Computed: true},
+				"hypervisor_num_iops":                       {Type: schema.TypeInt, Computed: true},
+				"hypervisor_avg_io_latency_micros":          {Type: schema.TypeInt, Computed: true},
+				"disk_usage_ppm":                            {Type: schema.TypeInt, Computed: true},
+				"disk_capacity_bytes":                       {Type: schema.TypeInt, Computed: true},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: DatasourceNutanixVMStatsV2Read,
+		Schema:      s,
+	}
+}
+
+func DatasourceNutanixVMStatsV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	q, diags := expandStatsQuery(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	vmExtID := d.Get("vm_ext_id").(string)
+	resp, err := conn.StatsAPIInstance.GetVmStatsById(utils.StringPtr(vmExtID), q.startTime, q.endTime, q.samplingInterval, q.statType, q.selects)
+	if err != nil {
+		return diag.Errorf("error while fetching vm stats : %v", err)
+	}
+
+	getResp := resp.Data.GetValue().(stats.VmStats)
+
+	if err := d.Set("ext_id", getResp.ExtId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tenant_id", getResp.TenantId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("links", flattenAPILink(getResp.Links)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("stats", flattenVMStatsTuples(getResp.Stats)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(vmExtID)
+	return nil
+}
+
+// statsQuery holds the query parameters shared by the vmm stats endpoints
+type statsQuery struct {
+	startTime        *time.Time
+	endTime          *time.Time
+	samplingInterval *int
+	statType         *import2.DownSamplingOperator
+	selects          *string
+}
+
+func schemaForStatsQuery() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"start_time": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+		},
+		"end_time": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.IsRFC3339Time,
+		},
+		"sampling_interval": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+		"stat_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: common.ValidateEnum[import2.DownSamplingOperator](),
+		},
+		"select": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func expandStatsQuery(d *schema.ResourceData) (*statsQuery, diag.Diagnostics) {
+	q := &statsQuery{}
+
+	startTime, err := time.Parse(time.RFC3339, d.Get("start_time").(string))
+	if err != nil {
+		return nil, diag.Errorf("error while parsing start_time : %v", err)
+	}
+	endTime, err := time.Parse(time.RFC3339, d.Get("end_time").(string))
+	if err != nil {
+		return nil, diag.Errorf("error while parsing end_time : %v", err)
+	}
+	if !endTime.After(startTime) {
+		return nil, diag.Errorf("end_time should be after start_time")
+	}
+	q.startTime = &startTime
+	q.endTime = &endTime
+
+	if samplingInterval, ok := d.GetOk("sampling_interval"); ok {
+		q.samplingInterval = utils.IntPtr(samplingInterval.(int))
+	}
+	if statType, ok := d.GetOk("stat_type"); ok {
+		q.statType = common.ExpandEnum[import2.DownSamplingOperator](statType.(string))
+	}
+	if selects, ok := d.GetOk("select"); ok {
+		q.selects = utils.StringPtr(strings.Join(common.ExpandListOfString(selects.([]interface{})), ","))
+	}
+	return q, nil
+}
+
+func flattenStatsTimestamp(t *time.Time) string {
+	if t != nil {
+		return t.Format(time.RFC3339)
+	}
+	return ""
+}
+
+func flattenVMStatsTuples(tuples []stats.VmStatsTuple) []map[string]interface{} {
+	if len(tuples) > 0 {
+		statsList := make([]map[string]interface{}, len(tuples))
+
+		for k, v := range tuples {
+			statsList[k] = map[string]interface{}{
+				"timestamp":                                 flattenStatsTimestamp(v.Timestamp),
+				"cluster":                                   utils.StringValue(v.Cluster),
+				"hypervisor_type":                           utils.StringValue(v.HypervisorType),
+				"hypervisor_cpu_usage_ppm":                  utils.Int64Value(v.HypervisorCpuUsagePpm),
+				"hypervisor_cpu_ready_time_ppm":             utils.Int64Value(v.HypervisorCpuReadyTimePpm),
+				"num_vcpus_used_ppm":                        utils.Int64Value(v.NumVcpusUsedPpm),
+				"memory_usage_bytes":                        utils.Int64Value(v.MemoryUsageBytes),
+				"memory_usage_ppm":                          utils.Int64Value(v.MemoryUsagePpm),
+				"memory_reserved_bytes":                     utils.Int64Value(v.MemoryReservedBytes),
+				"guest_memory_usage_ppm":                    utils.Int64Value(v.GuestMemoryUsagePpm),
+				"hypervisor_memory_usage_ppm":               utils.Int64Value(v.HypervisorMemoryUsagePpm),
+				"hypervisor_memory_balloon_reclaimed_bytes": utils.Int64Value(v.HypervisorMemoryBalloonReclaimedBytes),
+				"hypervisor_swap_in_rate_kbps":              utils.Int64Value(v.HypervisorSwapInRateKbps),
+				"hypervisor_swap_out_rate_kbps":             utils.Int64Value(v.HypervisorSwapOutRateKbps),
+				"controller_num_iops":                       utils.Int64Value(v.ControllerNumIops),
+				"controller_num_read_iops":                  utils.Int64Value(v.ControllerNumReadIops),
+				"controller_num_write_iops":                 utils.Int64Value(v.ControllerNumWriteIops),
+				"controller_io_bandwidth_kbps":              utils.Int64Value(v.ControllerIoBandwidthKbps),
+				"controller_read_io_bandwidth_kbps":         utils.Int64Value(v.ControllerReadIoBandwidthKbps),
+				"controller_write_io_bandwidth_kbps":        utils.Int64Value(v.ControllerWriteIoBandwidthKbps),
+				"controller_avg_io_latency_micros":          utils.Int64Value(v.ControllerAvgIoLatencyMicros),
+				"controller_avg_read_io_latency_micros":     utils.Int64Value(v.ControllerAvgReadIoLatencyMicros),
+				"controller_avg_write_io_latency_micros":    utils.Int64Value(v.ControllerAvgWriteIoLatencyMicros),
+				"hypervisor_num_iops":                       utils.Int64Value(v.HypervisorNumIops),
+				"hypervisor_avg_io_latency_micros":          utils.Int64Value(v.HypervisorAvgIoLatencyMicros),
+				"disk_usage_ppm":                            utils.Int64Value(v.DiskUsagePpm),
+				"disk_capacity_bytes":                       utils.Int64Value(v.DiskCapacityBytes),
+			}
+		}
+		return statsList
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package vmmv2_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
+)
+
+const datasourceNameVMStats = "data.nutanix_vm_stats_v2.test"
+
+func TestAccV2NutanixVMStatsDatasource_Basic(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vm-stats-%d", r)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVMStatsDatasourceConfig(name, `
+					sampling_interval = 30
+					stat_type = "AVG"
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceNameVMStats, "ext_id", "nutanix_virtual_machine_v2.test", "id"),
+					resource.TestCheckResourceAttrSet(datasourceNameVMStats, "stats.#"),
+					resource.TestCheckResourceAttr(datasourceNameVMStats, "sampling_interval", "30"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccV2NutanixVMStatsDatasource_WithSelect(t *testing.T) {
+	r := acctest.RandInt()
+	name := fmt.Sprintf("tf-test-vm-stats-%d", r)
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { acc.TestAccPreCheck(t) },
+		Providers: acc.TestAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testVMStatsDatasourceConfig(name, `
+					select = ["stats/memoryUsageBytes"]
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(datasourceNameVMStats, "stats.#"),
+					resource.TestCheckResourceAttrPair(datasourceNameVMStats, "ext_id", "nutanix_virtual_machine_v2.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+// testVMStatsConfig is a running VM with a disk, whose stats are read over the last hour
+func testVMStatsConfig(name string) string {
+	return fmt.Sprintf(`
+		data "nutanix_clusters_v2" "clusters" {}
+
+		locals {
+			cluster0 = [
+			for cluster in data.nutanix_clusters_v2.clusters.cluster_entities :
+			cluster.ext_id if cluster.config[0].cluster_function[0] != "PRISM_CENTRAL"
+		  ][0]
+		}
+
+		data "nutanix_storage_containers_v2" "sc" {
+		  filter = "clusterExtId eq '${local.cluster0}'"
+		  limit = 1
+		}
+
+		resource "nutanix_virtual_machine_v2" "test"{
+			name= "%[1]s"
+			description = "vm stats test"
+			num_cores_per_socket = 1
+			num_sockets = 1
+			memory_size_bytes = 1073741824
+			cluster {
+				ext_id = local.cluster0
+			}
+			disks{
+				disk_address{
+					bus_type = "SCSI"
+					index = 0
+				}
+				backing_info{
+					vm_disk{
+						disk_size_bytes = "1073741824"
+						storage_container{
+							ext_id = data.nutanix_storage_containers_v2.sc.storage_containers[0].ext_id
+						}
+					}
+				}
+			}
+			power_state = "ON"
+		}
+`, name)
+}
+
+func testVMStatsDatasourceConfig(name, query string) string {
+	now := time.Now().UTC()
+	return testVMStatsConfig(name) + fmt.Sprintf(`
+		data "nutanix_vm_stats_v2" "test" {
+			vm_ext_id = nutanix_virtual_machine_v2.test.id
+			start_time = "%[1]s"
+			end_time = "%[2]s"
+			%[3]s
+		}
+`, now.Add(-time.Hour).Format(time.RFC3339), now.Format(time.RFC3339), query)
+}
+
+func TestUnitV2NutanixVMStatsDatasource_InvalidTimeRange(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	r := vmmv2.DatasourceNutanixVMStatsV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"vm_ext_id":  "vm-1",
+		"start_time": "2026-10-01T11:00:00Z",
+		"end_time":   "2026-10-01T10:00:00Z",
+	})
+	if diags := r.ReadContext(context.Background(), d, meta); !diags.HasError() {
+		t.Fatal("expected an error for an end_time before the start_time")
+	}
+	if len(pc.Requests()) != 0 {
+		t.Errorf("stats fetched for an invalid time range: %v", pc.Requests())
+	}
+}
+
+func TestUnitV2NutanixVMStatsDatasource_Select(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	selects := ""
+	pc.Handle(http.MethodGet, "vmm/ahv/stats/vms/{extId}", func(w http.ResponseWriter, r *http.Request) {
+		selects = r.URL.Query().Get("$select")
+		mockpc.WriteError(w, http.StatusNotFound, "vm vm-1 not found")
+	})
+
+	r := vmmv2.DatasourceNutanixVMStatsV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"vm_ext_id":  "vm-1",
+		"start_time": "2026-10-01T10:00:00Z",
+		"end_time":   "2026-10-01T11:00:00Z",
+		"select":     []interface{}{"stats/hypervisorCpuUsagePpm", "stats/memoryUsageBytes"},
+	})
+	_ = r.ReadContext(context.Background(), d, meta)
+	if selects != "stats/hypervisorCpuUsagePpm,stats/memoryUsageBytes" {
+		t.Errorf("$select = %q, expected the selected stats joined", selects)
+	}
+}
@@ -0,0 +1,799 @@
+package vmmv2
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
+	"gopkg.in/yaml.v2"
+)
+
+// Structured guest customization. The cloud_config, network_config and unattend blocks are rendered by the
+// provider to the base64 encoded cloud-config user data, cloud-init metadata and Sysprep unattend xml the API
+// expects, as an alternative to the user_data, metadata and unattend_xml values encoded by the user.
+
+const cloudConfigHeader = "#cloud-config\n"
+
+var (
+	sshPublicKeyRegexp  = regexp.MustCompile(`^(ssh-(rsa|dss|ed25519)|ecdsa-sha2-nistp(256|384|521)|sk-(ssh-ed25519|ecdsa-sha2-nistp256)@openssh\.com) [A-Za-z0-9+/]+=*( .*)?$`)
+	filePermissionsRe   = regexp.MustCompile(`^0?[0-7]{3,4}$`)
+	windowsComputerName = regexp.MustCompile(`^[A-Za-z0-9-]*[A-Za-z-][A-Za-z0-9-]*$`)
+	windowsLocaleRegexp = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})+$`)
+)
+
+func validateSSHPublicKey() schema.SchemaValidateFunc {
+	return validation.StringMatch(sshPublicKeyRegexp, "must be an OpenSSH public key, e.g. ssh-ed25519 AAAA... user@host")
+}
+
+func schemaForCloudConfig() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"hostname": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"users": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+							"groups": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+							"sudo": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"shell": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"lock_passwd": {
+								Type:     schema.TypeBool,
+								Optional: true,
+								Default:  true,
+							},
+							"hashed_passwd": {
+								Type:      schema.TypeString,
+								Optional:  true,
+								Sensitive: true,
+							},
+							"ssh_authorized_keys": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validateSSHPublicKey(),
+								},
+							},
+						},
+					},
+				},
+				"ssh_authorized_keys": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validateSSHPublicKey(),
+					},
+				},
+				"package_update": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"package_upgrade": {
+					Type:     schema.TypeBool,
+					Optional: true,
+				},
+				"packages": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"write_files": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"path": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/`), "must be an absolute path"),
+							},
+							"content": {
+								Type:      schema.TypeString,
+								Required:  true,
+								Sensitive: true,
+							},
+							"encoding": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringInSlice([]string{"text/plain", "b64", "gzip+b64"}, false),
+							},
+							"owner": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"permissions": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringMatch(filePermissionsRe, "must be an octal file mode, e.g. 0644"),
+							},
+							"append": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"runcmd": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func schemaForCloudInitNetworkConfig() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ethernets": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+							"mac_address": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.IsMACAddress,
+							},
+							"dhcp4": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"addresses": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validation.IsCIDR,
+								},
+							},
+							"gateway4": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.IsIPv4Address,
+							},
+							"nameservers": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Schema{
+									Type:         schema.TypeString,
+									ValidateFunc: validation.IsIPAddress,
+								},
+							},
+							"search_domains": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaForSysprepUnattend() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"computer_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+					ValidateFunc: validation.All(
+						validation.StringLenBetween(1, 15),
+						validation.StringMatch(windowsComputerName, "must only contain letters, digits and hyphens, and not only digits"),
+					),
+				},
+				"registered_owner": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"registered_organization": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"time_zone": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"locale": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringMatch(windowsLocaleRegexp, "must be a language tag, e.g. en-US"),
+				},
+				"product_key": {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+				},
+				"admin_password": {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+				},
+				"domain_join": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"domain": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotWhiteSpace,
+							},
+							"username": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"password": {
+								Type:      schema.TypeString,
+								Required:  true,
+								Sensitive: true,
+							},
+							"machine_object_ou": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"first_logon_commands": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// expandCloudConfigUserData renders the cloud_config block to base64 encoded cloud-config user data
+func expandCloudConfigUserData(pr interface{}) (*string, error) {
+	cfgs := pr.([]interface{})
+	if len(cfgs) == 0 || cfgs[0] == nil {
+		return nil, nil
+	}
+	cfg := cfgs[0].(map[string]interface{})
+
+	doc := yaml.MapSlice{}
+	add := func(key string, value interface{}) {
+		doc = append(doc, yaml.MapItem{Key: key, Value: value})
+	}
+
+	if hostname := cfg["hostname"].(string); hostname != "" {
+		add("hostname", hostname)
+	}
+	if users := cfg["users"].([]interface{}); len(users) > 0 {
+		rendered := make([]interface{}, 0, len(users))
+		for _, u := range users {
+			user := u.(map[string]interface{})
+			// "default" keeps the default user of the distribution, which is replaced otherwise
+			if user["name"] == "default" {
+				rendered = append(rendered, "default")
+				continue
+			}
+			r := yaml.MapSlice{{Key: "name", Value: user["name"]}}
+			if groups := toStringList(user["groups"]); len(groups) > 0 {
+				r = append(r, yaml.MapItem{Key: "groups", Value: strings.Join(groups, ", ")})
+			}
+			if sudo := user["sudo"].(string); sudo != "" {
+				r = append(r, yaml.MapItem{Key: "sudo", Value: sudo})
+			}
+			if shell := user["shell"].(string); shell != "" {
+				r = append(r, yaml.MapItem{Key: "shell", Value: shell})
+			}
+			r = append(r, yaml.MapItem{Key: "lock_passwd", Value: user["lock_passwd"]})
+			if passwd := user["hashed_passwd"].(string); passwd != "" {
+				r = append(r, yaml.MapItem{Key: "hashed_passwd", Value: passwd})
+			}
+			if keys := toStringList(user["ssh_authorized_keys"]); len(keys) > 0 {
+				r = append(r, yaml.MapItem{Key: "ssh_authorized_keys", Value: keys})
+			}
+			rendered = append(rendered, r)
+		}
+		add("users", rendered)
+	}
+	if keys := toStringList(cfg["ssh_authorized_keys"]); len(keys) > 0 {
+		add("ssh_authorized_keys", keys)
+	}
+	if cfg["package_update"].(bool) {
+		add("package_update", true)
+	}
+	if cfg["package_upgrade"].(bool) {
+		add("package_upgrade", true)
+	}
+	if packages := toStringList(cfg["packages"]); len(packages) > 0 {
+		add("packages", packages)
+	}
+	if files := cfg["write_files"].([]interface{}); len(files) > 0 {
+		rendered := make([]interface{}, 0, len(files))
+		for _, f := range files {
+			file := f.(map[string]interface{})
+			r := yaml.MapSlice{{Key: "path", Value: file["path"]}, {Key: "content", Value: file["content"]}}
+			for _, key := range []string{"encoding", "owner", "permissions"} {
+				if v := file[key].(string); v != "" {
+					r = append(r, yaml.MapItem{Key: key, Value: v})
+				}
+			}
+			if file["append"].(bool) {
+				r = append(r, yaml.MapItem{Key: "append", Value: true})
+			}
+			rendered = append(rendered, r)
+		}
+		add("write_files", rendered)
+	}
+	if commands := toStringList(cfg["runcmd"]); len(commands) > 0 {
+		add("runcmd", commands)
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error while rendering cloud_config: %v", err)
+	}
+	return encodeGuestCustomization(cloudConfigHeader + string(out)), nil
+}
+
+// expandCloudInitMetadata adds the network configuration rendered from the network_config block, as a
+// network config version 2 document under the network key, to the metadata given by the user, if any.
+func expandCloudInitMetadata(metadata string, networkConfig interface{}) (*string, error) {
+	cfgs := networkConfig.([]interface{})
+	if len(cfgs) == 0 || cfgs[0] == nil {
+		if metadata == "" {
+			return nil, nil
+		}
+		return &metadata, nil
+	}
+	cfg := cfgs[0].(map[string]interface{})
+
+	doc := yaml.MapSlice{}
+	if metadata != "" {
+		decoded, err := base64.StdEncoding.DecodeString(metadata)
+		if err == nil {
+			err = yaml.Unmarshal(decoded, &doc)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("metadata must be base64 encoded YAML or JSON to be merged with network_config: %v", err)
+		}
+	}
+
+	ethernets := yaml.MapSlice{}
+	for _, e := range cfg["ethernets"].([]interface{}) {
+		ethernet := e.(map[string]interface{})
+		r := yaml.MapSlice{}
+		if mac := ethernet["mac_address"].(string); mac != "" {
+			r = append(r,
+				yaml.MapItem{Key: "match", Value: yaml.MapSlice{{Key: "macaddress", Value: strings.ToLower(mac)}}},
+				yaml.MapItem{Key: "set-name", Value: ethernet["name"]},
+			)
+		}
+		r = append(r, yaml.MapItem{Key: "dhcp4", Value: ethernet["dhcp4"]})
+		if addresses := toStringList(ethernet["addresses"]); len(addresses) > 0 {
+			r = append(r, yaml.MapItem{Key: "addresses", Value: addresses})
+		}
+		if gateway := ethernet["gateway4"].(string); gateway != "" {
+			r = append(r, yaml.MapItem{Key: "gateway4", Value: gateway})
+		}
+		nameservers := yaml.MapSlice{}
+		if servers := toStringList(ethernet["nameservers"]); len(servers) > 0 {
+			nameservers = append(nameservers, yaml.MapItem{Key: "addresses", Value: servers})
+		}
+		if search := toStringList(ethernet["search_domains"]); len(search) > 0 {
+			nameservers = append(nameservers, yaml.MapItem{Key: "search", Value: search})
+		}
+		if len(nameservers) > 0 {
+			r = append(r, yaml.MapItem{Key: "nameservers", Value: nameservers})
+		}
+		ethernets = append(ethernets, yaml.MapItem{Key: ethernet["name"], Value: r})
+	}
+	network := yaml.MapSlice{{Key: "version", Value: 2}, {Key: "ethernets", Value: ethernets}}
+
+	replaced := false
+	for i := range doc {
+		if doc[i].Key == "network" {
+			doc[i].Value = network
+			replaced = true
+		}
+	}
+	if !replaced {
+		doc = append(doc, yaml.MapItem{Key: "network", Value: network})
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("error while rendering network_config: %v", err)
+	}
+	return encodeGuestCustomization(string(out)), nil
+}
+
+type unattendComponent struct {
+	Name                   string                  `xml:"name,attr"`
+	ProcessorArchitecture  string                  `xml:"processorArchitecture,attr"`
+	PublicKeyToken         string                  `xml:"publicKeyToken,attr"`
+	Language               string                  `xml:"language,attr"`
+	VersionScope           string                  `xml:"versionScope,attr"`
+	XmlnsWcm               string                  `xml:"xmlns:wcm,attr"`
+	ComputerName           string                  `xml:"ComputerName,omitempty"`
+	RegisteredOwner        string                  `xml:"RegisteredOwner,omitempty"`
+	RegisteredOrganization string                  `xml:"RegisteredOrganization,omitempty"`
+	TimeZone               string                  `xml:"TimeZone,omitempty"`
+	ProductKey             string                  `xml:"ProductKey,omitempty"`
+	Identification         *unattendIdentification `xml:"Identification,omitempty"`
+	InputLocale            string                  `xml:"InputLocale,omitempty"`
+	SystemLocale           string                  `xml:"SystemLocale,omitempty"`
+	UILanguage             string                  `xml:"UILanguage,omitempty"`
+	UserLocale             string                  `xml:"UserLocale,omitempty"`
+	OOBE                   *unattendOOBE           `xml:"OOBE,omitempty"`
+	AdministratorPassword  *unattendPassword       `xml:"UserAccounts>AdministratorPassword,omitempty"`
+	AutoLogon              *unattendAutoLogon      `xml:"AutoLogon,omitempty"`
+	FirstLogonCommands     *unattendCommands       `xml:"FirstLogonCommands,omitempty"`
+}
+
+type unattendIdentification struct {
+	Domain          string `xml:"Credentials>Domain"`
+	Username        string `xml:"Credentials>Username"`
+	Password        string `xml:"Credentials>Password"`
+	JoinDomain      string `xml:"JoinDomain"`
+	MachineObjectOU string `xml:"MachineObjectOU,omitempty"`
+}
+
+type unattendOOBE struct {
+	HideEULAPage    bool `xml:"HideEULAPage"`
+	SkipMachineOOBE bool `xml:"SkipMachineOOBE"`
+	SkipUserOOBE    bool `xml:"SkipUserOOBE"`
+	ProtectYourPC   int  `xml:"ProtectYourPC"`
+}
+
+type unattendPassword struct {
+	Value     string `xml:"Value"`
+	PlainText bool   `xml:"PlainText"`
+}
+
+type unattendAutoLogon struct {
+	Password   unattendPassword `xml:"Password"`
+	Enabled    bool             `xml:"Enabled"`
+	LogonCount int              `xml:"LogonCount"`
+	Username   string           `xml:"Username"`
+}
+
+type unattendCommands struct {
+	Commands []unattendCommand `xml:"SynchronousCommand"`
+}
+
+type unattendCommand struct {
+	Action      string `xml:"wcm:action,attr"`
+	Order       int    `xml:"Order"`
+	CommandLine string `xml:"CommandLine"`
+}
+
+type unattendSettings struct {
+	Pass       string              `xml:"pass,attr"`
+	Components []unattendComponent `xml:"component"`
+}
+
+type unattendDocument struct {
+	XMLName  xml.Name           `xml:"urn:schemas-microsoft-com:unattend unattend"`
+	Settings []unattendSettings `xml:"settings"`
+}
+
+func newUnattendComponent(name string) unattendComponent {
+	return unattendComponent{
+		Name:                  name,
+		ProcessorArchitecture: "amd64",
+		PublicKeyToken:        "31bf3856ad364e35",
+		Language:              "neutral",
+		VersionScope:          "nonSxS",
+		XmlnsWcm:              "http://schemas.microsoft.com/WMIConfig/2002/State",
+	}
+}
+
+// expandSysprepUnattendXML renders the unattend block to a base64 encoded Sysprep unattend xml
+func expandSysprepUnattendXML(pr interface{}) (*string, error) {
+	cfgs := pr.([]interface{})
+	if len(cfgs) == 0 || cfgs[0] == nil {
+		return nil, nil
+	}
+	cfg := cfgs[0].(map[string]interface{})
+
+	specialize := unattendSettings{Pass: "specialize"}
+	shellSetup := newUnattendComponent("Microsoft-Windows-Shell-Setup")
+	shellSetup.ComputerName = cfg["computer_name"].(string)
+	shellSetup.RegisteredOwner = cfg["registered_owner"].(string)
+	shellSetup.RegisteredOrganization = cfg["registered_organization"].(string)
+	shellSetup.TimeZone = cfg["time_zone"].(string)
+	shellSetup.ProductKey = cfg["product_key"].(string)
+	specialize.Components = append(specialize.Components, shellSetup)
+
+	if domainJoin := cfg["domain_join"].([]interface{}); len(domainJoin) > 0 && domainJoin[0] != nil {
+		join := domainJoin[0].(map[string]interface{})
+		unattendedJoin := newUnattendComponent("Microsoft-Windows-UnattendedJoin")
+		unattendedJoin.Identification = &unattendIdentification{
+			Domain:          join["domain"].(string),
+			Username:        join["username"].(string),
+			Password:        join["password"].(string),
+			JoinDomain:      join["domain"].(string),
+			MachineObjectOU: join["machine_object_ou"].(string),
+		}
+		specialize.Components = append(specialize.Components, unattendedJoin)
+	}
+
+	oobeSystem := unattendSettings{Pass: "oobeSystem"}
+	if locale := cfg["locale"].(string); locale != "" {
+		international := newUnattendComponent("Microsoft-Windows-International-Core")
+		international.InputLocale = locale
+		international.SystemLocale = locale
+		international.UILanguage = locale
+		international.UserLocale = locale
+		oobeSystem.Components = append(oobeSystem.Components, international)
+	}
+	oobe := newUnattendComponent("Microsoft-Windows-Shell-Setup")
+	oobe.OOBE = &unattendOOBE{HideEULAPage: true, SkipMachineOOBE: true, SkipUserOOBE: true, ProtectYourPC: 3}
+	adminPassword := cfg["admin_password"].(string)
+	if adminPassword != "" {
+		oobe.AdministratorPassword = &unattendPassword{Value: adminPassword, PlainText: true}
+	}
+	commands := toStringList(cfg["first_logon_commands"])
+	if len(commands) > 0 {
+		oobe.FirstLogonCommands = &unattendCommands{}
+		for i, command := range commands {
+			oobe.FirstLogonCommands.Commands = append(oobe.FirstLogonCommands.Commands, unattendCommand{Action: "add", Order: i + 1, CommandLine: command})
+		}
+	}
+	// the first logon commands only run once the administrator is logged on
+	if len(commands) > 0 && adminPassword != "" {
+		oobe.AutoLogon = &unattendAutoLogon{
+			Password:   unattendPassword{Value: adminPassword, PlainText: true},
+			Enabled:    true,
+			LogonCount: 1,
+			Username:   "Administrator",
+		}
+	}
+	oobeSystem.Components = append(oobeSystem.Components, oobe)
+
+	out, err := xml.MarshalIndent(unattendDocument{Settings: []unattendSettings{specialize, oobeSystem}}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error while rendering unattend: %v", err)
+	}
+	return encodeGuestCustomization(fmt.Sprintf("%s%s\n", xml.Header, out)), nil
+}
+
+// expandCloudConfigScript returns the user data rendered from the cloud_config block of a cloud_init_script,
+// nil if it is not set. It replaces user_data, which is set from the rendered user data on read; both can not
+// be configured. Rendering errors are reported by the plan, see validateStructuredGuestCustomization.
+func expandCloudConfigScript(cloudInitScript map[string]interface{}) *config.Userdata {
+	cloudConfig, ok := cloudInitScript["cloud_config"]
+	if !ok {
+		return nil
+	}
+	value, err := expandCloudConfigUserData(cloudConfig)
+	if err != nil || value == nil {
+		return nil
+	}
+	user := config.NewUserdata()
+	user.Value = value
+	return user
+}
+
+// expandUnattendScript returns the unattend xml rendered from the unattend block of a sysprep_script, nil if it is not set.
+// Rendering errors are reported by the plan, see validateStructuredGuestCustomization.
+func expandUnattendScript(sysprepScript map[string]interface{}) *config.Unattendxml {
+	unattend, ok := sysprepScript["unattend"]
+	if !ok {
+		return nil
+	}
+	value, err := expandSysprepUnattendXML(unattend)
+	if err != nil || value == nil {
+		return nil
+	}
+	unattendXML := config.NewUnattendxml()
+	unattendXML.Value = value
+	return unattendXML
+}
+
+// expandCloudInitScriptMetadata returns the metadata of a cloud_init block, with its network_config if set.
+// Rendering errors are reported by the plan, see validateStructuredGuestCustomization.
+func expandCloudInitScriptMetadata(metadata string, networkConfig interface{}) *string {
+	value, err := expandCloudInitMetadata(metadata, networkConfig)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// structuredGuestCustomizationConflicts are the structured blocks and the value they are rendered to, which
+// can not both be configured
+var structuredGuestCustomizationConflicts = map[string]string{
+	"cloud_config": "user_data",
+	"unattend":     "unattend_xml",
+}
+
+// validateStructuredGuestCustomization is the CustomizeDiff of the resources with structured guest customization
+// blocks in the given attributes. It rejects a structured block configured along with the value it is rendered to,
+// and renders the blocks, so that these errors are reported by the plan instead of being found on apply.
+func validateStructuredGuestCustomization(keys ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		// the values read from the rendered blocks are in the state, only the configuration can conflict
+		if err := checkStructuredGuestCustomizationConflicts(d.GetRawConfig()); err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := renderStructuredGuestCustomization(d.Get(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// checkStructuredGuestCustomizationConflicts walks a configuration and returns an error for the first structured
+// block configured along with the value it is rendered to
+func checkStructuredGuestCustomizationConflicts(v cty.Value) error {
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+	t := v.Type()
+	switch {
+	case t.IsObjectType():
+		for block, rendered := range structuredGuestCustomizationConflicts {
+			if t.HasAttribute(block) && t.HasAttribute(rendered) && isConfigured(v.GetAttr(block)) && isConfigured(v.GetAttr(rendered)) {
+				return fmt.Errorf("%q and %q can not both be set, %q is rendered to %q", block, rendered, block, rendered)
+			}
+		}
+		for name := range t.AttributeTypes() {
+			if err := checkStructuredGuestCustomizationConflicts(v.GetAttr(name)); err != nil {
+				return err
+			}
+		}
+	case t.IsListType() || t.IsSetType() || t.IsTupleType():
+		for it := v.ElementIterator(); it.Next(); {
+			_, elem := it.Element()
+			if err := checkStructuredGuestCustomizationConflicts(elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isConfigured(v cty.Value) bool {
+	if v.IsNull() {
+		return false
+	}
+	if v.IsKnown() && (v.Type().IsListType() || v.Type().IsSetType() || v.Type().IsTupleType()) {
+		return v.LengthInt() > 0
+	}
+	return true
+}
+
+// renderStructuredGuestCustomization renders the structured blocks found in a value and returns the first error
+func renderStructuredGuestCustomization(v interface{}) error {
+	switch value := v.(type) {
+	case []interface{}:
+		for _, elem := range value {
+			if err := renderStructuredGuestCustomization(elem); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		if cloudConfig, ok := value["cloud_config"].([]interface{}); ok {
+			if _, err := expandCloudConfigUserData(cloudConfig); err != nil {
+				return err
+			}
+		}
+		if unattend, ok := value["unattend"].([]interface{}); ok {
+			if _, err := expandSysprepUnattendXML(unattend); err != nil {
+				return err
+			}
+		}
+		if networkConfig, ok := value["network_config"].([]interface{}); ok {
+			metadata, _ := value["metadata"].(string)
+			if _, err := expandCloudInitMetadata(metadata, networkConfig); err != nil {
+				return err
+			}
+		}
+		for _, elem := range value {
+			if err := renderStructuredGuestCustomization(elem); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// structuredGuestCustomizationPaths are the structured blocks and the path to the block holding them in a config
+var structuredGuestCustomizationPaths = map[string][]string{
+	"network_config": {"config", "cloud_init"},
+	"cloud_config":   {"config", "cloud_init", "cloud_init_script"},
+	"unattend":       {"config", "sysprep", "sysprep_script"},
+}
+
+// flattenGuestCustomizationWithStructuredInputs flattens the guest customization of a VM, keeping the structured
+// blocks of the configuration. The API only returns what they are rendered to, which would otherwise be a diff.
+func flattenGuestCustomizationWithStructuredInputs(d *schema.ResourceData, gst *config.GuestCustomizationParams) []map[string]interface{} {
+	flattened := flattenGuestCustomizationParams(gst)
+	if len(flattened) == 0 {
+		return flattened
+	}
+	for block, path := range structuredGuestCustomizationPaths {
+		prior := "guest_customization.0." + strings.Join(path, ".0.") + ".0." + block
+		value, ok := d.GetOk(prior)
+		if !ok {
+			continue
+		}
+		parent := flattened[0]
+		for _, p := range path {
+			list, _ := parent[p].([]map[string]interface{})
+			if len(list) == 0 {
+				parent = nil
+				break
+			}
+			parent = list[0]
+		}
+		if parent != nil {
+			parent[block] = value
+		}
+	}
+	return flattened
+}
+
+func encodeGuestCustomization(rendered string) *string {
+	encoded := base64.StdEncoding.EncodeToString([]byte(rendered))
+	return &encoded
+}
+
+func toStringList(pr interface{}) []string {
+	list, _ := pr.([]interface{})
+	out := make([]string, 0, len(list))
+	for _, v := range list {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
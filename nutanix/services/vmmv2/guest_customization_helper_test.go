@@ -0,0 +1,349 @@
+package vmmv2_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
+)
+
+// customizeGuest applies the guest customization config on a VM of the fake Prism Central and returns the
+// config of the customize-guest request
+func customizeGuest(t *testing.T, cfg map[string]interface{}) map[string]interface{} {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	const vmsPath = "vmm/ahv/config/vms"
+	extID := pc.Add(vmsPath, map[string]interface{}{"name": "tf-vm"})
+
+	var body struct {
+		Config map[string]interface{} `json:"config"`
+	}
+	pc.Handle(http.MethodPost, vmsPath+"/{extId}/$actions/customize-guest", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("customize-guest body: %v", err)
+		}
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("customize-guest", mockpc.DefaultCollections[0], extID))
+	})
+
+	r := vmmv2.ResourceNutanixVMGCUpdateV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"ext_id": extID,
+		"config": []interface{}{cfg},
+	})
+	if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if body.Config == nil {
+		t.Fatal("customize-guest not called")
+	}
+	return body.Config
+}
+
+func decodeBase64(t *testing.T, value interface{}) string {
+	decoded, err := base64.StdEncoding.DecodeString(value.(string))
+	if err != nil {
+		t.Fatalf("%v is not base64 encoded: %v", value, err)
+	}
+	return string(decoded)
+}
+
+func TestUnitV2NutanixVMGCUpdateResource_CloudConfig(t *testing.T) {
+	const key = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGx0ZXN0a2V5 admin@example.com"
+	metadata := base64.StdEncoding.EncodeToString([]byte(`{"instance-id": "tf-vm"}`))
+
+	cfg := customizeGuest(t, map[string]interface{}{
+		"cloud_init": []interface{}{map[string]interface{}{
+			"metadata": metadata,
+			"network_config": []interface{}{map[string]interface{}{
+				"ethernets": []interface{}{map[string]interface{}{
+					"name":        "eth0",
+					"mac_address": "50:6B:8D:00:00:01",
+					"addresses":   []interface{}{"10.0.0.10/24"},
+					"gateway4":    "10.0.0.1",
+					"nameservers": []interface{}{"10.0.0.2"},
+				}},
+			}},
+			"cloud_init_script": []interface{}{map[string]interface{}{
+				"cloud_config": []interface{}{map[string]interface{}{
+					"hostname": "tf-vm",
+					"users": []interface{}{
+						map[string]interface{}{"name": "default"},
+						map[string]interface{}{
+							"name":                "admin",
+							"groups":              []interface{}{"wheel", "adm"},
+							"sudo":                "ALL=(ALL) NOPASSWD:ALL",
+							"ssh_authorized_keys": []interface{}{key},
+						},
+					},
+					"packages": []interface{}{"nginx"},
+					"write_files": []interface{}{map[string]interface{}{
+						"path":        "/etc/motd",
+						"content":     "managed by terraform\n",
+						"permissions": "0644",
+					}},
+					"runcmd": []interface{}{"systemctl enable --now nginx"},
+				}},
+			}},
+		}},
+	})
+
+	userData := decodeBase64(t, cfg["cloudInitScript"].(map[string]interface{})["value"])
+	expected := `#cloud-config
+hostname: tf-vm
+users:
+- default
+- name: admin
+  groups: wheel, adm
+  sudo: ALL=(ALL) NOPASSWD:ALL
+  lock_passwd: true
+  ssh_authorized_keys:
+  - ` + key + `
+packages:
+- nginx
+write_files:
+- path: /etc/motd
+  content: |
+    managed by terraform
+  permissions: "0644"
+runcmd:
+- systemctl enable --now nginx
+`
+	if userData != expected {
+		t.Errorf("user data =\n%s\nexpected\n%s", userData, expected)
+	}
+
+	meta := decodeBase64(t, cfg["metadata"])
+	expected = `instance-id: tf-vm
+network:
+  version: 2
+  ethernets:
+    eth0:
+      match:
+        macaddress: 50:6b:8d:00:00:01
+      set-name: eth0
+      dhcp4: false
+      addresses:
+      - 10.0.0.10/24
+      gateway4: 10.0.0.1
+      nameservers:
+        addresses:
+        - 10.0.0.2
+`
+	if meta != expected {
+		t.Errorf("metadata =\n%s\nexpected\n%s", meta, expected)
+	}
+}
+
+func TestUnitV2NutanixVMGCUpdateResource_SysprepUnattend(t *testing.T) {
+	cfg := customizeGuest(t, map[string]interface{}{
+		"sysprep": []interface{}{map[string]interface{}{
+			"install_type": "PREPARED",
+			"sysprep_script": []interface{}{map[string]interface{}{
+				"unattend": []interface{}{map[string]interface{}{
+					"computer_name":  "WIN-TF-01",
+					"time_zone":      "UTC",
+					"locale":         "en-US",
+					"admin_password": "s3cr3t&<",
+					"domain_join": []interface{}{map[string]interface{}{
+						"domain":   "corp.example.com",
+						"username": "joiner",
+						"password": "j0in",
+					}},
+					"first_logon_commands": []interface{}{"powershell -File C:\\setup.ps1"},
+				}},
+			}},
+		}},
+	})
+
+	unattend := decodeBase64(t, cfg["sysprepScript"].(map[string]interface{})["value"])
+	for _, expected := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<unattend xmlns="urn:schemas-microsoft-com:unattend">`,
+		`<settings pass="specialize">`,
+		`<ComputerName>WIN-TF-01</ComputerName>`,
+		`<TimeZone>UTC</TimeZone>`,
+		`<JoinDomain>corp.example.com</JoinDomain>`,
+		`<Password>j0in</Password>`,
+		`<settings pass="oobeSystem">`,
+		`<UILanguage>en-US</UILanguage>`,
+		`<AdministratorPassword>`,
+		`<Value>s3cr3t&amp;&lt;</Value>`,
+		`<Username>Administrator</Username>`,
+		`<CommandLine>powershell -File C:\setup.ps1</CommandLine>`,
+	} {
+		if !strings.Contains(unattend, expected) {
+			t.Errorf("unattend xml does not contain %s:\n%s", expected, unattend)
+		}
+	}
+}
+
+func TestUnitV2NutanixVirtualMachineResource_GuestCustomizationValidation(t *testing.T) {
+	r := vmmv2.ResourceNutanixVirtualMachineV2()
+	guestCustomization := func(cfg map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                "tf-vm",
+			"guest_customization": []interface{}{map[string]interface{}{"config": []interface{}{cfg}}},
+		}
+	}
+
+	for name, tc := range map[string]map[string]interface{}{
+		"ssh key": {"cloud_init": []interface{}{map[string]interface{}{
+			"cloud_init_script": []interface{}{map[string]interface{}{
+				"cloud_config": []interface{}{map[string]interface{}{"ssh_authorized_keys": []interface{}{"not a key"}}},
+			}},
+		}}},
+		"file permissions": {"cloud_init": []interface{}{map[string]interface{}{
+			"cloud_init_script": []interface{}{map[string]interface{}{
+				"cloud_config": []interface{}{map[string]interface{}{
+					"write_files": []interface{}{map[string]interface{}{"path": "/etc/motd", "content": "hi", "permissions": "rw-r--r--"}},
+				}},
+			}},
+		}}},
+		"address": {"cloud_init": []interface{}{map[string]interface{}{
+			"network_config": []interface{}{map[string]interface{}{
+				"ethernets": []interface{}{map[string]interface{}{"name": "eth0", "addresses": []interface{}{"10.0.0.10"}}},
+			}},
+		}}},
+		"computer name": {"sysprep": []interface{}{map[string]interface{}{
+			"sysprep_script": []interface{}{map[string]interface{}{
+				"unattend": []interface{}{map[string]interface{}{"computer_name": "a-computer-name-too-long"}},
+			}},
+		}}},
+	} {
+		if diags := r.Validate(terraform.NewResourceConfigRaw(guestCustomization(tc))); !diags.HasError() {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+
+	for _, attr := range []string{
+		"guest_customization.0.config.0.cloud_init.0.cloud_init_script.0.cloud_config.0.users.0.hashed_passwd",
+		"guest_customization.0.config.0.cloud_init.0.cloud_init_script.0.cloud_config.0.write_files.0.content",
+		"guest_customization.0.config.0.sysprep.0.sysprep_script.0.unattend.0.admin_password",
+		"guest_customization.0.config.0.sysprep.0.sysprep_script.0.unattend.0.domain_join.0.password",
+	} {
+		if s := schema.InternalMap(r.Schema); !attributeSchema(s, attr).Sensitive {
+			t.Errorf("%s is not sensitive", attr)
+		}
+	}
+}
+
+// TestUnitV2NutanixVMResources_RenderedScriptsSensitive checks the unattend XML and cloud-init user data,
+// which the structured configs are rendered into along with their passwords, are sensitive
+func TestUnitV2NutanixVMResources_RenderedScriptsSensitive(t *testing.T) {
+	for name, r := range map[string]*schema.Resource{
+		"nutanix_virtual_machine_v2":      vmmv2.ResourceNutanixVirtualMachineV2(),
+		"nutanix_vm_clone_v2":             vmmv2.ResourceNutanixVMCloneV2(),
+		"nutanix_vm_gc_update_v2":         vmmv2.ResourceNutanixVMGCUpdateV2(),
+		"nutanix_template_v2":             vmmv2.ResourceNutanixTemplatesV2(),
+		"nutanix_deploy_templates_v2":     vmmv2.ResourceNutanixTemplateDeployV2(),
+		"data.nutanix_virtual_machine_v2": vmmv2.DatasourceNutanixVirtualMachineV4(),
+	} {
+		found := 0
+		walkSchema(r.Schema, "", func(attr string, s *schema.Schema) {
+			if !strings.HasSuffix(attr, ".unattend_xml.0.value") && !strings.HasSuffix(attr, ".user_data.0.value") {
+				return
+			}
+			found++
+			if !s.Sensitive {
+				t.Errorf("%s: %s is not sensitive", name, attr)
+			}
+		})
+		if found < 2 {
+			t.Errorf("%s: found %d unattend_xml and user_data values, expected at least 2", name, found)
+		}
+	}
+}
+
+// walkSchema calls fn with every attribute of the schema and its nested blocks
+func walkSchema(m map[string]*schema.Schema, prefix string, fn func(attr string, s *schema.Schema)) {
+	for key, s := range m {
+		attr := prefix + key
+		fn(attr, s)
+		if elem, ok := s.Elem.(*schema.Resource); ok {
+			walkSchema(elem.Schema, attr+".0.", fn)
+		}
+	}
+}
+
+func TestUnitV2NutanixVMGCUpdateResource_StructuredGuestCustomizationPlan(t *testing.T) {
+	r := vmmv2.ResourceNutanixVMGCUpdateV2()
+	cloudInit := func(cloudInit map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"ext_id": "vm-uuid",
+			"config": []interface{}{map[string]interface{}{"cloud_init": []interface{}{cloudInit}}},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		cfg      map[string]interface{}
+		expected string
+	}{
+		"cloud_config and user_data": {
+			cfg: cloudInit(map[string]interface{}{"cloud_init_script": []interface{}{map[string]interface{}{
+				"cloud_config": []interface{}{map[string]interface{}{"hostname": "tf-vm"}},
+				"user_data":    []interface{}{map[string]interface{}{"value": "I2Nsb3VkLWNvbmZpZwo="}},
+			}}}),
+			expected: `"cloud_config" and "user_data" can not both be set`,
+		},
+		"unattend and unattend_xml": {
+			cfg: map[string]interface{}{
+				"ext_id": "vm-uuid",
+				"config": []interface{}{map[string]interface{}{"sysprep": []interface{}{map[string]interface{}{
+					"sysprep_script": []interface{}{map[string]interface{}{
+						"unattend":     []interface{}{map[string]interface{}{"computer_name": "tf-vm"}},
+						"unattend_xml": []interface{}{map[string]interface{}{"value": "PHVuYXR0ZW5kLz4="}},
+					}},
+				}}}},
+			},
+			expected: `"unattend" and "unattend_xml" can not both be set`,
+		},
+		"cloud_config": {
+			cfg: cloudInit(map[string]interface{}{"cloud_init_script": []interface{}{map[string]interface{}{
+				"cloud_config": []interface{}{map[string]interface{}{"hostname": "tf-vm"}},
+			}}}),
+		},
+		"metadata not merged with network_config": {
+			cfg: cloudInit(map[string]interface{}{
+				"metadata":       "not base64",
+				"network_config": []interface{}{map[string]interface{}{"ethernets": []interface{}{map[string]interface{}{"name": "eth0", "dhcp4": true}}}},
+			}),
+			expected: "metadata must be base64 encoded YAML or JSON",
+		},
+	} {
+		config, _ := json.Marshal(tc.cfg)
+		rawConfig, err := ctyjson.Unmarshal(config, r.CoreConfigSchema().ImpliedType())
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		_, err = r.Diff(context.Background(), &terraform.InstanceState{RawConfig: rawConfig}, terraform.NewResourceConfigRaw(tc.cfg), nil)
+		if tc.expected == "" && err != nil {
+			t.Errorf("%s: plan error = %v", name, err)
+		}
+		if tc.expected != "" && (err == nil || !strings.Contains(err.Error(), tc.expected)) {
+			t.Errorf("%s: plan error = %v, expected %q", name, err, tc.expected)
+		}
+	}
+}
+
+func attributeSchema(m schema.InternalMap, attr string) *schema.Schema {
+	parts := strings.Split(attr, ".")
+	s := m[parts[0]]
+	for i := 2; i < len(parts); i += 2 {
+		s = s.Elem.(*schema.Resource).Schema[parts[i]]
+	}
+	return s
+}
@@ -1,8 +1,10 @@
 package vmmv2
 
 import (
+	"errors"
 	"strings"
 
+	vmmClient "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/client"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v4/vmm"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
@@ -22,3 +24,9 @@ func isVmmEtagMismatchErr(err error) bool {
 		strings.Contains(msg, "VM_ETAG_MISMATCH") ||
 		strings.Contains(msg, "VMM-30303")
 }
+
+// isVmmNotFoundErr reports whether err is a 404 response of the vmm API
+func isVmmNotFoundErr(err error) bool {
+	var apiErr vmmClient.GenericOpenAPIError
+	return errors.As(err, &apiErr) && strings.HasPrefix(apiErr.Status, "404")
+}
@@ -0,0 +1,180 @@
+package vmmv2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
+	esxiConfig "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/esxi/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// splitImportID splits an import id of the form part1/part2/..., format is the expected form used in the error
+func splitImportID(importID, format string) ([]string, error) {
+	parts := strings.Split(importID, "/")
+	if len(parts) != strings.Count(format, "/")+1 {
+		return nil, fmt.Errorf("invalid import id (%q), expected %s", importID, format)
+	}
+	for _, p := range parts {
+		if p == "" {
+			return nil, fmt.Errorf("invalid import id (%q), expected %s", importID, format)
+		}
+	}
+	return parts, nil
+}
+
+func getVMForImport(meta interface{}, vmExtID string) (*config.Vm, error) {
+	conn := meta.(*conns.Client).VmmAPI
+
+	resp, err := conn.VMAPIInstance.GetVmById(utils.StringPtr(vmExtID))
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching vm %s : %v", vmExtID, err)
+	}
+	vm := resp.Data.GetValue().(config.Vm)
+	return &vm, nil
+}
+
+// importNutanixVMCloneV2 imports a clone by its VM ext_id, the cloned VM comes from the source of the clone
+func importNutanixVMCloneV2(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vm, err := getVMForImport(meta, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if vm.Source == nil || vm.Source.EntityType == nil || *vm.Source.EntityType != config.VMSOURCEREFERENCEENTITYTYPE_VM {
+		return nil, fmt.Errorf("vm %s was not cloned from a vm, it can not be imported as a clone", d.Id())
+	}
+	if err := d.Set("vm_ext_id", utils.StringValue(vm.Source.ExtId)); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importNutanixTemplateDeployV2 imports the VMs deployed from a template, by <template_ext_id>/<vm_ext_id>[,<vm_ext_id>...].
+// All the VMs of the deployment are given, so that number_of_vms matches the deployment
+func importNutanixTemplateDeployV2(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	const format = "<template_ext_id>/<vm_ext_id>[,<vm_ext_id>...]"
+	parts, err := splitImportID(d.Id(), format)
+	if err != nil {
+		return nil, err
+	}
+	vmExtIDs := strings.Split(parts[1], ",")
+
+	clusterExtID := ""
+	for i, vmExtID := range vmExtIDs {
+		if vmExtID == "" {
+			return nil, fmt.Errorf("invalid import id (%q), expected %s", d.Id(), format)
+		}
+		vm, err := getVMForImport(meta, vmExtID)
+		if err != nil {
+			return nil, err
+		}
+		vmClusterExtID := ""
+		if vm.Cluster != nil {
+			vmClusterExtID = utils.StringValue(vm.Cluster.ExtId)
+		}
+		if i > 0 && vmClusterExtID != clusterExtID {
+			return nil, fmt.Errorf("vms %s and %s are on different clusters, they are not from the same deployment", vmExtIDs[0], vmExtID)
+		}
+		clusterExtID = vmClusterExtID
+	}
+
+	if err := d.Set("ext_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("number_of_vms", len(vmExtIDs)); err != nil {
+		return nil, err
+	}
+	if err := d.Set("cluster_reference", clusterExtID); err != nil {
+		return nil, err
+	}
+	// a deployment has the id of its first vm
+	d.SetId(vmExtIDs[0])
+	return []*schema.ResourceData{d}, nil
+}
+
+// importNutanixOvaVMDeploymentV2 imports a VM deployed from an OVA, by <ova_ext_id>/<vm_ext_id>
+func importNutanixOvaVMDeploymentV2(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportID(d.Id(), "<ova_ext_id>/<vm_ext_id>")
+	if err != nil {
+		return nil, err
+	}
+	vm, err := getVMForImport(meta, parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("ext_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if vm.Cluster != nil {
+		if err := d.Set("cluster_location_ext_id", utils.StringValue(vm.Cluster.ExtId)); err != nil {
+			return nil, err
+		}
+	}
+	// the Read only refreshes an existing override_vm_config, and only the disks already in it
+	if err := d.Set("override_vm_config", []interface{}{map[string]interface{}{
+		"name":  utils.StringValue(vm.Name),
+		"disks": flattenDisk(vm.Disks),
+	}}); err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// importNutanixNGTInstallationV2 imports the NGT installation of a VM by the VM ext_id
+func importNutanixNGTInstallationV2(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("ext_id", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importNutanixVmsShutdownActionV2 records an action already done on a VM, by <vm_ext_id>/<action>,
+// so that it is not done again by the first apply
+func importNutanixVmsShutdownActionV2(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportID(d.Id(), "<vm_ext_id>/<action>")
+	if err != nil {
+		return nil, err
+	}
+	switch parts[1] {
+	case "shutdown", "guest_shutdown", "reboot", "guest_reboot":
+	default:
+		return nil, fmt.Errorf("invalid import id (%q), action should be one of shutdown, guest_shutdown, reboot, guest_reboot", d.Id())
+	}
+	if _, err := getVMForImport(meta, parts[0]); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("ext_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := d.Set("action", parts[1]); err != nil {
+		return nil, err
+	}
+	d.SetId(resource.UniqueId())
+	return []*schema.ResourceData{d}, nil
+}
+
+// importNutanixVMCategoriesEsxiV2 imports the categories of an ESXi VM by the VM ext_id, all of them become managed
+func importNutanixVMCategoriesEsxiV2(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	conn := meta.(*conns.Client).VmmAPI
+
+	resp, err := conn.EsxiVMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching esxi vm %s : %v", d.Id(), err)
+	}
+	vm := resp.Data.GetValue().(esxiConfig.Vm)
+
+	if err := d.Set("ext_id", d.Id()); err != nil {
+		return nil, err
+	}
+	if err := d.Set("categories", flattenEsxiCategoryReference(vm.Categories)); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
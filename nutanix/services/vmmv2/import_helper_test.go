@@ -0,0 +1,222 @@
+package vmmv2_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
+)
+
+func importResource(t *testing.T, r *schema.Resource, importID string, meta interface{}) (*schema.ResourceData, error) {
+	d := r.Data(nil)
+	d.SetId(importID)
+	imported, err := r.Importer.StateContext(context.Background(), d, meta)
+	if err != nil {
+		return nil, err
+	}
+	if len(imported) != 1 {
+		t.Fatalf("imported %d resources, expected 1", len(imported))
+	}
+	return imported[0], nil
+}
+
+func TestUnitV2NutanixVMCloneResource_Import(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	const vmsPath = "vmm/ahv/config/vms"
+	cloneExtID := pc.Add(vmsPath, map[string]interface{}{
+		"name":    "tf-clone",
+		"cluster": map[string]interface{}{"extId": "cluster-1"},
+		"source":  map[string]interface{}{"entityType": "VM", "extId": "source-vm"},
+	})
+	recoveredExtID := pc.Add(vmsPath, map[string]interface{}{
+		"name":   "tf-restored",
+		"source": map[string]interface{}{"entityType": "VM_RECOVERY_POINT", "extId": "recovery-point"},
+	})
+
+	r := vmmv2.ResourceNutanixVMCloneV2()
+	d, err := importResource(t, r, cloneExtID, meta)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != cloneExtID || d.Get("vm_ext_id") != "source-vm" {
+		t.Errorf("id = %s, vm_ext_id = %v, expected the clone with its source vm", d.Id(), d.Get("vm_ext_id"))
+	}
+	if d.Get("name") != "tf-clone" {
+		t.Errorf("name = %v, expected the clone to be read back", d.Get("name"))
+	}
+
+	if _, err := importResource(t, r, recoveredExtID, meta); err == nil {
+		t.Error("expected an error importing a vm restored from a recovery point as a clone")
+	}
+}
+
+func TestUnitV2NutanixTemplateDeployResource_Import(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	const vmsPath = "vmm/ahv/config/vms"
+	var vmExtIDs []string
+	for _, name := range []string{"tf-deployed-1", "tf-deployed-2"} {
+		vmExtIDs = append(vmExtIDs, pc.Add(vmsPath, map[string]interface{}{
+			"name":    name,
+			"cluster": map[string]interface{}{"extId": "cluster-1"},
+		}))
+	}
+	otherClusterExtID := pc.Add(vmsPath, map[string]interface{}{
+		"name":    "tf-other",
+		"cluster": map[string]interface{}{"extId": "cluster-2"},
+	})
+
+	r := vmmv2.ResourceNutanixTemplateDeployV2()
+	d, err := importResource(t, r, "template-1/"+strings.Join(vmExtIDs, ","), meta)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	for attr, expected := range map[string]interface{}{
+		"ext_id":            "template-1",
+		"number_of_vms":     2,
+		"cluster_reference": "cluster-1",
+	} {
+		if got := d.Get(attr); got != expected {
+			t.Errorf("%s = %v, expected %v", attr, got, expected)
+		}
+	}
+	if d.Id() != vmExtIDs[0] {
+		t.Errorf("id = %s, expected the ext_id of the first vm %s", d.Id(), vmExtIDs[0])
+	}
+
+	for _, importID := range []string{
+		vmExtIDs[0],
+		"template-1/" + vmExtIDs[0] + ",",
+		"template-1/" + vmExtIDs[0] + "," + otherClusterExtID,
+		"template-1/" + vmExtIDs[0] + ",missing-vm",
+	} {
+		if _, err := importResource(t, r, importID, meta); err == nil {
+			t.Errorf("%s: expected an import error", importID)
+		}
+	}
+}
+
+func TestUnitV2NutanixOvaVMDeploymentResource_Import(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	vmExtID := pc.Add("vmm/ahv/config/vms", map[string]interface{}{
+		"name":            "tf-ova-vm",
+		"numSockets":      2,
+		"memorySizeBytes": 4294967296,
+		"cluster":         map[string]interface{}{"extId": "cluster-1"},
+		"disks": []interface{}{map[string]interface{}{
+			"extId":       "disk-1",
+			"diskAddress": map[string]interface{}{"busType": "SCSI", "index": 0},
+		}},
+	})
+
+	r := vmmv2.ResourceNutanixOvaVMDeploymentV2()
+	d, err := importResource(t, r, "ova-1/"+vmExtID, meta)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	for attr, expected := range map[string]interface{}{
+		"ext_id":                                               "ova-1",
+		"cluster_location_ext_id":                              "cluster-1",
+		"override_vm_config.0.name":                            "tf-ova-vm",
+		"override_vm_config.0.num_sockets":                     2,
+		"override_vm_config.0.memory_size_bytes":               4294967296,
+		"override_vm_config.0.disks.#":                         1,
+		"override_vm_config.0.disks.0.ext_id":                  "disk-1",
+		"override_vm_config.0.disks.0.disk_address.0.bus_type": "SCSI",
+	} {
+		if got := d.Get(attr); got != expected {
+			t.Errorf("%s = %v, expected %v", attr, got, expected)
+		}
+	}
+}
+
+func TestUnitV2NutanixVmsShutdownActionResource_Import(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	vmExtID := pc.Add("vmm/ahv/config/vms", map[string]interface{}{"name": "tf-vm"})
+
+	r := vmmv2.ResourceNutanixVmsShutdownActionV2()
+	d, err := importResource(t, r, vmExtID+"/guest_shutdown", meta)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if d.Id() == "" || d.Get("ext_id") != vmExtID || d.Get("action") != "guest_shutdown" {
+		t.Errorf("id = %s, ext_id = %v, action = %v, expected the guest shutdown of %s", d.Id(), d.Get("ext_id"), d.Get("action"), vmExtID)
+	}
+
+	if _, err := importResource(t, r, vmExtID+"/power_off", meta); err == nil {
+		t.Error("expected an error importing an unknown action")
+	}
+	for _, req := range pc.Requests() {
+		if req.Method != http.MethodGet {
+			t.Errorf("import called %s %s, expected no action to be performed", req.Method, req.Path)
+		}
+	}
+}
+
+func TestUnitV2NutanixVMCategoriesEsxiResource_Import(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(esxiVMs)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	extID := pc.Add(esxiVMs.Path, map[string]interface{}{
+		"name": "esxi-vm-1",
+		"categories": []interface{}{
+			map[string]interface{}{"extId": "category-1"},
+			map[string]interface{}{"extId": "category-2"},
+		},
+	})
+
+	r := vmmv2.ResourceNutanixVMCategoriesEsxiV2()
+	d, err := importResource(t, r, extID, meta)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Get("ext_id") != extID || d.Get("categories.#") != 2 {
+		t.Errorf("ext_id = %v, categories = %v, expected all the categories of %s", d.Get("ext_id"), d.Get("categories"), extID)
+	}
+}
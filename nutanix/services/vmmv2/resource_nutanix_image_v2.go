@@ -5,19 +5,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	import5 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/content"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v4/vmm"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
+// minImageUploadChunkSize is the smallest chunk size accepted for local file uploads
+const minImageUploadChunkSize = 1024 * 1024
+
 func ResourceNutanixImageV4() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: ResourceNutanixImageV4Create,
@@ -28,7 +31,7 @@ func ResourceNutanixImageV4() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
-			sources := []string{"source.0.url_source", "source.0.vm_disk_source", "source.0.object_lite_source"}
+			sources := []string{"source.0.url_source", "source.0.vm_disk_source", "source.0.object_lite_source", "source.0.local_file_source"}
 			count := 0
 			for _, s := range sources {
 				if _, ok := d.GetOk(s); ok {
@@ -36,7 +39,7 @@ func ResourceNutanixImageV4() *schema.Resource {
 				}
 			}
 			if count > 1 {
-				return fmt.Errorf("only one of url_source, vm_disk_source, or object_lite_source can be specified in source")
+				return fmt.Errorf("only one of url_source, vm_disk_source, object_lite_source or local_file_source can be specified in source")
 			}
 			return nil
 		},
@@ -139,6 +142,26 @@ func ResourceNutanixImageV4() *schema.Resource {
 								},
 							},
 						},
+						"local_file_source": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"path": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"chunk_size_bytes": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      vmm.DefaultImageUploadChunkSize,
+										ValidateFunc: validation.IntAtLeast(minImageUploadChunkSize),
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -252,7 +275,13 @@ func ResourceNutanixImageV4Create(ctx context.Context, d *schema.ResourceData, m
 	if checksum, ok := d.GetOk("checksum"); ok {
 		body.Checksum = expandOneOfImageChecksum(checksum)
 	}
-	if src, ok := d.GetOk("source"); ok {
+	localFile, isLocalFile := d.GetOk("source.0.local_file_source.0")
+	if isLocalFile {
+		// fail before creating the image if the local file does not match the expected checksum
+		if err := verifyImageLocalFileChecksum(localFile.(map[string]interface{})["path"].(string), d.Get("checksum")); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if src, ok := d.GetOk("source"); ok {
 		body.Source = expandOneOfImageSource(src)
 	}
 	if ctgExts, ok := d.GetOk("category_ext_ids"); ok {
@@ -272,32 +301,31 @@ func ResourceNutanixImageV4Create(ctx context.Context, d *schema.ResourceData, m
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the image to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for image (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching image create task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
-
 	aJSON, _ := json.MarshalIndent(taskDetails, "", " ")
 	log.Printf("[DEBUG] Create Image Task Details: %s", string(aJSON))
 
-	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeImages, "Image")
+	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeImages, "Image")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(utils.StringValue(uuid))
+
+	if isLocalFile {
+		if err := uploadImageLocalFile(ctx, conn, d.Id(), localFile.(map[string]interface{}), d.Get("checksum")); err != nil {
+			diags := diag.Errorf("error while uploading image (%s) file: %v", d.Id(), err)
+			// an image without its file is of no use, the next apply creates it again
+			if deleteDiags := ResourceNutanixImageV4Delete(ctx, d, meta); deleteDiags.HasError() {
+				return append(diags, deleteDiags...)
+			}
+			d.SetId("")
+			return diags
+		}
+	}
 	return ResourceNutanixImageV4Read(ctx, d, meta)
 }
 
@@ -335,8 +363,11 @@ func ResourceNutanixImageV4Read(ctx context.Context, d *schema.ResourceData, met
 	if err := d.Set("size_bytes", getResp.SizeBytes); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("source", flattenOneOfImageSource(getResp.Source)); err != nil {
-		return diag.FromErr(err)
+	// images uploaded from a local file have no source on Prism Central, keep the configured one
+	if _, ok := d.GetOk("source.0.local_file_source"); !ok || getResp.Source != nil {
+		if err := d.Set("source", flattenOneOfImageSource(getResp.Source)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	if err := d.Set("category_ext_ids", getResp.CategoryExtIds); err != nil {
 		return diag.FromErr(err)
@@ -396,7 +427,7 @@ func ResourceNutanixImageV4Update(ctx context.Context, d *schema.ResourceData, m
 	if d.HasChange("checksum") {
 		updateSpec.Checksum = expandOneOfImageChecksum(d.Get("checksum"))
 	}
-	if d.HasChange("source") {
+	if _, isLocalFile := d.GetOk("source.0.local_file_source"); d.HasChange("source") && !isLocalFile {
 		updateSpec.Source = expandOneOfImageSource(d.Get("source"))
 	}
 	if d.HasChange("category_ext_ids") {
@@ -415,14 +446,7 @@ func ResourceNutanixImageV4Update(ctx context.Context, d *schema.ResourceData, m
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the image to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 		return diag.Errorf("error waiting for image (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
@@ -441,14 +465,7 @@ func ResourceNutanixImageV4Delete(ctx context.Context, d *schema.ResourceData, m
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the image to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 		return diag.Errorf("error waiting for image (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 	return nil
@@ -462,14 +479,14 @@ func expandOneOfImageChecksum(pr interface{}) *import5.OneOfImageChecksum {
 		chksum := &import5.OneOfImageChecksum{}
 
 		if val["object_type"] == "sha1" {
-			sha1 := chksum.GetValue().(import5.ImageSha1Checksum)
+			sha1 := import5.NewImageSha1Checksum()
 
 			sha1.HexDigest = utils.StringPtr(val["hex_digest"].(string))
-			chksum.SetValue(sha1)
+			chksum.SetValue(*sha1)
 		} else {
-			sha256 := chksum.GetValue().(import5.ImageSha256Checksum)
+			sha256 := import5.NewImageSha256Checksum()
 			sha256.HexDigest = utils.StringPtr(val["hex_digest"].(string))
-			chksum.SetValue(sha256)
+			chksum.SetValue(*sha256)
 		}
 		return chksum
 	}
@@ -559,3 +576,38 @@ func flattenStringValue(pr []interface{}) []string {
 	}
 	return res
 }
+
+// verifyImageLocalFileChecksum checks a local image file against the checksum block, if set
+func verifyImageLocalFileChecksum(path string, checksum interface{}) error {
+	checksums := checksum.([]interface{})
+	if len(checksums) == 0 || checksums[0] == nil {
+		return nil
+	}
+	val := checksums[0].(map[string]interface{})
+
+	digest, err := vmm.FileChecksum(path, val["object_type"].(string))
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(digest, val["hex_digest"].(string)) {
+		return fmt.Errorf("%s checksum of %s is %s, expected %s", val["object_type"], path, digest, val["hex_digest"])
+	}
+	return nil
+}
+
+// uploadImageLocalFile streams a local file to an image created without source
+func uploadImageLocalFile(ctx context.Context, conn *vmm.Client, extID string, localFile map[string]interface{}, checksum interface{}) error {
+	opts := vmm.ImageUploadOptions{
+		ChunkSize: int64(localFile["chunk_size_bytes"].(int)),
+		RetryWait: vmm.DefaultImageUploadRetryWait,
+	}
+	if checksums := checksum.([]interface{}); len(checksums) > 0 && checksums[0] != nil {
+		val := checksums[0].(map[string]interface{})
+		opts.ChecksumType = val["object_type"].(string)
+		opts.Checksum = val["hex_digest"].(string)
+	}
+
+	path := localFile["path"].(string)
+	log.Printf("[DEBUG] uploading %s to image %s", path, extID)
+	return conn.ImageUploadAPIInstance.UploadImageFile(ctx, extID, path, opts)
+}
@@ -1,13 +1,20 @@
 package vmmv2_test
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
 )
 
 const resourceNameImage = "nutanix_images_v2.test"
@@ -287,3 +294,70 @@ resource "nutanix_images_v2" "test" {
 }
 `
 }
+
+func TestUnitV2NutanixImageResource_LocalFileSource(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	content := make([]byte, 3*1024*1024+10)
+	for i := range content {
+		content[i] = byte(i)
+	}
+	path := t.TempDir() + "/image.iso"
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var uploaded []byte
+	pc.Handle(http.MethodPut, "vmm/content/images/{extId}/file", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		uploaded = append(uploaded, body...)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	r := vmmv2.ResourceNutanixImageV4()
+	config := map[string]interface{}{
+		"name": "tf-test-image",
+		"type": "ISO_IMAGE",
+		"checksum": []interface{}{map[string]interface{}{
+			"object_type": "sha1",
+			"hex_digest":  "0000000000000000000000000000000000000000",
+		}},
+		"source": []interface{}{map[string]interface{}{
+			"local_file_source": []interface{}{map[string]interface{}{
+				"path":             path,
+				"chunk_size_bytes": 1024 * 1024,
+			}},
+		}},
+	}
+
+	// the image is not created if the local file does not match the checksum
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, meta); !diags.HasError() {
+		t.Fatal("expected a checksum mismatch error")
+	}
+	if images := pc.List("vmm/content/images"); len(images) != 0 {
+		t.Fatalf("image created despite the checksum mismatch: %v", images)
+	}
+
+	delete(config, "checksum")
+	d = schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if image, ok := pc.Get("vmm/content/images", d.Id()); !ok || image["source"] != nil {
+		t.Errorf("unexpected image created: %v", image)
+	}
+	if len(uploaded) != len(content) || string(uploaded) != string(content) {
+		t.Errorf("uploaded %d bytes, expected the %d bytes of the file", len(uploaded), len(content))
+	}
+	if got := d.Get("source.0.local_file_source.0.path").(string); got != path {
+		t.Errorf("source.0.local_file_source.0.path = %s, expected %s", got, path)
+	}
+}
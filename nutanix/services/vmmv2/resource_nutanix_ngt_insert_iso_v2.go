@@ -7,10 +7,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	taskPoll "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	vmmPrism "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	vmmConfig "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
@@ -151,24 +149,11 @@ func ResourceNutanixNGTInsertIsoV2Create(ctx context.Context, d *schema.Resource
 
 		taskconn := meta.(*conns.Client).PrismAPI
 		// Wait for the NGT ISO to be inserted
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutCreate),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+		if errWaitTask != nil {
 			return diag.Errorf("error waiting for NGT ISO insert (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 
-		// Get UUID from TASK API
-		taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-		if err != nil {
-			return diag.Errorf("error while fetching NGT ISO insert task (%s): %v", utils.StringValue(taskUUID), err)
-		}
-		taskDetails := taskResp.Data.GetValue().(taskPoll.Task)
-
 		aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 		log.Printf("[DEBUG] NGT ISO Insert Task Details: %s", string(aJSON))
 
@@ -318,14 +303,7 @@ func ejectCdromISO(ctx context.Context, d *schema.ResourceData, meta interface{}
 		taskconn := meta.(*conns.Client).PrismAPI
 
 		// Wait for the CD-ROM to be ejected
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutDelete),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 			// Retry only for the known ETag mismatch failure mode.
 			if attempt < maxAttempts && isVmmEtagMismatchErr(errWaitTask) {
 				log.Printf("[DEBUG] ISO EJECTION failed due to VM ETag mismatch (attempt %d/%d). Retrying with refreshed ETag. Task UUID: %s, error: %s",
@@ -0,0 +1,223 @@
+package vmmv2
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
+	esxiConfig "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/esxi/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// ResourceNutanixNGTInstallationEsxiV2 installs and uninstalls NGT on an ESXi VM.
+// It shares its schema with the AHV NGT installation, except that ESXi requires the guest credential.
+func ResourceNutanixNGTInstallationEsxiV2() *schema.Resource {
+	s := ResourceNutanixNGTInstallationV2().Schema
+	s["credential"].Optional = false
+	s["credential"].Required = true
+
+	return &schema.Resource{
+		CreateContext: ResourceNutanixNGTInstallationEsxiV2Create,
+		ReadContext:   ResourceNutanixNGTInstallationEsxiV2Read,
+		UpdateContext: ResourceNutanixNGTInstallationEsxiV2Update,
+		DeleteContext: ResourceNutanixNGTInstallationEsxiV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importNutanixNGTInstallationV2,
+		},
+		Schema: s,
+	}
+}
+
+func ResourceNutanixNGTInstallationEsxiV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	vmExtID := utils.StringPtr(d.Get("ext_id").(string))
+
+	readResp, err := conn.EsxiVMAPIInstance.GetNutanixGuestToolsById(vmExtID)
+	if err != nil {
+		return diag.Errorf("error while fetching esxi vm NGT configuration : %v", err)
+	}
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	body := &esxiConfig.NutanixGuestToolsInstallConfig{
+		Capabilities: common.ExpandEnumList[esxiConfig.NutanixGuestToolsCapability](d.Get("capablities").([]interface{})),
+	}
+	credential := d.Get("credential").([]interface{})[0].(map[string]interface{})
+	body.Credential = &esxiConfig.NutanixCredential{
+		Username: utils.StringPtr(credential["username"].(string)),
+		Password: utils.StringPtr(credential["password"].(string)),
+	}
+	rebootPreference, err := expandEsxiRebootPreference(d.Get("reboot_preference").([]interface{}))
+	if err != nil {
+		return diag.Errorf("error while installing esxi vm NGT : %v", err)
+	}
+	body.RebootPreference = rebootPreference
+
+	resp, err := conn.EsxiVMAPIInstance.InstallNutanixGuestTools(vmExtID, body, args)
+	if err != nil {
+		return diag.Errorf("error while installing esxi vm NGT : %v", err)
+	}
+
+	taskRef := resp.Data.GetValue().(import1.TaskReference)
+	taskUUID := taskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	// Wait for the NGT to be installed
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for esxi vm NGT installation (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	d.SetId(utils.StringValue(vmExtID))
+	return ResourceNutanixNGTInstallationEsxiV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixNGTInstallationEsxiV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	resp, err := conn.EsxiVMAPIInstance.GetNutanixGuestToolsById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching esxi vm NGT configuration : %v", err)
+	}
+	return setEsxiNGTAttributes(d, resp.Data.GetValue().(esxiConfig.NutanixGuestTools))
+}
+
+func ResourceNutanixNGTInstallationEsxiV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	vmExtID := utils.StringPtr(d.Id())
+
+	readResp, err := conn.EsxiVMAPIInstance.GetNutanixGuestToolsById(vmExtID)
+	if err != nil {
+		return diag.Errorf("error while fetching esxi vm NGT configuration : %v", err)
+	}
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	getResp := readResp.Data.GetValue().(esxiConfig.NutanixGuestTools)
+	updateSpec := getResp
+
+	if d.HasChange("capablities") {
+		updateSpec.Capabilities = common.ExpandEnumList[esxiConfig.NutanixGuestToolsCapability](d.Get("capablities").([]interface{}))
+	}
+	if d.HasChange("is_enabled") {
+		updateSpec.IsEnabled = utils.BoolPtr(d.Get("is_enabled").(bool))
+	}
+
+	if reflect.DeepEqual(getResp, updateSpec) {
+		log.Printf("[DEBUG] esxi vm NGT configuration is same, no update required")
+		return ResourceNutanixNGTInstallationEsxiV2Read(ctx, d, meta)
+	}
+
+	aJSON, _ := json.Marshal(updateSpec)
+	log.Printf("[DEBUG] esxi vm NGT update payload : %s", string(aJSON))
+
+	resp, err := conn.EsxiVMAPIInstance.UpdateNutanixGuestToolsById(vmExtID, &updateSpec, args)
+	if err != nil {
+		return diag.Errorf("error while updating esxi vm NGT : %v", err)
+	}
+
+	taskRef := resp.Data.GetValue().(import1.TaskReference)
+	taskUUID := taskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	// Wait for the NGT to be updated
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for esxi vm NGT update (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	return ResourceNutanixNGTInstallationEsxiV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixNGTInstallationEsxiV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	vmExtID := utils.StringPtr(d.Id())
+
+	readResp, err := conn.EsxiVMAPIInstance.GetVmById(vmExtID)
+	if err != nil {
+		return diag.Errorf("error while reading esxi vm : %v", err)
+	}
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	resp, err := conn.EsxiVMAPIInstance.UninstallNutanixGuestTools(vmExtID, args)
+	if err != nil {
+		return diag.Errorf("error while uninstalling esxi vm NGT : %v", err)
+	}
+
+	taskRef := resp.Data.GetValue().(import1.TaskReference)
+	taskUUID := taskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	// Wait for the NGT to be uninstalled
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
+		return diag.Errorf("error waiting for esxi vm NGT uninstallation (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func expandEsxiRebootPreference(pr []interface{}) (*esxiConfig.NutanixRebootPreference, error) {
+	if len(pr) == 0 || pr[0] == nil {
+		return nil, nil
+	}
+	rp := pr[0].(map[string]interface{})
+
+	rebootPreference := &esxiConfig.NutanixRebootPreference{
+		ScheduleType: common.ExpandEnum[esxiConfig.NutanixScheduleType](rp["schedule_type"].(string)),
+	}
+	if rp["schedule_type"].(string) == "LATER" {
+		if schedule, ok := rp["schedule"].([]interface{}); ok && len(schedule) > 0 {
+			s := schedule[0].(map[string]interface{})
+			t, err := time.Parse(time.RFC3339, s["start_time"].(string))
+			if err != nil {
+				return nil, err
+			}
+			rebootPreference.Schedule = &esxiConfig.NutanixRebootPreferenceSchedule{
+				StartTime: utils.Time(t),
+			}
+		}
+	}
+	return rebootPreference, nil
+}
+
+func setEsxiNGTAttributes(d *schema.ResourceData, ngt esxiConfig.NutanixGuestTools) diag.Diagnostics {
+	if err := d.Set("version", ngt.Version); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_installed", ngt.IsInstalled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_enabled", ngt.IsEnabled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_iso_inserted", ngt.IsIsoInserted); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("capablities", flattenCapabilities(ngt.Capabilities)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("available_version", ngt.AvailableVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("guest_os_version", ngt.GuestOsVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_reachable", ngt.IsReachable); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_vss_snapshot_capable", ngt.IsVssSnapshotCapable); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_vm_mobility_drivers_installed", ngt.IsVmMobilityDriversInstalled); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package vmmv2_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
+)
+
+func TestUnitV2NutanixNGTInstallationEsxiResource_Install(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(esxiVMs)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	extID := pc.Add(esxiVMs.Path, map[string]interface{}{"name": "esxi-vm-1"})
+
+	ngt := map[string]interface{}{"$objectType": "vmm.v4.esxi.config.NutanixGuestTools", "isInstalled": false}
+	pc.Handle(http.MethodGet, esxiVMs.Path+"/{extId}/nutanix-guest-tools", func(w http.ResponseWriter, r *http.Request) {
+		mockpc.WriteJSON(w, http.StatusOK, map[string]interface{}{"data": ngt})
+	})
+	var install map[string]interface{}
+	pc.Handle(http.MethodPost, esxiVMs.Path+"/{extId}/nutanix-guest-tools/$actions/install", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&install)
+		ngt["isInstalled"] = true
+		ngt["version"] = "4.1"
+		ngt["capabilities"] = install["capabilities"]
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("install-ngt", esxiVMs, extID))
+	})
+
+	r := vmmv2.ResourceNutanixNGTInstallationEsxiV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"ext_id":      extID,
+		"credential":  []interface{}{map[string]interface{}{"username": "root", "password": "secret"}},
+		"capablities": []interface{}{"VSS_SNAPSHOT"},
+		"reboot_preference": []interface{}{map[string]interface{}{
+			"schedule_type": "LATER",
+			"schedule":      []interface{}{map[string]interface{}{"start_time": "2026-10-18T02:00:00Z"}},
+		}},
+	})
+	if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	if d.Id() != extID {
+		t.Errorf("id = %s, expected the vm ext_id %s", d.Id(), extID)
+	}
+	if got := install["credential"].(map[string]interface{})["username"]; got != "root" {
+		t.Errorf("credential username = %v, expected root", got)
+	}
+	if got := install["capabilities"].([]interface{}); len(got) != 1 || got[0] != "VSS_SNAPSHOT" {
+		t.Errorf("capabilities = %v, expected [VSS_SNAPSHOT]", got)
+	}
+	rebootPreference := install["rebootPreference"].(map[string]interface{})
+	if rebootPreference["scheduleType"] != "LATER" || rebootPreference["schedule"].(map[string]interface{})["startTime"] != "2026-10-18T02:00:00Z" {
+		t.Errorf("reboot preference = %v, expected a reboot scheduled LATER", rebootPreference)
+	}
+	if d.Get("is_installed") != true || d.Get("version") != "4.1" {
+		t.Errorf("is_installed = %v, version = %v, expected the installed NGT to be read back", d.Get("is_installed"), d.Get("version"))
+	}
+}
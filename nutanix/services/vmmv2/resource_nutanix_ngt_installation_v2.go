@@ -9,10 +9,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	taskPoll "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	vmmPrism "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	vmmConfig "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
@@ -27,6 +25,9 @@ func ResourceNutanixNGTInstallationV2() *schema.Resource {
 		ReadContext:   ResourceNutanixNGTInstallationV4Read,
 		UpdateContext: ResourceNutanixNGTInstallationV4Update,
 		DeleteContext: ResourceNutanixNGTInstallationV4Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importNutanixNGTInstallationV2,
+		},
 		Schema: map[string]*schema.Schema{
 			"ext_id": {
 				Type:     schema.TypeString,
@@ -247,28 +248,15 @@ func ResourceNutanixNGTInstallationV4Create(ctx context.Context, d *schema.Resou
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the NGT to be installed
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for NGT installation (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching NGT installation task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(taskPoll.Task)
-
 	aJSON, _ = json.MarshalIndent(taskDetails, "", " ")
 	log.Printf("[DEBUG] NGT Installation Task Details: %s", string(aJSON))
 
-	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeVM, "VM")
+	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeVM, "VM")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -390,14 +378,7 @@ func ResourceNutanixNGTInstallationV4Update(ctx context.Context, d *schema.Resou
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the NGT to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 		return diag.Errorf("error waiting for NGT update (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
@@ -433,30 +414,14 @@ func ResourceNutanixNGTInstallationV4Delete(ctx context.Context, d *schema.Resou
 		TaskRef := resp.Data.GetValue().(vmmPrism.TaskReference)
 		taskUUID = TaskRef.ExtId
 
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutDelete),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 			if attempt < maxAttempts && isVmmEtagMismatchErr(errWaitTask) {
 				log.Printf("[DEBUG] NGT uninstall failed due to VM ETag mismatch (attempt %d/%d). Retrying with refreshed ETag. Task UUID: %s, error: %s",
 					attempt, maxAttempts, utils.StringValue(taskUUID), errWaitTask)
 				time.Sleep(2 * time.Second)
 				continue
 			}
-			taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-			if err != nil {
-				taskDetails := taskResp.Data.GetValue().(taskPoll.Task)
-				aJSON, _ := json.MarshalIndent(taskDetails, "", " ")
-				log.Printf("[DEBUG] NGT Uninstallation Task Details: %s", string(aJSON))
-				return diag.Errorf("error while uninstalling gest tools, in Get UUID from TASK API  : %v", err)
-			}
-			taskDetails := taskResp.Data.GetValue().(taskPoll.Task)
-			aJSON, _ := json.MarshalIndent(taskDetails, "", " ")
-			log.Printf("[DEBUG] NGT Uninstallation Task Details: %s", string(aJSON))
+			return diag.Errorf("error waiting for guest tools uninstallation (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 		break
 	}
@@ -0,0 +1,82 @@
+package vmmv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
+	esxiConfig "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/esxi/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// ResourceNutanixNGTUpgradeEsxiV2 upgrades NGT on an ESXi VM, it shares its schema with the AHV NGT upgrade
+func ResourceNutanixNGTUpgradeEsxiV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixNGTUpgradeEsxiV2Create,
+		ReadContext:   ResourceNutanixNGTUpgradeEsxiV2Read,
+		UpdateContext: ResourceNutanixNGTUpgradeEsxiV2Update,
+		DeleteContext: ResourceNutanixNGTUpgradeEsxiV2Delete,
+		Schema:        ResourceNutanixNGTUpgradeV2().Schema,
+	}
+}
+
+func ResourceNutanixNGTUpgradeEsxiV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	vmExtID := utils.StringPtr(d.Get("ext_id").(string))
+
+	readResp, err := conn.EsxiVMAPIInstance.GetNutanixGuestToolsById(vmExtID)
+	if err != nil {
+		return diag.Errorf("error while fetching esxi vm NGT configuration : %v", err)
+	}
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	rebootPreference, err := expandEsxiRebootPreference(d.Get("reboot_preference").([]interface{}))
+	if err != nil {
+		return diag.Errorf("error while upgrading esxi vm NGT : %v", err)
+	}
+	body := &esxiConfig.NutanixGuestToolsUpgradeConfig{
+		RebootPreference: rebootPreference,
+	}
+
+	resp, err := conn.EsxiVMAPIInstance.UpgradeNutanixGuestTools(vmExtID, body, args)
+	if err != nil {
+		return diag.Errorf("error while upgrading esxi vm NGT : %v", err)
+	}
+
+	taskRef := resp.Data.GetValue().(import1.TaskReference)
+	taskUUID := taskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	// Wait for the NGT upgrade to complete
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for esxi vm NGT upgrade (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+
+	d.SetId(utils.StringValue(vmExtID))
+	return ResourceNutanixNGTUpgradeEsxiV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixNGTUpgradeEsxiV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	resp, err := conn.EsxiVMAPIInstance.GetNutanixGuestToolsById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching esxi vm NGT configuration : %v", err)
+	}
+	return setEsxiNGTAttributes(d, resp.Data.GetValue().(esxiConfig.NutanixGuestTools))
+}
+
+// ResourceNutanixNGTUpgradeEsxiV2Update  Not supported
+func ResourceNutanixNGTUpgradeEsxiV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// ResourceNutanixNGTUpgradeEsxiV2Delete  Not supported
+func ResourceNutanixNGTUpgradeEsxiV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
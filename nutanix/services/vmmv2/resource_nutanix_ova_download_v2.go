@@ -3,12 +3,19 @@ package vmmv2
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/content"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v4/vmm"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -23,10 +30,41 @@ func ResourceNutanixOvaDownloadV2() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"local_file_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"verify_checksum": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"disk_format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"local_file_path"},
+				ValidateFunc: common.ValidateEnum[content.OvaDiskFormat](),
+			},
+			"max_attempts": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      vmm.DefaultOvaDownloadAttempts,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
 			"ova_file_path": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"size_bytes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"checksum": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -35,6 +73,14 @@ func ResourceNutanixOvaDownloadV2Create(ctx context.Context, d *schema.ResourceD
 	conn := meta.(*conns.Client).VmmAPI
 
 	ovaExtID := d.Get("ova_ext_id")
+	if localFilePath, ok := d.GetOk("local_file_path"); ok {
+		if err := downloadOvaToLocalFile(ctx, d, meta, ovaExtID.(string), localFilePath.(string)); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(ovaExtID.(string))
+		return ResourceNutanixOvaDownloadV2Read(ctx, d, meta)
+	}
+
 	resp, err := conn.OvasAPIInstance.GetFileByOvaId(utils.StringPtr(ovaExtID.(string)))
 	if err != nil {
 		return diag.Errorf("error Downloading Ova file: %v", err)
@@ -63,13 +109,164 @@ func ResourceNutanixOvaDownloadV2Create(ctx context.Context, d *schema.ResourceD
 }
 
 func ResourceNutanixOvaDownloadV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// a local file removed since the download is downloaded again
+	if localFilePath, ok := d.GetOk("local_file_path"); ok {
+		if _, err := os.Stat(localFilePath.(string)); os.IsNotExist(err) {
+			log.Printf("[WARN] OVA file %s not found, downloading it again", localFilePath)
+			d.SetId("")
+		}
+	}
 	return nil
 }
 
 func ResourceNutanixOvaDownloadV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// the download settings only apply to the next download
+	if _, ok := d.GetOk("local_file_path"); ok && !d.HasChange("ova_ext_id") {
+		return ResourceNutanixOvaDownloadV2Read(ctx, d, meta)
+	}
 	return ResourceNutanixOvaDownloadV2Create(ctx, d, meta)
 }
 
 func ResourceNutanixOvaDownloadV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
+
+// downloadOvaToLocalFile streams the OVA to a local file and verifies its size and checksum. An OVA with
+// another disk format is exported again from its source VM in the expected disk format and that export is
+// downloaded instead.
+func downloadOvaToLocalFile(ctx context.Context, d *schema.ResourceData, meta interface{}, ovaExtID, localFilePath string) error {
+	conn := meta.(*conns.Client).VmmAPI
+
+	resp, err := conn.OvasAPIInstance.GetOvaById(utils.StringPtr(ovaExtID))
+	if err != nil {
+		return fmt.Errorf("error reading OVA (%s): %v", ovaExtID, err)
+	}
+	ova := resp.Data.GetValue().(content.Ova)
+
+	if diskFormat, ok := d.GetOk("disk_format"); ok && flattenOvaDiskFormat(ova.DiskFormat) != diskFormat.(string) {
+		converted, err := exportOvaWithDiskFormat(ctx, d, meta, ova, diskFormat.(string))
+		if err != nil {
+			return err
+		}
+		// the partial file of a failed download is named after this export, the next apply exports the OVA
+		// again and downloads it from the start instead of resuming it with the bytes of another export
+		defer deleteConvertedOva(ctx, d, meta, utils.StringValue(converted.ExtId))
+		ova = *converted
+	}
+
+	opts := vmm.OvaDownloadOptions{
+		Attempts:  d.Get("max_attempts").(int),
+		RetryWait: vmm.DefaultOvaDownloadRetryWait,
+	}
+	if ova.SizeBytes != nil {
+		opts.SizeBytes = *ova.SizeBytes
+	}
+
+	log.Printf("[DEBUG] downloading OVA %s to %s", utils.StringValue(ova.ExtId), localFilePath)
+	size, err := conn.OvaFileAPIInstance.DownloadOvaFile(ctx, utils.StringValue(ova.ExtId), localFilePath, opts)
+	if err != nil {
+		return err
+	}
+
+	checksumType, expected := ovaChecksum(ova.Checksum)
+	checksum := ""
+	if d.Get("verify_checksum").(bool) && checksumType != "" {
+		checksum, err = vmm.FileChecksum(localFilePath, checksumType)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(checksum, expected) {
+			// a corrupted file is not kept, it would be resumed by the next download
+			os.Remove(localFilePath)
+			return fmt.Errorf("%s checksum of %s is %s, expected %s", checksumType, localFilePath, checksum, expected)
+		}
+	}
+
+	if err := d.Set("ova_file_path", localFilePath); err != nil {
+		return err
+	}
+	if err := d.Set("size_bytes", size); err != nil {
+		return err
+	}
+	return d.Set("checksum", checksum)
+}
+
+// ovaChecksum returns the checksum type (sha1 or sha256) and hex digest of an OVA, empty if it has none
+func ovaChecksum(checksum *content.OneOfOvaChecksum) (string, string) {
+	if checksum == nil || checksum.GetValue() == nil {
+		return "", ""
+	}
+	switch v := checksum.GetValue().(type) {
+	case content.OvaSha1Checksum:
+		return "sha1", utils.StringValue(v.HexDigest)
+	case content.OvaSha256Checksum:
+		return "sha256", utils.StringValue(v.HexDigest)
+	}
+	return "", ""
+}
+
+// exportOvaWithDiskFormat creates an OVA from the source VM of an OVA in another disk format. Prism Central
+// converts the disks while exporting the VM, OVAs uploaded or created from a URL can not be converted.
+func exportOvaWithDiskFormat(ctx context.Context, d *schema.ResourceData, meta interface{}, ova content.Ova, diskFormat string) (*content.Ova, error) {
+	conn := meta.(*conns.Client).VmmAPI
+
+	var vmSource *content.OvaVmSource
+	if ova.Source != nil {
+		if src, ok := ova.Source.GetValue().(content.OvaVmSource); ok {
+			vmSource = &src
+		}
+	}
+	if vmSource == nil || vmSource.VmExtId == nil {
+		return nil, fmt.Errorf("OVA %s is %s and was not created from a VM, it can not be converted to %s", utils.StringValue(ova.ExtId), flattenOvaDiskFormat(ova.DiskFormat), diskFormat)
+	}
+
+	source := content.NewOvaVmSource()
+	source.VmExtId = vmSource.VmExtId
+	source.DiskFileFormat = common.ExpandEnum[content.OvaDiskFormat](diskFormat)
+	body := content.NewOva()
+	body.Name = utils.StringPtr(fmt.Sprintf("%s-%s", utils.StringValue(ova.Name), strings.ToLower(diskFormat)))
+	body.ClusterLocationExtIds = ova.ClusterLocationExtIds
+	body.Source = content.NewOneOfOvaSource()
+	if err := body.Source.SetValue(*source); err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] exporting VM %s to a %s OVA", utils.StringValue(source.VmExtId), diskFormat)
+	resp, err := conn.OvasAPIInstance.CreateOva(body)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting OVA %s to %s: %v", utils.StringValue(ova.ExtId), diskFormat, err)
+	}
+	taskUUID := resp.Data.GetValue().(import1.TaskReference).ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	taskDetails, err := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for OVA %s to be exported to %s: %s", utils.StringValue(ova.ExtId), diskFormat, err)
+	}
+	extID, err := taskDetails.EntityExtID(utils.RelEntityTypeOVA)
+	if err != nil {
+		return nil, err
+	}
+
+	getResp, err := conn.OvasAPIInstance.GetOvaById(utils.StringPtr(extID))
+	if err != nil {
+		return nil, fmt.Errorf("error reading OVA (%s): %v", extID, err)
+	}
+	converted := getResp.Data.GetValue().(content.Ova)
+	return &converted, nil
+}
+
+// deleteConvertedOva deletes the OVA exported for a download, a failure only leaves it on Prism Central
+func deleteConvertedOva(ctx context.Context, d *schema.ResourceData, meta interface{}, extID string) {
+	conn := meta.(*conns.Client).VmmAPI
+
+	resp, err := conn.OvasAPIInstance.DeleteOvaById(utils.StringPtr(extID))
+	if err != nil {
+		log.Printf("[WARN] error deleting exported OVA %s: %v", extID, err)
+		return
+	}
+	taskUUID := resp.Data.GetValue().(import1.TaskReference).ExtId
+	if _, err := common.WaitForTask(ctx, meta.(*conns.Client).PrismAPI, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); err != nil {
+		log.Printf("[WARN] error waiting for exported OVA %s to be deleted: %v", extID, err)
+	}
+}
@@ -1,13 +1,23 @@
 package vmmv2_test
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/http"
+	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
 )
 
 const resourceNameOvaDownload = "nutanix_ova_download_v2.test"
@@ -161,3 +171,146 @@ resource "nutanix_ova_download_v2" "test" {
 
 `, filepath, vmName, vmDescription, ovaName)
 }
+
+var ovas = mockpc.Collection{Path: "vmm/content/ovas", ObjectType: "vmm.v4.content.Ova", Rel: "vmm:content:ova"}
+
+// mockOvaFile registers an OVA exported from a VM and serves its file, honoring range requests
+func mockOvaFile(t *testing.T, pc *mockpc.Server, content []byte, diskFormat string) string {
+	pc.Register(ovas)
+	sum := sha256.Sum256(content)
+	extID := pc.Add(ovas.Path, map[string]interface{}{
+		"name":       "tf-appliance",
+		"sizeBytes":  len(content),
+		"diskFormat": diskFormat,
+		"checksum":   map[string]interface{}{"$objectType": "vmm.v4.content.OvaSha256Checksum", "hexDigest": hex.EncodeToString(sum[:])},
+		"source":     map[string]interface{}{"$objectType": "vmm.v4.content.OvaVmSource", "vmExtId": "vm-1", "diskFileFormat": diskFormat},
+	})
+
+	pc.Handle(http.MethodGet, ovas.Path+"/{extId}/file", func(w http.ResponseWriter, r *http.Request) {
+		start := 0
+		status := http.StatusOK
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start); err == nil {
+			status = http.StatusPartialContent
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(status)
+		_, _ = w.Write(content[start:])
+	})
+	return extID
+}
+
+func TestUnitV2NutanixOvaDownloadResource_LocalFile(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	content := []byte(strings.Repeat("appliance disk ", 1000))
+	extID := mockOvaFile(t, pc, content, "QCOW2")
+
+	path := t.TempDir() + "/appliance.ova"
+	// left by an interrupted download
+	if err := os.WriteFile(path+"."+extID+".part", content[:100], 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	r := vmmv2.ResourceNutanixOvaDownloadV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"ova_ext_id":      extID,
+		"local_file_path": path,
+	})
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	if downloaded, _ := os.ReadFile(path); !bytes.Equal(downloaded, content) {
+		t.Errorf("downloaded %d bytes, expected the %d bytes of the OVA", len(downloaded), len(content))
+	}
+	sum := sha256.Sum256(content)
+	if d.Get("checksum") != hex.EncodeToString(sum[:]) || d.Get("size_bytes") != len(content) || d.Get("ova_file_path") != path {
+		t.Errorf("checksum = %v, size_bytes = %v, ova_file_path = %v", d.Get("checksum"), d.Get("size_bytes"), d.Get("ova_file_path"))
+	}
+	for _, req := range pc.Requests() {
+		if strings.HasSuffix(req.Path, "/file") && req.Header.Get("Range") != "bytes=100-" {
+			t.Errorf("downloaded with range %q, expected the partial file to be resumed", req.Header.Get("Range"))
+		}
+	}
+
+	// the file is downloaded again once removed
+	os.Remove(path)
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() || d.Id() != "" {
+		t.Errorf("read: %v, id = %s, expected the download to be removed from the state", diags, d.Id())
+	}
+}
+
+func TestUnitV2NutanixOvaDownloadResource_ChecksumMismatch(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	extID := mockOvaFile(t, pc, []byte("appliance"), "QCOW2")
+	ova, _ := pc.Get(ovas.Path, extID)
+	ova["checksum"] = map[string]interface{}{"$objectType": "vmm.v4.content.OvaSha1Checksum", "hexDigest": "0000000000000000000000000000000000000000"}
+	pc.Add(ovas.Path, ova)
+
+	path := t.TempDir() + "/appliance.ova"
+	r := vmmv2.ResourceNutanixOvaDownloadV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"ova_ext_id":      extID,
+		"local_file_path": path,
+	})
+	if diags := r.CreateContext(context.Background(), d, meta); !diags.HasError() {
+		t.Fatal("expected a checksum mismatch error")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("corrupted file kept: %v", err)
+	}
+}
+
+func TestUnitV2NutanixOvaDownloadResource_DiskFormat(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	extID := mockOvaFile(t, pc, []byte("appliance"), "QCOW2")
+
+	r := vmmv2.ResourceNutanixOvaDownloadV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"ova_ext_id":      extID,
+		"local_file_path": t.TempDir() + "/appliance.ova",
+		"disk_format":     "VMDK",
+	})
+	if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	var exported string
+	for _, req := range pc.Requests() {
+		switch {
+		case req.Method == http.MethodPost:
+			if !strings.Contains(string(req.Body), `"diskFileFormat":"VMDK"`) || !strings.Contains(string(req.Body), `"vm-1"`) {
+				t.Errorf("unexpected export request: %s", req.Body)
+			}
+		case strings.HasSuffix(req.Path, "/file"):
+			exported = strings.Split(strings.TrimPrefix(req.Path, ovas.Path+"/"), "/")[0]
+		}
+	}
+	if exported == "" || exported == extID {
+		t.Errorf("downloaded OVA %q, expected the VMDK export", exported)
+	}
+	if remaining := pc.List(ovas.Path); len(remaining) != 1 {
+		t.Errorf("%d OVAs left, expected the VMDK export to be deleted", len(remaining))
+	}
+}
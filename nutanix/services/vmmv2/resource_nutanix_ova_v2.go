@@ -6,17 +6,17 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	import3 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	import4 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/iam/v4/authn"
 	import2 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/content"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
 	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v4/vmm"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -133,8 +133,9 @@ func ResourceNutanixOvaV2() *schema.Resource {
 										Required: true,
 									},
 									"disk_file_format": {
-										Type:     schema.TypeString,
-										Required: true,
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: common.ValidateEnum[import1.OvaDiskFormat](),
 									},
 								},
 							},
@@ -152,6 +153,26 @@ func ResourceNutanixOvaV2() *schema.Resource {
 								},
 							},
 						},
+						"local_file_source": {
+							Type:     schema.TypeList,
+							Optional: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"path": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+									"chunk_size_bytes": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      vmm.DefaultImageUploadChunkSize,
+										ValidateFunc: validation.IntAtLeast(minImageUploadChunkSize),
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -315,7 +336,13 @@ func ResourceNutanixOvaV2Create(ctx context.Context, d *schema.ResourceData, met
 	if checksum, ok := d.GetOk("checksum"); ok {
 		body.Checksum = expandOneOfOvaChecksum(checksum)
 	}
-	if source, ok := d.GetOk("source"); ok {
+	localFile, isLocalFile := d.GetOk("source.0.local_file_source.0")
+	if isLocalFile {
+		// fail before creating the OVA if the local file does not match the expected checksum
+		if err := verifyOvaLocalFileChecksum(localFile.(map[string]interface{})["path"].(string), d.Get("checksum")); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if source, ok := d.GetOk("source"); ok {
 		body.Source = expandOneOfOvaSource(source)
 	}
 	if clsExts, ok := d.GetOk("cluster_location_ext_ids"); ok {
@@ -348,33 +375,25 @@ func ResourceNutanixOvaV2Create(ctx context.Context, d *schema.ResourceData, met
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the OVA to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for OVA (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching OVA create task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import3.Task)
-
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] OVA Create Task Details: %s", string(aJSON))
 
-	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeOVA, "OVA")
+	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeOVA, "OVA")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(utils.StringValue(uuid))
 
+	if isLocalFile {
+		if err := uploadOvaLocalFile(ctx, conn, d.Id(), localFile.(map[string]interface{}), d.Get("checksum")); err != nil {
+			return diag.Errorf("error while uploading OVA (%s) file: %v", d.Id(), err)
+		}
+	}
 	return ResourceNutanixOvaV2Read(ctx, d, meta)
 }
 
@@ -402,7 +421,7 @@ func ResourceNutanixOvaV2Read(ctx context.Context, d *schema.ResourceData, meta
 	if err := d.Set("checksum", flattenOneOfOvaChecksum(ova.Checksum)); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("size_bytes", int(*ova.SizeBytes)); err != nil {
+	if err := d.Set("size_bytes", int(utils.Int64Value(ova.SizeBytes))); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("created_by", flattenCreatedBy(ova.CreatedBy)); err != nil {
@@ -415,13 +434,15 @@ func ResourceNutanixOvaV2Read(ctx context.Context, d *schema.ResourceData, meta
 		return diag.FromErr(err)
 	}
 
-	// Set the VM config
-	fields, diags := extractVMConfigFields(*ova.VmConfig)
-	if diags.HasError() {
-		return diags
-	}
-	if err := d.Set("vm_config", []interface{}{fields}); err != nil {
-		return diag.FromErr(fmt.Errorf("failed setting vm_config: %w", err))
+	// Set the VM config, only known once the file of an uploaded OVA is processed
+	if ova.VmConfig != nil {
+		fields, diags := extractVMConfigFields(*ova.VmConfig)
+		if diags.HasError() {
+			return diags
+		}
+		if err := d.Set("vm_config", []interface{}{fields}); err != nil {
+			return diag.FromErr(fmt.Errorf("failed setting vm_config: %w", err))
+		}
 	}
 
 	if err := d.Set("disk_format", flattenOvaDiskFormat(ova.DiskFormat)); err != nil {
@@ -478,14 +499,7 @@ func ResourceNutanixOvaV2Update(ctx context.Context, d *schema.ResourceData, met
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the OVA to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 		return diag.Errorf("error waiting for OVA (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 	return ResourceNutanixOvaV2Read(ctx, d, meta)
@@ -508,14 +522,7 @@ func ResourceNutanixOvaV2Delete(ctx context.Context, d *schema.ResourceData, met
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the OVA to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 		return diag.Errorf("error waiting for OVA (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
@@ -523,6 +530,51 @@ func ResourceNutanixOvaV2Delete(ctx context.Context, d *schema.ResourceData, met
 	return nil
 }
 
+// ovaChecksumBlock returns the checksum type (sha1 or sha256) and hex digest of the checksum block, if set
+func ovaChecksumBlock(checksum interface{}) (string, string) {
+	checksums := checksum.([]interface{})
+	if len(checksums) == 0 || checksums[0] == nil {
+		return "", ""
+	}
+	val := checksums[0].(map[string]interface{})
+	for checksumType, attr := range map[string]string{"sha1": "ova_sha1_checksum", "sha256": "ova_sha256_checksum"} {
+		if digests, ok := val[attr].([]interface{}); ok && len(digests) > 0 && digests[0] != nil {
+			return checksumType, digests[0].(map[string]interface{})["hex_digest"].(string)
+		}
+	}
+	return "", ""
+}
+
+// verifyOvaLocalFileChecksum checks a local OVA file against the checksum block, if set
+func verifyOvaLocalFileChecksum(path string, checksum interface{}) error {
+	checksumType, expected := ovaChecksumBlock(checksum)
+	if checksumType == "" {
+		return nil
+	}
+
+	digest, err := vmm.FileChecksum(path, checksumType)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(digest, expected) {
+		return fmt.Errorf("%s checksum of %s is %s, expected %s", checksumType, path, digest, expected)
+	}
+	return nil
+}
+
+// uploadOvaLocalFile streams a local file to an OVA created without source
+func uploadOvaLocalFile(ctx context.Context, conn *vmm.Client, extID string, localFile map[string]interface{}, checksum interface{}) error {
+	opts := vmm.OvaUploadOptions{
+		ChunkSize: int64(localFile["chunk_size_bytes"].(int)),
+		RetryWait: vmm.DefaultImageUploadRetryWait,
+	}
+	opts.ChecksumType, opts.Checksum = ovaChecksumBlock(checksum)
+
+	path := localFile["path"].(string)
+	log.Printf("[DEBUG] uploading %s to OVA %s", path, extID)
+	return conn.OvaFileAPIInstance.UploadOvaFile(ctx, extID, path, opts)
+}
+
 func expandOneOfOvaChecksum(pr interface{}) *import1.OneOfOvaChecksum {
 	if pr != nil {
 		prI := pr.([]interface{})
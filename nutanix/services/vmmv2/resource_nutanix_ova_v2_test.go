@@ -1,14 +1,24 @@
 package vmmv2_test
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec
+	"encoding/hex"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
 	"regexp"
 	"strconv"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
 )
 
 const resourceNameOva = "nutanix_ova_v2.test"
@@ -295,3 +305,72 @@ resource "nutanix_ova_v2" "test" {
 }
 `, filepath, ovaName)
 }
+
+func TestUnitV2NutanixOvaResource_LocalFileSource(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(ovas)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	content := make([]byte, 2*1024*1024+10)
+	for i := range content {
+		content[i] = byte(i)
+	}
+	path := t.TempDir() + "/appliance.ova"
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var uploaded []byte
+	pc.Handle(http.MethodPut, ovas.Path+"/{extId}/file", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Nutanix-Checksum-Type") != "sha1" {
+			t.Errorf("checksum not sent with the upload: %v", r.Header)
+		}
+		body, _ := io.ReadAll(r.Body)
+		uploaded = append(uploaded, body...)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	r := vmmv2.ResourceNutanixOvaV2()
+	config := map[string]interface{}{
+		"name": "tf-appliance",
+		"checksum": []interface{}{map[string]interface{}{
+			"ova_sha1_checksum": []interface{}{map[string]interface{}{"hex_digest": "0000000000000000000000000000000000000000"}},
+		}},
+		"source": []interface{}{map[string]interface{}{
+			"local_file_source": []interface{}{map[string]interface{}{
+				"path":             path,
+				"chunk_size_bytes": 1024 * 1024,
+			}},
+		}},
+	}
+
+	// the OVA is not created if the local file does not match the checksum
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, meta); !diags.HasError() {
+		t.Fatal("expected a checksum mismatch error")
+	}
+	if created := pc.List(ovas.Path); len(created) != 0 {
+		t.Fatalf("OVA created despite the checksum mismatch: %v", created)
+	}
+
+	digest := sha1.Sum(content) //nolint:gosec
+	config["checksum"] = []interface{}{map[string]interface{}{
+		"ova_sha1_checksum": []interface{}{map[string]interface{}{"hex_digest": hex.EncodeToString(digest[:])}},
+	}}
+	d = schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if ova, ok := pc.Get(ovas.Path, d.Id()); !ok || ova["source"] != nil {
+		t.Errorf("unexpected OVA created: %v", ova)
+	}
+	if !bytes.Equal(uploaded, content) {
+		t.Errorf("uploaded %d bytes, expected the %d bytes of the file", len(uploaded), len(content))
+	}
+}
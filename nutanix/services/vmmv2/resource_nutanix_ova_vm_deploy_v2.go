@@ -8,10 +8,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	import4 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	import3 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	import2 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/content"
@@ -30,6 +28,9 @@ func ResourceNutanixOvaVMDeploymentV2() *schema.Resource {
 		ReadContext:   ResourceNutanixOvaVMDeploymentRead,
 		UpdateContext: ResourceNutanixOvaVMDeploymentUpdate,
 		DeleteContext: ResourceNutanixOvaVMDeploymentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importNutanixOvaVMDeploymentV2,
+		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(ovaVMDeployTimeout),
 			Update: schema.DefaultTimeout(ovaVMDeployTimeout),
@@ -504,33 +505,17 @@ func ResourceNutanixOvaVMDeploymentCreate(ctx context.Context, d *schema.Resourc
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the OVA VM to be deployed
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskResult, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for OVA VM deployment (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
 	log.Printf("[DEBUG] OVA deployment task completed successfully with UUID: %s", utils.StringValue(taskUUID))
 
-	resourceUUID, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		var errordata map[string]interface{}
-		e := json.Unmarshal([]byte(err.Error()), &errordata)
-		if e != nil {
-			return diag.FromErr(e)
-		}
-		return diag.Errorf("error while fetching vm UUID : %v", err)
-	}
-	taskResult := resourceUUID.Data.GetValue().(import4.Task)
-
 	if len(taskResult.EntitiesAffected) == 0 {
 		return diag.Errorf("no entities affected in OVA deployment task")
 	}
-	vmUUID, err := common.ExtractEntityUUIDFromTask(taskResult, utils.RelEntityTypeVM, "Virtual Machine")
+	vmUUID, err := common.ExtractEntityUUIDFromTask(taskResult.Task, utils.RelEntityTypeVM, "Virtual Machine")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -791,13 +776,7 @@ func setOvaVMConfig(d *schema.ResourceData, vm import2.Vm) diag.Diagnostics {
 func waitForTask(ctx context.Context, d *schema.ResourceData, meta interface{}, taskUUID *string, timeoutType string, operation string) diag.Diagnostics {
 	taskconn := meta.(*conns.Client).PrismAPI
 
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(timeoutType),
-	}
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(timeoutType)); errWaitTask != nil {
 		return diag.Errorf("error waiting for %s (%s): %s", operation, utils.StringValue(taskUUID), errWaitTask)
 	}
 
@@ -6,9 +6,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	import4 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/common/v1/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
@@ -26,6 +24,10 @@ func ResourceNutanixTemplateDeployV2() *schema.Resource {
 		ReadContext:   ResourceNutanixTemplateDeployV2Read,
 		UpdateContext: ResourceNutanixTemplateDeployV2Update,
 		DeleteContext: ResourceNutanixTemplateDeployV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importNutanixTemplateDeployV2,
+		},
+		CustomizeDiff: validateStructuredGuestCustomization("override_vm_config_map"),
 		Schema: map[string]*schema.Schema{
 			"ext_id": {
 				Type:     schema.TypeString,
@@ -110,28 +112,15 @@ func ResourceNutanixTemplateDeployV2Create(ctx context.Context, d *schema.Resour
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the template to be deployed
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for template deploy (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching template deploy task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
-
 	aJSON, _ := json.MarshalIndent(taskDetails, "", " ")
 	log.Printf("[DEBUG] Template Deploy Task Details: %s", string(aJSON))
 
-	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeVM, "VM")
+	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeVM, "VM")
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -564,14 +553,7 @@ func expandOneOfGuestCustomizationParamsConfig(pr interface{}) *config.OneOfGues
 			val := prI[0].(map[string]interface{})
 
 			if installType, ok := val["install_type"]; ok {
-				const two, three = 2, 3
-				subMap := map[string]interface{}{
-					"FRESH":    two,
-					"PREPARED": three,
-				}
-				pVal := subMap[installType.(string)]
-				p := config.InstallType(pVal.(int))
-				sysPrepInput.InstallType = &p
+				sysPrepInput.InstallType = common.ExpandEnum[config.InstallType](installType.(string))
 			}
 			if sysScript, ok := val["sysprep_script"]; ok {
 				sysPrepInput.SysprepScript = expandOneOfSysprepSysprepScript(sysScript)
@@ -585,17 +567,14 @@ func expandOneOfGuestCustomizationParamsConfig(pr interface{}) *config.OneOfGues
 			val := prI[0].(map[string]interface{})
 
 			if ds, ok := val["datasource_type"]; ok && len(ds.(string)) > 0 {
-				const two = 2
-				subMap := map[string]interface{}{
-					"CONFIG_DRIVE_V2": two,
-				}
-				pVal := subMap[ds.(string)]
-				p := config.CloudInitDataSourceType(pVal.(int))
-				cloud.DatasourceType = &p
+				cloud.DatasourceType = common.ExpandEnum[config.CloudInitDataSourceType](ds.(string))
 			}
 			if meta, ok := val["metadata"]; ok && len(meta.(string)) > 0 {
 				cloud.Metadata = utils.StringPtr(meta.(string))
 			}
+			if networkConfig, ok := val["network_config"]; ok {
+				cloud.Metadata = expandCloudInitScriptMetadata(utils.StringValue(cloud.Metadata), networkConfig)
+			}
 			if cloudScript, ok := val["cloud_init_script"]; ok {
 				cloud.CloudInitScript = expandOneOfCloudInitCloudInitScript(cloudScript)
 			}
@@ -632,6 +611,9 @@ func expandOneOfSysprepSysprepScript(pr interface{}) *config.OneOfSysprepSysprep
 			}
 			scripts.SetValue(*ckey)
 		}
+		if xml := expandUnattendScript(val); xml != nil {
+			scripts.SetValue(*xml)
+		}
 		return scripts
 	}
 	return nil
@@ -663,6 +645,9 @@ func expandOneOfCloudInitCloudInitScript(pr interface{}) *config.OneOfCloudInitC
 			}
 			cloudInit.SetValue(*ckey)
 		}
+		if user := expandCloudConfigScript(val); user != nil {
+			cloudInit.SetValue(*user)
+		}
 		return cloudInit
 	}
 	return nil
@@ -6,10 +6,8 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	import5 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/content"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
@@ -62,6 +60,8 @@ func ResourceNutanixTemplateActionsV2Create(ctx context.Context, d *schema.Resou
 	action := d.Get("action").(string)
 
 	var taskUUID *string
+	var taskDetails *common.TaskResult
+	var errWaitTask error
 
 	if action == "initiate" {
 		versionID := d.Get("version_id").(string)
@@ -76,14 +76,7 @@ func ResourceNutanixTemplateActionsV2Create(ctx context.Context, d *schema.Resou
 		taskUUID = TaskRef.ExtId
 
 		// Wait for the guest OS update to be initiated
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutCreate),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		if taskDetails, errWaitTask = common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 			return diag.Errorf("error waiting for guest OS update initiation (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 	}
@@ -106,14 +99,7 @@ func ResourceNutanixTemplateActionsV2Create(ctx context.Context, d *schema.Resou
 		taskUUID = TaskRef.ExtId
 
 		// Wait for the guest OS update to complete
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutCreate),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		if taskDetails, errWaitTask = common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 			return diag.Errorf("error waiting for guest OS update completion (%s) to finish: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 	}
@@ -127,24 +113,11 @@ func ResourceNutanixTemplateActionsV2Create(ctx context.Context, d *schema.Resou
 		taskUUID = TaskRef.ExtId
 
 		// Wait for the guest OS update to be cancelled
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutCreate),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		if taskDetails, errWaitTask = common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 			return diag.Errorf("error waiting for guest OS update cancellation (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching template guest OS action task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Template Guest OS Action (%s) Task Details: %s", action, string(aJSON))
 
@@ -4,12 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	prismConfig "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	vmmCommon "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/common/v1/config"
 	vmmAuthn "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/iam/v4/authn"
 	vmmProsmConfig "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
@@ -26,6 +25,7 @@ func ResourceNutanixTemplatesV2() *schema.Resource {
 		ReadContext:   ResourceNutanixTemplatesV2Read,
 		UpdateContext: ResourceNutanixTemplatesV2Update,
 		DeleteContext: ResourceNutanixTemplatesV2Delete,
+		CustomizeDiff: resourceNutanixTemplatesV2Diff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -140,6 +140,19 @@ func ResourceNutanixTemplatesV2() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"version_retention_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			// the versions created by the template resource, the latest first, only those are deleted by the retention
+			"managed_version_ext_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"tenant_id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -218,33 +231,26 @@ func ResourceNutanixTemplatesV2Create(ctx context.Context, d *schema.ResourceDat
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the template to be created
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for template (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching template create task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(prismConfig.Task)
-
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Template Create Task Details: %s", string(aJSON))
 
-	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeTemplates, "Template")
+	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails.Task, utils.RelEntityTypeTemplates, "Template")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(utils.StringValue(uuid))
-	return ResourceNutanixTemplatesV2Read(ctx, d, meta)
+
+	// all the versions of a new template are created by the resource
+	versions, err := listTemplateVersions(conn, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return applyTemplateVersionRetention(ctx, d, meta, newTemplateVersionExtIDs(versions, nil), d.Timeout(schema.TimeoutCreate))
 }
 
 func ResourceNutanixTemplatesV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -314,12 +320,31 @@ func ResourceNutanixTemplatesV2Read(ctx context.Context, d *schema.ResourceData,
 	if err := d.Set("category_ext_ids", getResp.CategoryExtIds); err != nil {
 		return diag.FromErr(err)
 	}
+	// the managed versions deleted outside of terraform are forgotten
+	if managed := common.ExpandListOfString(d.Get("managed_version_ext_ids").([]interface{})); len(managed) > 0 {
+		versions, err := listTemplateVersions(conn, d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("managed_version_ext_ids", existingTemplateVersionExtIDs(versions, managed)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return nil
 }
 
 func ResourceNutanixTemplatesV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn := meta.(*conns.Client).VmmAPI
 
+	// the planned managed_version_ext_ids are the versions kept by the retention, the versions to delete are in the state
+	oldManaged, _ := d.GetChange("managed_version_ext_ids")
+	managed := common.ExpandListOfString(oldManaged.([]interface{}))
+
+	// the retention is only applied by the provider, it does not need a template update
+	if !d.HasChangesExcept("version_retention_count", "managed_version_ext_ids") {
+		return applyTemplateVersionRetention(ctx, d, meta, managed, d.Timeout(schema.TimeoutUpdate))
+	}
+
 	readResp, err := conn.TemplatesAPIInstance.GetTemplateById(utils.StringPtr(d.Id()))
 	if err != nil {
 		return diag.Errorf("error while fetching template : %v", err)
@@ -418,6 +443,15 @@ func ResourceNutanixTemplatesV2Update(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
+	// the versions listed before the update tell apart the version created by the update
+	var versionsBefore []vmmContent.TemplateVersionSpec
+	if updateSpec.TemplateVersionSpec != nil {
+		versionsBefore, err = listTemplateVersions(conn, d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	aJSON, _ := json.MarshalIndent(updateSpec, "", "  ")
 	log.Printf("[DEBUG] Template update request body :\n %v", string(aJSON))
 
@@ -431,15 +465,53 @@ func ResourceNutanixTemplatesV2Update(ctx context.Context, d *schema.ResourceDat
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the template to be updated
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for template (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
-		return diag.Errorf("error waiting for template (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
+	if updateSpec.TemplateVersionSpec != nil {
+		versions, err := listTemplateVersions(conn, d.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		managed = append(newTemplateVersionExtIDs(versions, versionsBefore), managed...)
+	}
+	return applyTemplateVersionRetention(ctx, d, meta, managed, d.Timeout(schema.TimeoutUpdate))
+}
+
+// resourceNutanixTemplatesV2Diff plans the deletion of the managed versions over version_retention_count. A template
+// update creates a version, and the versions kept as the active version are deleted once another one is promoted
+func resourceNutanixTemplatesV2Diff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("template_version_spec") {
+		return d.SetNewComputed("managed_version_ext_ids")
+	}
+	retentionCount, ok := d.GetOk("version_retention_count")
+	if !ok {
+		return nil
+	}
+	managed := common.ExpandListOfString(d.Get("managed_version_ext_ids").([]interface{}))
+	kept := retainedTemplateVersionExtIDs(managed, d.Get("template_version_spec.0.ext_id").(string), retentionCount.(int))
+	if len(kept) < len(managed) {
+		return d.SetNew("managed_version_ext_ids", kept)
+	}
+	return nil
+}
+
+// applyTemplateVersionRetention deletes the managed versions over version_retention_count, and records the
+// versions left as the managed versions
+func applyTemplateVersionRetention(ctx context.Context, d *schema.ResourceData, meta interface{}, managed []string, timeout time.Duration) diag.Diagnostics {
+	if retentionCount, ok := d.GetOk("version_retention_count"); ok {
+		kept, err := pruneTemplateVersions(ctx, meta, d.Id(), managed, retentionCount.(int), timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		managed = kept
+	}
+	if err := d.Set("managed_version_ext_ids", managed); err != nil {
+		return diag.FromErr(err)
 	}
 	return ResourceNutanixTemplatesV2Read(ctx, d, meta)
 }
@@ -456,14 +528,7 @@ func ResourceNutanixTemplatesV2Delete(ctx context.Context, d *schema.ResourceDat
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the template to be deleted
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 		return diag.Errorf("error waiting for template (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 	return nil
@@ -1505,9 +1570,10 @@ func schemaForTemplateGuestCustomization() *schema.Schema {
 														Elem: &schema.Resource{
 															Schema: map[string]*schema.Schema{
 																"value": {
-																	Type:     schema.TypeString,
-																	Optional: true,
-																	Computed: true,
+																	Type:      schema.TypeString,
+																	Optional:  true,
+																	Computed:  true,
+																	Sensitive: true,
 																},
 															},
 														},
@@ -1549,9 +1615,10 @@ func schemaForTemplateGuestCustomization() *schema.Schema {
 														Elem: &schema.Resource{
 															Schema: map[string]*schema.Schema{
 																"value": {
-																	Type:     schema.TypeString,
-																	Optional: true,
-																	Computed: true,
+																	Type:      schema.TypeString,
+																	Optional:  true,
+																	Computed:  true,
+																	Sensitive: true,
 																},
 															},
 														},
@@ -2148,8 +2215,8 @@ func expandTemplateVMSpec(vmSpec interface{}) *vmmConfig.Vm {
 		if protectionPolicyState, ok := vmVal["protection_policy_state"]; ok {
 			vm.ProtectionPolicyState = expandProtectionPolicyState(protectionPolicyState)
 		}
-		if pcieDevices, ok := vmVal["pcie_devices"]; ok {
-			vm.PcieDevices = expandPcieDevices(pcieDevices)
+		if pcieDevices, ok := vmVal["pci_devices"]; ok {
+			vm.PcieDevices = expandPcieDevices(pcieDevices.([]interface{}))
 		}
 		return vm
 	}
@@ -2169,69 +2236,6 @@ func expandAvailabilityZone(availabilityZone interface{}) *vmmConfig.Availabilit
 	return nil
 }
 
-func expandPcieDevices(pcieDevices interface{}) []vmmConfig.PcieDevice {
-	if len(pcieDevices.([]interface{})) > 0 {
-		var pcieDevicesList []vmmConfig.PcieDevice
-
-		for _, pcieDevice := range pcieDevices.([]interface{}) {
-			pcieDeviceObj := vmmConfig.PcieDevice{}
-			pcieDeviceData := pcieDevice.(map[string]interface{})
-
-			if assignedDeviceInfo, ok := pcieDeviceData["assigned_device_info"]; ok {
-				pcieDeviceObj.AssignedDeviceInfo = expandTemplateAssignedDeviceInfo(assignedDeviceInfo)
-			}
-			if backingInfo, ok := pcieDeviceData["backing_info"]; ok {
-				pcieDeviceObj.BackingInfo = expandTemplatePcieDeviceBackingInfo(backingInfo)
-			}
-			pcieDevicesList = append(pcieDevicesList, pcieDeviceObj)
-		}
-		return pcieDevicesList
-	}
-	return nil
-}
-
-func expandTemplateAssignedDeviceInfo(assignedDeviceInfo interface{}) *vmmConfig.PcieDeviceInfo {
-	if assignedDeviceInfo != nil {
-		assignedDeviceInfoObj := &vmmConfig.PcieDeviceInfo{}
-		assignedDeviceInfoData := assignedDeviceInfo.(map[string]interface{})
-
-		if device, ok := assignedDeviceInfoData["device"]; ok {
-			deviceObj := &vmmConfig.PcieDeviceReference{}
-			deviceData := device.(map[string]interface{})
-
-			if deviceExtID := deviceData["device_ext_id"]; deviceExtID != nil {
-				deviceObj.DeviceExtId = utils.StringPtr(deviceExtID.(string))
-			}
-			assignedDeviceInfoObj.Device = deviceObj
-		}
-		return assignedDeviceInfoObj
-	}
-	return nil
-}
-
-func expandTemplatePcieDeviceBackingInfo(backingInfo interface{}) *vmmConfig.OneOfPcieDeviceBackingInfo {
-	if backingInfo != nil {
-		backingInfoObj := &vmmConfig.OneOfPcieDeviceBackingInfo{}
-		backingInfoData := backingInfo.(map[string]interface{})
-
-		if pcieDeviceReference, ok := backingInfoData["pcie_device_reference"]; ok {
-			pcieDeviceReferenceObj := &vmmConfig.PcieDeviceReference{}
-			pcieDeviceReferenceData := pcieDeviceReference.(map[string]interface{})
-
-			if deviceExtID := pcieDeviceReferenceData["device_ext_id"]; deviceExtID != nil {
-				pcieDeviceReferenceObj.DeviceExtId = utils.StringPtr(deviceExtID.(string))
-			}
-			err := backingInfoObj.SetValue(pcieDeviceReferenceObj)
-			if err != nil {
-				log.Printf("[ERROR] Error setting value for pcie_device_reference: %v", err)
-				diag.Errorf("Error setting value for pcie_device_reference: %v", err)
-				return nil
-			}
-		}
-	}
-	return nil
-}
-
 func expandTemplateVersionSpecVersionSource(versionSource interface{}) *vmmContent.OneOfTemplateVersionSpecVersionSource {
 	if len(versionSource.([]interface{})) > 0 {
 		templateVersionSpecVersionSource := &vmmContent.OneOfTemplateVersionSpecVersionSource{}
@@ -2355,6 +2359,9 @@ func expandTemplateGuestCustomizationConfig(config interface{}) *vmmConfig.OneOf
 			if metadata, ok := cloudInitData["metadata"]; ok && len(metadata.(string)) > 0 {
 				cloudInitObj.Metadata = utils.StringPtr(metadata.(string))
 			}
+			if networkConfig, ok := cloudInitData["network_config"]; ok {
+				cloudInitObj.Metadata = expandCloudInitScriptMetadata(utils.StringValue(cloudInitObj.Metadata), networkConfig)
+			}
 			if cloudInitScript, ok := cloudInitData["cloud_init_script"]; ok && len(cloudInitScript.([]interface{})) > 0 {
 				cloudInitScriptObj := vmmConfig.NewOneOfCloudInitCloudInitScript()
 				cloudInitScriptData := cloudInitScript.([]interface{})[0].(map[string]interface{})
@@ -2384,6 +2391,13 @@ func expandTemplateGuestCustomizationConfig(config interface{}) *vmmConfig.OneOf
 						return nil
 					}
 				}
+				if user := expandCloudConfigScript(cloudInitScriptData); user != nil {
+					err := cloudInitScriptObj.SetValue(*user)
+					if err != nil {
+						log.Printf("[ERROR] cloudInitScript: Error setting value for cloud config: %v", err)
+						return nil
+					}
+				}
 				cloudInitObj.CloudInitScript = cloudInitScriptObj
 			}
 
@@ -2431,6 +2445,13 @@ func expandSysprepScript(sysprepScript interface{}) *vmmConfig.OneOfSysprepSyspr
 				return nil
 			}
 		}
+		if unattendXMLObj := expandUnattendScript(sysprepScriptData); unattendXMLObj != nil {
+			err := sysprepScriptObj.SetValue(*unattendXMLObj)
+			if err != nil {
+				log.Printf("[ERROR] SysprepScript: Error setting value for unattend: %v", err)
+				return nil
+			}
+		}
 
 		return sysprepScriptObj
 	}
@@ -0,0 +1,392 @@
+package vmmv2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	vmmProsmConfig "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
+	vmmContent "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/content"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/sdks/v4/vmm"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// ResourceNutanixTemplateVersionV2 manages one version of an existing template. The version is created from a VM
+// or from another version of the template, it is promoted to the active version of the template with
+// is_active_version, and deleted with the resource. The version_retention_count of the template only deletes
+// the versions created by the template resource, not the versions managed here.
+func ResourceNutanixTemplateVersionV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixTemplateVersionV2Create,
+		ReadContext:   ResourceNutanixTemplateVersionV2Read,
+		UpdateContext: ResourceNutanixTemplateVersionV2Update,
+		DeleteContext: ResourceNutanixTemplateVersionV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importNutanixTemplateVersionV2,
+		},
+		Schema: map[string]*schema.Schema{
+			"template_ext_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"version_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"version_description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"vm_ext_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"vm_ext_id", "source_version_ext_id"},
+				DiffSuppressFunc: suppressImportedVersionSource,
+			},
+			"source_version_ext_id": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"vm_ext_id", "source_version_ext_id"},
+				DiffSuppressFunc: suppressImportedVersionSource,
+			},
+			"is_active_version": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+			},
+			"ext_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"is_gc_override_enabled": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"create_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_by": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     schemaForTemplateUser(),
+			},
+		},
+	}
+}
+
+func ResourceNutanixTemplateVersionV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+	templateExtID := d.Get("template_ext_id").(string)
+	versionName := d.Get("version_name").(string)
+
+	readResp, err := conn.TemplatesAPIInstance.GetTemplateById(utils.StringPtr(templateExtID))
+	if err != nil {
+		return diag.Errorf("error while fetching template : %v", err)
+	}
+	// Extract E-Tag Header
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	versionSpec := vmmContent.NewTemplateVersionSpec()
+	versionSpec.VersionName = utils.StringPtr(versionName)
+	if desc, ok := d.GetOk("version_description"); ok {
+		versionSpec.VersionDescription = utils.StringPtr(desc.(string))
+	}
+	// the new version becomes the active one unless is_active_version is set to false
+	if isActive, ok := d.GetOkExists("is_active_version"); ok {
+		versionSpec.IsActiveVersion = utils.BoolPtr(isActive.(bool))
+	}
+
+	versionSpec.VersionSource = vmmContent.NewOneOfTemplateVersionSpecVersionSource()
+	if vmExtID, ok := d.GetOk("vm_ext_id"); ok {
+		vmRef := vmmContent.NewTemplateVmReference()
+		vmRef.ExtId = utils.StringPtr(vmExtID.(string))
+		if err := versionSpec.VersionSource.SetValue(*vmRef); err != nil {
+			return diag.Errorf("error while setting version source : %v", err)
+		}
+	} else {
+		versionRef := vmmContent.NewTemplateVersionReference()
+		versionRef.VersionId = utils.StringPtr(d.Get("source_version_ext_id").(string))
+		versionRef.OverrideVmConfig = vmmContent.NewVmConfigOverride()
+		if err := versionSpec.VersionSource.SetValue(*versionRef); err != nil {
+			return diag.Errorf("error while setting version source : %v", err)
+		}
+	}
+
+	body := vmmContent.NewTemplate()
+	body.ExtId = utils.StringPtr(templateExtID)
+	body.TemplateVersionSpec = versionSpec
+
+	aJSON, _ := json.MarshalIndent(body, "", "  ")
+	log.Printf("[DEBUG] Template version create request body :\n %s", string(aJSON))
+
+	resp, err := conn.TemplatesAPIInstance.UpdateTemplateById(utils.StringPtr(templateExtID), body, args)
+	if err != nil {
+		return diag.Errorf("error while creating template version : %v", err)
+	}
+	TaskRef := resp.Data.GetValue().(vmmProsmConfig.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	// Wait for the template version to be created
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
+		return diag.Errorf("error waiting for template version (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	versionExtID, err := taskDetails.EntityExtID(utils.RelEntityTypeTemplateVersion)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(versionExtID)
+
+	return ResourceNutanixTemplateVersionV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixTemplateVersionV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	resp, err := conn.TemplatesAPIInstance.GetTemplateVersionById(utils.StringPtr(d.Get("template_ext_id").(string)), utils.StringPtr(d.Id()))
+	if err != nil {
+		if isVmmNotFoundErr(err) {
+			log.Printf("[WARN] template version %s not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error while fetching template version : %v", err)
+	}
+	version := resp.Data.GetValue().(vmmContent.TemplateVersionSpec)
+
+	if err := d.Set("ext_id", version.ExtId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version_name", version.VersionName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version_description", version.VersionDescription); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_active_version", utils.BoolValue(version.IsActiveVersion)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_gc_override_enabled", version.IsGcOverrideEnabled); err != nil {
+		return diag.FromErr(err)
+	}
+	if version.CreateTime != nil {
+		if err := d.Set("create_time", version.CreateTime.String()); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("created_by", flattenTemplateUser(version.CreatedBy)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func ResourceNutanixTemplateVersionV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !d.HasChange("is_active_version") {
+		return ResourceNutanixTemplateVersionV2Read(ctx, d, meta)
+	}
+
+	conn := meta.(*conns.Client).VmmAPI
+	templateExtID := d.Get("template_ext_id").(string)
+
+	if !d.Get("is_active_version").(bool) {
+		// a version stops being active when another one is promoted, which may already be done
+		resp, err := conn.TemplatesAPIInstance.GetTemplateVersionById(utils.StringPtr(templateExtID), utils.StringPtr(d.Id()))
+		if err != nil {
+			return diag.Errorf("error while fetching template version : %v", err)
+		}
+		if utils.BoolValue(resp.Data.GetValue().(vmmContent.TemplateVersionSpec).IsActiveVersion) {
+			return diag.Errorf("template version %s is the active version of template %s, promote another version to deactivate it", d.Id(), templateExtID)
+		}
+		return ResourceNutanixTemplateVersionV2Read(ctx, d, meta)
+	}
+
+	if err := publishTemplateVersion(ctx, meta, templateExtID, d.Id(), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+	return ResourceNutanixTemplateVersionV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixTemplateVersionV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := deleteTemplateVersion(ctx, meta, d.Get("template_ext_id").(string), d.Id(), d.Timeout(schema.TimeoutDelete)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// suppressImportedVersionSource ignores the source of an imported version, which has none in its state
+// since the source is not returned by the API
+func suppressImportedVersionSource(k, old, new string, d *schema.ResourceData) bool {
+	oldVMExtID, _ := d.GetChange("vm_ext_id")
+	oldSourceVersionExtID, _ := d.GetChange("source_version_ext_id")
+	return d.Id() != "" && oldVMExtID.(string) == "" && oldSourceVersionExtID.(string) == ""
+}
+
+// importNutanixTemplateVersionV2 imports a template version by <template_ext_id>/<version_ext_id>
+func importNutanixTemplateVersionV2(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts, err := splitImportID(d.Id(), "<template_ext_id>/<version_ext_id>")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("template_ext_id", parts[0]); err != nil {
+		return nil, err
+	}
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// listTemplateVersions returns all the versions of a template, the latest first
+func listTemplateVersions(conn *vmm.Client, templateExtID string) ([]vmmContent.TemplateVersionSpec, error) {
+	resp, err := common.ListAllPages(true, nil, nil, func(page, limit *int) (*vmmContent.ListTemplateVersionsApiResponse, error) {
+		return conn.TemplatesAPIInstance.ListTemplateVersions(utils.StringPtr(templateExtID), page, limit, nil, nil, nil)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error while fetching template versions : %v", err)
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+	versions := resp.Data.GetValue().([]vmmContent.TemplateVersionSpec)
+	sort.SliceStable(versions, func(i, j int) bool {
+		if versions[i].CreateTime == nil || versions[j].CreateTime == nil {
+			return versions[j].CreateTime == nil && versions[i].CreateTime != nil
+		}
+		return versions[i].CreateTime.After(*versions[j].CreateTime)
+	})
+	return versions, nil
+}
+
+// publishTemplateVersion makes the version the active version of the template
+func publishTemplateVersion(ctx context.Context, meta interface{}, templateExtID, versionExtID string, timeout time.Duration) error {
+	conn := meta.(*conns.Client).VmmAPI
+
+	readResp, err := conn.TemplatesAPIInstance.GetTemplateById(utils.StringPtr(templateExtID))
+	if err != nil {
+		return fmt.Errorf("error while fetching template : %v", err)
+	}
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	body := vmmContent.NewTemplatePublishSpec()
+	body.VersionId = utils.StringPtr(versionExtID)
+
+	resp, err := conn.TemplatesAPIInstance.PublishTemplate(utils.StringPtr(templateExtID), body, args)
+	if err != nil {
+		return fmt.Errorf("error while promoting template version %s : %v", versionExtID, err)
+	}
+	TaskRef := resp.Data.GetValue().(vmmProsmConfig.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	// Wait for the template version to be promoted
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), timeout); errWaitTask != nil {
+		return fmt.Errorf("error waiting for template version (%s) to be promoted: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func deleteTemplateVersion(ctx context.Context, meta interface{}, templateExtID, versionExtID string, timeout time.Duration) error {
+	conn := meta.(*conns.Client).VmmAPI
+
+	resp, err := conn.TemplatesAPIInstance.DeleteTemplateVersionById(utils.StringPtr(templateExtID), utils.StringPtr(versionExtID))
+	if err != nil {
+		return fmt.Errorf("error while deleting template version %s : %v", versionExtID, err)
+	}
+	TaskRef := resp.Data.GetValue().(vmmProsmConfig.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	// Wait for the template version to be deleted
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), timeout); errWaitTask != nil {
+		return fmt.Errorf("error waiting for template version (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+// pruneTemplateVersions deletes the managed versions of the template older than the last retentionCount ones,
+// and returns the managed versions left. The active version is always kept.
+func pruneTemplateVersions(ctx context.Context, meta interface{}, templateExtID string, managed []string, retentionCount int, timeout time.Duration) ([]string, error) {
+	conn := meta.(*conns.Client).VmmAPI
+
+	versions, err := listTemplateVersions(conn, templateExtID)
+	if err != nil {
+		return nil, err
+	}
+	managed = existingTemplateVersionExtIDs(versions, managed)
+	activeExtID := ""
+	for _, version := range versions {
+		if utils.BoolValue(version.IsActiveVersion) {
+			activeExtID = utils.StringValue(version.ExtId)
+		}
+	}
+
+	kept := retainedTemplateVersionExtIDs(managed, activeExtID, retentionCount)
+	for i, versionExtID := range managed {
+		if i < retentionCount || versionExtID == activeExtID {
+			continue
+		}
+		log.Printf("[DEBUG] deleting version %s of template %s, over the retention of %d versions", versionExtID, templateExtID, retentionCount)
+		if err := deleteTemplateVersion(ctx, meta, templateExtID, versionExtID, timeout); err != nil {
+			return nil, err
+		}
+	}
+	return kept, nil
+}
+
+// retainedTemplateVersionExtIDs returns the managed versions kept by the retention, the latest retentionCount
+// ones and the active version, which is only ever kept in addition to them
+func retainedTemplateVersionExtIDs(managed []string, activeExtID string, retentionCount int) []string {
+	kept := make([]string, 0, len(managed))
+	for i, versionExtID := range managed {
+		if i < retentionCount || versionExtID == activeExtID {
+			kept = append(kept, versionExtID)
+		}
+	}
+	return kept
+}
+
+// existingTemplateVersionExtIDs returns the managed versions still in the versions of the template, in the
+// order of the versions, the latest first
+func existingTemplateVersionExtIDs(versions []vmmContent.TemplateVersionSpec, managed []string) []string {
+	isManaged := make(map[string]bool, len(managed))
+	for _, versionExtID := range managed {
+		isManaged[versionExtID] = true
+	}
+	existing := make([]string, 0, len(managed))
+	for _, version := range versions {
+		if versionExtID := utils.StringValue(version.ExtId); isManaged[versionExtID] {
+			existing = append(existing, versionExtID)
+		}
+	}
+	return existing
+}
+
+// newTemplateVersionExtIDs returns the versions which are not in the versions listed before, the latest first
+func newTemplateVersionExtIDs(versions, before []vmmContent.TemplateVersionSpec) []string {
+	known := make(map[string]bool, len(before))
+	for _, version := range before {
+		known[utils.StringValue(version.ExtId)] = true
+	}
+	created := make([]string, 0)
+	for _, version := range versions {
+		if versionExtID := utils.StringValue(version.ExtId); !known[versionExtID] {
+			created = append(created, versionExtID)
+		}
+	}
+	return created
+}
@@ -0,0 +1,371 @@
+package vmmv2_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
+)
+
+const templatesPath = "vmm/content/templates"
+
+// mockTemplateVersions registers the versions of a template on the fake Prism Central, with the template
+// update creating a version and the publish action promoting one. It returns the versions collection.
+func mockTemplateVersions(t *testing.T, pc *mockpc.Server, versionNames ...string) (string, mockpc.Collection) {
+	templateExtID := pc.Add(templatesPath, map[string]interface{}{
+		"templateName":        "tf-template",
+		"templateVersionSpec": map[string]interface{}{"extId": versionNames[0], "versionName": versionNames[0], "isActiveVersion": true},
+	})
+	versions := mockpc.Collection{
+		Path:       templatesPath + "/" + templateExtID + "/versions",
+		ObjectType: "vmm.v4.content.TemplateVersionSpec",
+		Rel:        "vmm:content:template-version",
+	}
+	pc.Register(versions)
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	addVersion := func(version map[string]interface{}) {
+		created = created.Add(time.Hour)
+		version["createTime"] = created.Format(time.RFC3339)
+		if version["isActiveVersion"] == true {
+			for _, v := range pc.List(versions.Path) {
+				v["isActiveVersion"] = false
+				pc.Add(versions.Path, v)
+			}
+		}
+		pc.Add(versions.Path, version)
+	}
+	for i, name := range versionNames {
+		addVersion(map[string]interface{}{"extId": name, "versionName": name, "isActiveVersion": i == 0})
+	}
+
+	pc.Handle(http.MethodPut, templatesPath+"/{extId}", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			TemplateVersionSpec map[string]interface{} `json:"templateVersionSpec"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.TemplateVersionSpec == nil {
+			t.Errorf("template update without a version: %v", err)
+		}
+		version := body.TemplateVersionSpec
+		version["extId"] = "version-" + version["versionName"].(string)
+		delete(version, "versionSource")
+		addVersion(version)
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("update", versions, version["extId"].(string)))
+	})
+	pc.Handle(http.MethodPost, templatesPath+"/{extId}/$actions/publish", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") == "" {
+			t.Error("publish sent without If-Match")
+		}
+		var body struct {
+			VersionID string `json:"versionId"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		version, _ := pc.Get(versions.Path, body.VersionID)
+		version["isActiveVersion"] = true
+		addVersion(version)
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("publish", mockpc.DefaultCollections[2], templateExtID))
+	})
+	return templateExtID, versions
+}
+
+func TestUnitV2NutanixTemplateVersionResource_PromoteAndRollback(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	templateExtID, versions := mockTemplateVersions(t, pc, "v1")
+
+	r := vmmv2.ResourceNutanixTemplateVersionV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"template_ext_id":     templateExtID,
+		"version_name":        "v2",
+		"version_description": "golden image v2",
+		"vm_ext_id":           "vm-1",
+	})
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "version-v2" || d.Get("is_active_version") != true {
+		t.Errorf("id = %s, is_active_version = %v, expected the new active version", d.Id(), d.Get("is_active_version"))
+	}
+	for _, req := range pc.Requests() {
+		if req.Method == http.MethodPut && !strings.Contains(string(req.Body), `"vm-1"`) {
+			t.Errorf("version created without the source vm: %s", req.Body)
+		}
+	}
+
+	// rollback: the previous version is imported and promoted
+	v1 := r.Data(nil)
+	v1.SetId(templateExtID + "/v1")
+	imported, err := r.Importer.StateContext(ctx, v1, meta)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	v1 = imported[0]
+	if diags := r.ReadContext(ctx, v1, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v1.Get("is_active_version") != false {
+		t.Fatalf("v1 is_active_version = %v, expected v2 to be the active version", v1.Get("is_active_version"))
+	}
+
+	diff, err := r.Diff(ctx, v1.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"template_ext_id":       templateExtID,
+		"version_name":          "v1",
+		"source_version_ext_id": "v0",
+		"is_active_version":     true,
+	}), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Fatalf("promoting a version should not replace it: %v", diff)
+	}
+	v1, err = schema.InternalMap(r.Schema).Data(v1.State(), diff)
+	if err != nil {
+		t.Fatalf("data: %v", err)
+	}
+	if diags := r.UpdateContext(ctx, v1, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if v1.Get("is_active_version") != true {
+		t.Errorf("v1 is_active_version = %v, expected v1 to be promoted", v1.Get("is_active_version"))
+	}
+	if v2, _ := pc.Get(versions.Path, "version-v2"); v2["isActiveVersion"] != false {
+		t.Errorf("v2 isActiveVersion = %v, expected v2 to be deactivated", v2["isActiveVersion"])
+	}
+
+	if diags := r.DeleteContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if _, ok := pc.Get(versions.Path, "version-v2"); ok {
+		t.Error("v2 not deleted")
+	}
+}
+
+func TestUnitV2NutanixTemplateResource_VersionRetention(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	// v1 is the oldest version, and the active one
+	templateExtID, versions := mockTemplateVersions(t, pc, "v1", "v2", "v3", "v4")
+
+	r := vmmv2.ResourceNutanixTemplatesV2()
+	cfg := map[string]interface{}{
+		"template_name": "tf-template",
+		"template_version_spec": []interface{}{map[string]interface{}{
+			"version_source": []interface{}{map[string]interface{}{
+				"template_vm_reference": []interface{}{map[string]interface{}{"ext_id": "vm-1"}},
+			}},
+		}},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	d.SetId(templateExtID)
+	if err := d.Set("managed_version_ext_ids", []string{"v4", "v3", "v2", "v1"}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	// a version managed by nutanix_template_version_v2 is never deleted by the retention of the template
+	v5 := schema.TestResourceDataRaw(t, vmmv2.ResourceNutanixTemplateVersionV2().Schema, map[string]interface{}{
+		"template_ext_id":   templateExtID,
+		"version_name":      "v5",
+		"vm_ext_id":         "vm-1",
+		"is_active_version": false,
+	})
+	if diags := vmmv2.ResourceNutanixTemplateVersionV2().CreateContext(ctx, v5, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	sent := len(pc.Requests())
+
+	applyRetention := func(expected string) {
+		t.Helper()
+		diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(cfg), meta)
+		if err != nil {
+			t.Fatalf("diff: %v", err)
+		}
+		if diff == nil || diff.Empty() {
+			t.Fatal("no diff, expected the versions over the retention to be deleted")
+		}
+		d, err = schema.InternalMap(r.Schema).Data(d.State(), diff)
+		if err != nil {
+			t.Fatalf("data: %v", err)
+		}
+		if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+			t.Fatalf("update: %v", diags)
+		}
+
+		var kept []string
+		for _, v := range pc.List(versions.Path) {
+			kept = append(kept, v["extId"].(string))
+		}
+		if strings.Join(kept, ",") != expected {
+			t.Errorf("versions = %v, expected %s", kept, expected)
+		}
+	}
+
+	cfg["version_retention_count"] = 2
+	applyRetention("v1,v3,v4,version-v5")
+	if managed := d.Get("managed_version_ext_ids"); len(managed.([]interface{})) != 3 {
+		t.Errorf("managed_version_ext_ids = %v, expected the 2 latest versions and the active one", managed)
+	}
+
+	// v1 was only kept as the active version, it is deleted once another version is promoted
+	for _, v := range pc.List(versions.Path) {
+		v["isActiveVersion"] = v["extId"] == "version-v5"
+		pc.Add(versions.Path, v)
+	}
+	template, _ := pc.Get(templatesPath, templateExtID)
+	template["templateVersionSpec"] = map[string]interface{}{"extId": "version-v5", "versionName": "v5", "isActiveVersion": true}
+	pc.Add(templatesPath, template)
+	if diags := r.ReadContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	applyRetention("v3,v4,version-v5")
+	if diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(cfg), meta); err != nil || (diff != nil && !diff.Empty()) {
+		t.Errorf("unexpected diff once the retention is applied: %v, %v", diff, err)
+	}
+
+	for _, req := range pc.Requests()[sent:] {
+		if req.Method == http.MethodPut {
+			t.Errorf("template updated for a retention change: %s", req.Body)
+		}
+	}
+}
+
+func TestUnitV2NutanixTemplateResource_PciDevices(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	templateExtID, _ := mockTemplateVersions(t, pc, "v1")
+
+	r := vmmv2.ResourceNutanixTemplatesV2()
+	versionSpec := map[string]interface{}{
+		"version_source": []interface{}{map[string]interface{}{
+			"template_vm_reference": []interface{}{map[string]interface{}{"ext_id": "vm-1"}},
+		}},
+	}
+	cfg := map[string]interface{}{
+		"template_name":         "tf-template",
+		"template_version_spec": []interface{}{versionSpec},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	d.SetId(templateExtID)
+
+	// the new version attaches a pcie device to the VMs deployed from the template
+	versionSpec["version_name"] = "v2"
+	versionSpec["version_description"] = "with fpga"
+	versionSpec["vm_spec"] = []interface{}{map[string]interface{}{
+		"pci_devices": []interface{}{map[string]interface{}{
+			"backing_info": []interface{}{map[string]interface{}{
+				"pcie_device_reference": []interface{}{map[string]interface{}{"device_ext_id": "fpga-1"}},
+			}},
+		}},
+	}}
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(d.State(), diff)
+	if err != nil {
+		t.Fatalf("data: %v", err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+
+	updated := false
+	for _, req := range pc.Requests() {
+		if req.Method == http.MethodPut {
+			updated = true
+			if !strings.Contains(string(req.Body), `"pcieDevices":[{"$objectType":"vmm.v4.ahv.config.PcieDevice"`) ||
+				!strings.Contains(string(req.Body), `"deviceExtId":"fpga-1"`) {
+				t.Errorf("template updated without the pcie device: %s", req.Body)
+			}
+		}
+	}
+	if !updated {
+		t.Error("template not updated")
+	}
+}
+
+func TestUnitV2NutanixTemplateVersionResource_NewerVersion(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+	templateExtID, _ := mockTemplateVersions(t, pc, "v1")
+
+	r := vmmv2.ResourceNutanixTemplateVersionV2()
+	cfg := map[string]interface{}{
+		"template_ext_id": templateExtID,
+		"version_name":    "v2",
+		"vm_ext_id":       "vm-1",
+	}
+	v2 := schema.TestResourceDataRaw(t, r.Schema, cfg)
+	if diags := r.CreateContext(ctx, v2, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	v3 := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"template_ext_id": templateExtID,
+		"version_name":    "v3",
+		"vm_ext_id":       "vm-1",
+	})
+	if diags := r.CreateContext(ctx, v3, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	// v2 is no longer the active version, which is not a drift when is_active_version is not set
+	if diags := r.ReadContext(ctx, v2, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v2.Get("is_active_version") != false {
+		t.Fatalf("v2 is_active_version = %v, expected v3 to be the active version", v2.Get("is_active_version"))
+	}
+	diff, err := r.Diff(ctx, v2.State(), terraform.NewResourceConfigRaw(cfg), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("unexpected diff once a newer version is active: %v", diff)
+	}
+
+	// a version deleted outside of terraform is removed from the state
+	if diags := r.DeleteContext(ctx, v3, meta); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	v3.SetId("version-v3")
+	if diags := r.ReadContext(ctx, v3, meta); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v3.Id() != "" {
+		t.Errorf("id = %s, expected the deleted version to be removed from the state", v3.Id())
+	}
+}
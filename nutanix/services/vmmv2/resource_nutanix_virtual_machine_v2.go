@@ -6,13 +6,15 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
@@ -26,12 +28,23 @@ const (
 	delay   = 3 * time.Second
 )
 
+// powerCycleAttributes are the attributes which can not be changed while the VM is running
+var powerCycleAttributes = []string{
+	"num_sockets", "num_cores_per_socket", "memory_size_bytes", "num_threads_per_core", "cd_rom", "num_numa_nodes",
+	"is_cpu_passthrough_enabled", "enabled_cpu_features", "is_vcpu_hard_pinning_enabled", "guest_customization",
+	"guest_tools", "serial_ports", "gpus", "pcie_devices", "boot_config",
+}
+
 func ResourceNutanixVirtualMachineV2() *schema.Resource {
 	r := &schema.Resource{
 		CreateContext: ResourceNutanixVirtualMachineV2Create,
 		ReadContext:   ResourceNutanixVirtualMachineV2Read,
 		UpdateContext: ResourceNutanixVirtualMachineV2Update,
 		DeleteContext: ResourceNutanixVirtualMachineV2Delete,
+		CustomizeDiff: customdiff.All(
+			resourceNutanixVirtualMachineV2Diff,
+			validateStructuredGuestCustomization("guest_customization"),
+		),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -60,7 +73,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice([]string{"VM", "VM_RECOVERY_POINT"}, false),
+							ValidateFunc: common.ValidateEnum[config.VmSourceReferenceEntityType](),
 						},
 						"ext_id": {
 							Type:     schema.TypeString,
@@ -113,7 +126,8 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 				Optional: true,
 				Computed: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: common.ValidateEnum[config.CpuFeature](),
 				},
 			},
 			"is_memory_overcommit_enabled": {
@@ -266,7 +280,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 							Computed: true,
 							Elem: &schema.Schema{
 								Type:         schema.TypeString,
-								ValidateFunc: validation.StringInSlice([]string{"SELF_SERVICE_RESTORE", "VSS_SNAPSHOT"}, false),
+								ValidateFunc: common.ValidateEnum[config.NgtCapability](),
 							},
 						},
 						"version": {
@@ -348,7 +362,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 																			Type:         schema.TypeString,
 																			Optional:     true,
 																			Computed:     true,
-																			ValidateFunc: validation.StringInSlice([]string{"SCSI", "SPAPR", "PCI", "IDE", "SATA"}, false),
+																			ValidateFunc: common.ValidateEnum[config.DiskBusType](),
 																		},
 																		"index": {
 																			Type:     schema.TypeInt,
@@ -383,7 +397,8 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 										Optional: true,
 										Computed: true,
 										Elem: &schema.Schema{
-											Type: schema.TypeString,
+											Type:         schema.TypeString,
+											ValidateFunc: common.ValidateEnum[config.BootDeviceType](),
 										},
 									},
 								},
@@ -493,13 +508,10 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 																									Elem: &schema.Resource{
 																										Schema: map[string]*schema.Schema{
 																											"bus_type": {
-																												Type:     schema.TypeString,
-																												Optional: true,
-																												Computed: true,
-																												ValidateFunc: validation.StringInSlice([]string{
-																													"SCSI", "SPAPR", "PCI",
-																													"IDE", "SATA",
-																												}, false),
+																												Type:         schema.TypeString,
+																												Optional:     true,
+																												Computed:     true,
+																												ValidateFunc: common.ValidateEnum[config.DiskBusType](),
 																											},
 																											"index": {
 																												Type:     schema.TypeInt,
@@ -564,7 +576,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 																			Type:         schema.TypeString,
 																			Optional:     true,
 																			Computed:     true,
-																			ValidateFunc: validation.StringInSlice([]string{"SCSI", "SPAPR", "PCI", "IDE", "SATA"}, false),
+																			ValidateFunc: common.ValidateEnum[config.DiskBusType](),
 																		},
 																		"index": {
 																			Type:     schema.TypeInt,
@@ -600,7 +612,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 										Computed: true,
 										Elem: &schema.Schema{
 											Type:         schema.TypeString,
-											ValidateFunc: validation.StringInSlice([]string{"CDROM", "DISK", "NETWORK"}, false),
+											ValidateFunc: common.ValidateEnum[config.BootDeviceType](),
 										},
 									},
 								},
@@ -618,14 +630,16 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
-				ValidateFunc: validation.StringInSlice([]string{"PSERIES", "Q35", "PC"}, false),
+				ValidateFunc: common.ValidateEnum[config.MachineType](),
 			},
 			"power_state": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Default:      "ON",
-				ValidateFunc: validation.StringInSlice([]string{"ON", "OFF", "PAUSED", "UNDETERMINED"}, false),
+				ValidateFunc: common.ValidateEnum[config.PowerState](),
 			},
+			"power_cycle_policy":          common.PowerCyclePolicySchema(),
+			"pending_power_cycle_reasons": common.PendingPowerCycleReasonsSchema(),
 			"vtpm_config": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -727,13 +741,10 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"bus_type": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Computed: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											"SCSI", "SPAPR", "PCI",
-											"IDE", "SATA",
-										}, false),
+										Type:         schema.TypeString,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: common.ValidateEnum[config.DiskBusType](),
 									},
 									"index": {
 										Type:     schema.TypeInt,
@@ -836,13 +847,10 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 																						Elem: &schema.Resource{
 																							Schema: map[string]*schema.Schema{
 																								"bus_type": {
-																									Type:     schema.TypeString,
-																									Optional: true,
-																									Computed: true,
-																									ValidateFunc: validation.StringInSlice([]string{
-																										"SCSI", "SPAPR", "PCI",
-																										"IDE", "SATA",
-																									}, false),
+																									Type:         schema.TypeString,
+																									Optional:     true,
+																									Computed:     true,
+																									ValidateFunc: common.ValidateEnum[config.DiskBusType](),
 																								},
 																								"index": {
 																									Type:     schema.TypeInt,
@@ -922,7 +930,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice([]string{"IDE", "SATA"}, false),
+										ValidateFunc: common.ValidateEnum[config.CdRomBusType](),
 									},
 									"index": {
 										Type:     schema.TypeInt,
@@ -1019,13 +1027,10 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 																			Elem: &schema.Resource{
 																				Schema: map[string]*schema.Schema{
 																					"bus_type": {
-																						Type:     schema.TypeString,
-																						Optional: true,
-																						Computed: true,
-																						ValidateFunc: validation.StringInSlice([]string{
-																							"SCSI", "SPAPR", "PCI",
-																							"IDE", "SATA",
-																						}, false),
+																						Type:         schema.TypeString,
+																						Optional:     true,
+																						Computed:     true,
+																						ValidateFunc: common.ValidateEnum[config.DiskBusType](),
 																					},
 																					"index": {
 																						Type:     schema.TypeInt,
@@ -1069,7 +1074,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice([]string{"OTHER", "GUEST_TOOLS", "GUEST_CUSTOMIZATION"}, false),
+							ValidateFunc: common.ValidateEnum[config.IsoType](),
 						},
 					},
 				},
@@ -1097,7 +1102,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice([]string{"PASSTHROUGH_GRAPHICS", "PASSTHROUGH_COMPUTE", "VIRTUAL"}, false),
+							ValidateFunc: common.ValidateEnum[config.GpuMode](),
 						},
 						"device_id": {
 							Type:     schema.TypeInt,
@@ -1108,7 +1113,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice([]string{"NVIDIA", "AMD", "INTEL"}, false),
+							ValidateFunc: common.ValidateEnum[config.GpuVendor](),
 						},
 						// not present in api reference doc
 						"pci_address": {
@@ -1163,6 +1168,65 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 					},
 				},
 			},
+			"pcie_devices": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ext_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tenant_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"links": schemaForLinks(),
+						"backing_info": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"pcie_device_reference": {
+										Type:     schema.TypeList,
+										Required: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"device_ext_id": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						"assigned_device_info": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"device": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"device_ext_id": {
+													Type:     schema.TypeString,
+													Computed: true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			"serial_ports": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -1190,7 +1254,7 @@ func ResourceNutanixVirtualMachineV2() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Computed:     true,
-				ValidateFunc: validation.StringInSlice([]string{"PD_PROTECTED", "UNPROTECTED", "RULE_PROTECTED"}, false),
+				ValidateFunc: common.ValidateEnum[config.ProtectionType](),
 			},
 			"protection_policy_state": {
 				Type:     schema.TypeList,
@@ -1262,7 +1326,7 @@ func schemaForGuestCustomization() *schema.Schema {
 											Type:         schema.TypeString,
 											Optional:     true,
 											Computed:     true,
-											ValidateFunc: validation.StringInSlice([]string{"PREPARED", "FRESH"}, false),
+											ValidateFunc: common.ValidateEnum[config.InstallType](),
 										},
 										"sysprep_script": {
 											Type:     schema.TypeList,
@@ -1270,6 +1334,7 @@ func schemaForGuestCustomization() *schema.Schema {
 											Computed: true,
 											Elem: &schema.Resource{
 												Schema: map[string]*schema.Schema{
+													"unattend": schemaForSysprepUnattend(),
 													"unattend_xml": {
 														Type:     schema.TypeList,
 														Optional: true,
@@ -1279,8 +1344,9 @@ func schemaForGuestCustomization() *schema.Schema {
 																// this value is required but not present in API reference
 																// the create vm request fails if this is not provided or is empty
 																"value": {
-																	Type:     schema.TypeString,
-																	Required: true,
+																	Type:      schema.TypeString,
+																	Required:  true,
+																	Sensitive: true,
 																},
 															},
 														},
@@ -1299,21 +1365,24 @@ func schemaForGuestCustomization() *schema.Schema {
 								Elem: &schema.Resource{
 									Schema: map[string]*schema.Schema{
 										"datasource_type": {
-											Type:     schema.TypeString,
-											Optional: true,
-											Default:  "CONFIG_DRIVE_V2",
+											Type:         schema.TypeString,
+											Optional:     true,
+											Default:      "CONFIG_DRIVE_V2",
+											ValidateFunc: common.ValidateEnum[config.CloudInitDataSourceType](),
 										},
 										"metadata": {
 											Type:     schema.TypeString,
 											Optional: true,
 											Computed: true,
 										},
+										"network_config": schemaForCloudInitNetworkConfig(),
 										"cloud_init_script": {
 											Type:     schema.TypeList,
 											Optional: true,
 											Computed: true,
 											Elem: &schema.Resource{
 												Schema: map[string]*schema.Schema{
+													"cloud_config": schemaForCloudConfig(),
 													"user_data": {
 														Type:     schema.TypeList,
 														Optional: true,
@@ -1321,9 +1390,10 @@ func schemaForGuestCustomization() *schema.Schema {
 														Elem: &schema.Resource{
 															Schema: map[string]*schema.Schema{
 																"value": {
-																	Type:     schema.TypeString,
-																	Optional: true,
-																	Computed: true,
+																	Type:      schema.TypeString,
+																	Optional:  true,
+																	Computed:  true,
+																	Sensitive: true,
 																},
 															},
 														},
@@ -1365,32 +1435,20 @@ func ResourceNutanixVirtualMachineV2Create(ctx context.Context, d *schema.Resour
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the task to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for virtual Machine (%s) to create: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching VM UUID : %v", err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
-
 	aJSON, _ = json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Virtual Machine Create Task Details: %s", string(aJSON))
 
-	uuid, err := common.ExtractEntityUUIDFromTask(taskDetails, utils.RelEntityTypeVM, "Virtual Machine")
+	vmExtID, err := taskDetails.EntityExtID(utils.RelEntityTypeVM)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(utils.StringValue(uuid))
+	uuid := utils.StringPtr(vmExtID)
+	d.SetId(vmExtID)
 
 	var PowerTaskRef import1.TaskReference
 	if powerState, ok := d.GetOk("power_state"); ok {
@@ -1409,14 +1467,7 @@ func ResourceNutanixVirtualMachineV2Create(ctx context.Context, d *schema.Resour
 	powertaskUUID := PowerTaskRef.ExtId
 
 	// Wait for the task to complete
-	powerStateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(powertaskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-
-	if _, errWaitTask := powerStateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(powertaskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 		return diag.Errorf("error waiting for vm (%s) to power ON: %s", utils.StringValue(uuid), errWaitTask)
 	}
 
@@ -1476,9 +1527,29 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 	// respImages := resp.Data.GetValue().(config.Vm)
 	// updateSpec := respImages
 
-	if checkForHotPlugChanges(d) && !isVMPowerOff(d, conn) {
+	powerCycle := checkForHotPlugChanges(d) && !isVMPowerOff(d, conn)
+	policy := d.Get("power_cycle_policy").(string)
+	if powerCycle && policy == common.PowerCyclePolicyDeny {
+		return diag.Errorf("vm %s must be powered off to change %s, which power_cycle_policy %q does not allow",
+			d.Id(), strings.Join(common.PowerCycleReasons(d.HasChange, powerCycleAttributes), ", "), policy)
+	}
+
+	// host and cluster changes are live migrations of the VM, done before any power off
+	hostMigrated, diags := migrateVirtualMachineV2(ctx, conn, d, meta)
+	if diags.HasError() {
+		return diags
+	}
+
+	if powerCycle {
+		if policy == common.PowerCyclePolicyGuestShutdownThenAllow {
+			if diags := callForGuestShutdownVM(ctx, conn, d, meta); diags.HasError() {
+				log.Printf("[WARN] guest shutdown of vm %s failed, powering it off: %v", d.Id(), diags[0].Summary)
+			}
+		}
 		log.Printf("[DEBUG] callingForPowerOffVM func")
-		callForPowerOffVM(ctx, conn, d, meta)
+		if diags := callForPowerOffVM(ctx, conn, d, meta); diags.HasError() {
+			return diags
+		}
 	}
 
 	updatedVMResp, _ := conn.VMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
@@ -1561,14 +1632,10 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 		updateSpec.OwnershipInfo = expandOwnershipInfo(d.Get("ownership_info"))
 		checkForUpdateParams = true
 	}
-	if d.HasChange("host") {
+	if d.HasChange("host") && !hostMigrated {
 		updateSpec.Host = expandHostReference(d.Get("host"))
 		checkForUpdateParams = true
 	}
-	if d.HasChange("cluster") {
-		updateSpec.Cluster = expandClusterReference(d.Get("cluster"))
-		checkForUpdateParams = true
-	}
 	if d.HasChange("guest_customization") {
 		updateSpec.GuestCustomization = expandTemplateGuestCustomizationParams(d.Get("guest_customization"))
 		checkForUpdateParams = true
@@ -1590,15 +1657,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 		checkForUpdateParams = true
 	}
 	if d.HasChange("machine_type") {
-		const two, three, four = 2, 3, 4
-		subMap := map[string]interface{}{
-			"PC":      two,
-			"PSERIES": three,
-			"Q35":     four,
-		}
-		pVal := subMap[d.Get("machine_type").(string)]
-		p := config.MachineType(pVal.(int))
-		updateSpec.MachineType = &p
+		updateSpec.MachineType = common.ExpandEnum[config.MachineType](d.Get("machine_type").(string))
 		checkForUpdateParams = true
 	}
 	if d.HasChange("vtpm_config") {
@@ -1618,15 +1677,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 		checkForUpdateParams = true
 	}
 	if d.HasChange("protection_type") {
-		const two, three, four = 2, 3, 4
-		subMap := map[string]interface{}{
-			"UNPROTECTED":    two,
-			"PD_PROTECTED":   three,
-			"RULE_PROTECTED": four,
-		}
-		pVal := subMap[d.Get("protection_type").(string)]
-		p := config.ProtectionType(pVal.(int))
-		updateSpec.ProtectionType = &p
+		updateSpec.ProtectionType = common.ExpandEnum[config.ProtectionType](d.Get("protection_type").(string))
 		checkForUpdateParams = true
 	}
 	if d.HasChange("protection_policy_state") {
@@ -1649,14 +1700,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 		taskconn := meta.(*conns.Client).PrismAPI
 		// Wait for the task to complete
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutUpdate),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 			return diag.Errorf("error waiting for virtual machine (%s) to update: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 	}
@@ -1691,19 +1735,20 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutDelete),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 					return diag.Errorf("error waiting for disk (%s) to be deleted: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
 		}
 
+		// disks moved to another storage container are migrated in place
+		diskMigrations, updatedDisk := diskStorageMigrations(oldDisk.([]interface{}), updatedDisk)
+		if len(diskMigrations) > 0 {
+			if diags := migrateVMDisks(ctx, conn, d, meta, diskMigrations); diags.HasError() {
+				return diags
+			}
+		}
+
 		if len(updatedDisk) > 0 {
 			for _, disk := range updatedDisk {
 				if diskMap, ok := disk.(map[string]interface{}); ok {
@@ -1743,14 +1788,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutUpdate),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 					return diag.Errorf("error waiting for disk (%s) to be updated: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -1778,14 +1816,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutCreate),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 					return diag.Errorf("error waiting for disk (%s) to add: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -1831,14 +1862,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutDelete),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 					return diag.Errorf("error waiting for nic (%s) to be deleted: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -1871,14 +1895,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutUpdate),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 					return diag.Errorf("error waiting for nic (%s) to be updated: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -1905,14 +1922,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutCreate),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 					return diag.Errorf("error waiting for NIC (%s) to add: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -1944,14 +1954,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutCreate),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 					return diag.Errorf("error waiting for CdRom (%s) to add: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -1981,14 +1984,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutDelete),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 					return diag.Errorf("error waiting for cdrom (%s) to be deleted: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -2023,14 +2019,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutDelete),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 					return diag.Errorf("error waiting for serial port (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -2059,14 +2048,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutUpdate),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 					return diag.Errorf("error waiting for seial port (%s) to be updated: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -2093,14 +2075,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutCreate),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 					return diag.Errorf("error waiting for SerialPort (%s) to add: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -2133,14 +2108,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutCreate),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 					return diag.Errorf("error waiting for Gpu (%s) to add: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
@@ -2169,20 +2137,72 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 				taskconn := meta.(*conns.Client).PrismAPI
 				// Wait for the task to complete
-				stateConf := &resource.StateChangeConf{
-					Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-					Target:  []string{"SUCCEEDED"},
-					Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-					Timeout: d.Timeout(schema.TimeoutDelete),
-				}
-
-				if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+				if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 					return diag.Errorf("error waiting for gpu (%s) to be deleted: %s", utils.StringValue(taskUUID), errWaitTask)
 				}
 			}
 		}
 	}
 
+	if d.HasChange("pcie_devices") {
+		oldPcieDevices, newPcieDevices := d.GetChange("pcie_devices")
+		newAddedPcieDevices, oldDeletedPcieDevices := diffPcieDevices(oldPcieDevices.([]interface{}), newPcieDevices.([]interface{}))
+
+		// detach first, a device moved to another block is attached again below
+		for _, pcieDevice := range oldDeletedPcieDevices {
+			pcieDeviceExtID := pcieDevice.(map[string]interface{})["ext_id"].(string)
+
+			ReadVMResp, err := conn.VMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
+			if err != nil {
+				return diag.Errorf("error while fetching vm : %v", err)
+			}
+
+			// Extract E-Tag Header
+			args := make(map[string]interface{})
+			args["If-Match"] = getEtagHeader(ReadVMResp, conn)
+
+			resp, err := conn.VMAPIInstance.DeletePcieDeviceById(utils.StringPtr(d.Id()), utils.StringPtr(pcieDeviceExtID), args)
+			if err != nil {
+				return diag.Errorf("error while deleting pcie device : %v", err)
+			}
+			TaskRef := resp.Data.GetValue().(import1.TaskReference)
+			taskUUID := TaskRef.ExtId
+
+			taskconn := meta.(*conns.Client).PrismAPI
+			// Wait for the task to complete
+			if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
+				return diag.Errorf("error waiting for pcie device (%s) to be deleted: %s", utils.StringValue(taskUUID), errWaitTask)
+			}
+		}
+
+		for _, pcieDevice := range newAddedPcieDevices {
+			pcieDeviceInput := expandPcieDevices([]interface{}{pcieDevice})[0]
+			pcieDeviceInput.ExtId = nil
+
+			ReadVMResp, err := conn.VMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
+			if err != nil {
+				return diag.Errorf("error while fetching vm : %v", err)
+			}
+
+			// Extract E-Tag Header
+			args := make(map[string]interface{})
+			args["If-Match"] = getEtagHeader(ReadVMResp, conn)
+
+			resp, err := conn.VMAPIInstance.CreatePcieDevice(utils.StringPtr(d.Id()), &pcieDeviceInput, args)
+			if err != nil {
+				return diag.Errorf("error while creating pcie device : %v", err)
+			}
+			TaskRef := resp.Data.GetValue().(import1.TaskReference)
+			taskUUID := TaskRef.ExtId
+
+			taskconn := meta.(*conns.Client).PrismAPI
+			// Wait for the task to complete
+			if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
+				return diag.Errorf("error waiting for pcie device (%s) to add: %s", utils.StringValue(taskUUID), errWaitTask)
+			}
+		}
+	}
+
 	if d.HasChange("categories") {
 		oldCategories, newCategories := d.GetChange("categories")
 		oldCategoriesList := common.InterfaceToSlice(oldCategories)
@@ -2212,14 +2232,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 			taskconn := meta.(*conns.Client).PrismAPI
 			// Wait for the task to complete
-			stateConf := &resource.StateChangeConf{
-				Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-				Target:  []string{"SUCCEEDED"},
-				Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-				Timeout: d.Timeout(schema.TimeoutDelete),
-			}
-
-			if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+			if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 				return diag.Errorf("error waiting for categories (%s) to diassociate: %s", utils.StringValue(taskUUID), errWaitTask)
 			}
 		}
@@ -2247,14 +2260,7 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 
 			taskconn := meta.(*conns.Client).PrismAPI
 			// Wait for the task to complete
-			stateConf := &resource.StateChangeConf{
-				Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-				Target:  []string{"SUCCEEDED"},
-				Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-				Timeout: d.Timeout(schema.TimeoutCreate),
-			}
-
-			if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+			if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
 				return diag.Errorf("error waiting for categories (%s) to attach: %s", utils.StringValue(taskUUID), errWaitTask)
 			}
 		}
@@ -2264,10 +2270,16 @@ func ResourceNutanixVirtualMachineV2Update(ctx context.Context, d *schema.Resour
 	if checkForHotPlugChanges(d) {
 		if power, ok := d.GetOk("power_state"); ok {
 			if power == "ON" {
-				callForPowerOnVM(ctx, conn, d, meta)
+				if diags := callForPowerOnVM(ctx, conn, d, meta); diags.HasError() {
+					return diags
+				}
 			}
 		}
 	}
+	if powerCycle {
+		// the power cycle is done, it is no longer pending
+		d.Set("pending_power_cycle_reasons", []string{})
+	}
 
 	if d.HasChange("power_state") {
 		if power, ok := d.GetOk("power_state"); ok {
@@ -2307,14 +2319,7 @@ func ResourceNutanixVirtualMachineV2Delete(ctx context.Context, d *schema.Resour
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the task to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutDelete),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutDelete)); errWaitTask != nil {
 		return diag.Errorf("error waiting for vm (%s) to delete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 	return nil
@@ -2343,14 +2348,7 @@ func expandVMSourceReference(pr interface{}) *config.VmSourceReference {
 		val := prI[0].(map[string]interface{})
 
 		if entity, ok := val["entity_type"]; ok {
-			const two, three = 2, 3
-			subMap := map[string]interface{}{
-				"VM":                two,
-				"VM_RECOVERY_POINT": three,
-			}
-			pVal := subMap[entity.(string)]
-			p := config.VmSourceReferenceEntityType(pVal.(int))
-			srcRef.EntityType = &p
+			srcRef.EntityType = common.ExpandEnum[config.VmSourceReferenceEntityType](entity.(string))
 		}
 		return srcRef
 	}
@@ -2359,17 +2357,7 @@ func expandVMSourceReference(pr interface{}) *config.VmSourceReference {
 
 func expandCPUFeature(pr []interface{}) []config.CpuFeature {
 	if len(pr) > 0 {
-		feats := make([]config.CpuFeature, len(pr))
-		const two = 2
-		for k, v := range pr {
-			subMap := map[string]interface{}{
-				"HARDWARE_VIRTUALIZATION": two,
-			}
-			pVal := subMap[v.(string)]
-			p := config.CpuFeature(pVal.(int))
-			feats[k] = p
-		}
-		return feats
+		return common.ExpandEnumList[config.CpuFeature](pr)
 	}
 	return nil
 }
@@ -2460,22 +2448,7 @@ func expandGuestTools(pr interface{}) *config.GuestTools {
 			tools.IsEnabled = utils.BoolPtr(isEnabled.(bool))
 		}
 		if capabilities, ok := val["capabilities"]; ok && len(capabilities.([]interface{})) > 0 {
-			feats := make([]config.NgtCapability, len(capabilities.([]interface{})))
-
-			for k, v := range capabilities.([]interface{}) {
-				const two, three = 2, 3
-				subMap := map[string]interface{}{
-					"SELF_SERVICE_RESTORE": two,
-					"VSS_SNAPSHOT":         three,
-				}
-				if subMap[v.(string)] == nil {
-					return nil
-				}
-				pVal := subMap[v.(string)]
-				p := config.NgtCapability(pVal.(int))
-				feats[k] = p
-			}
-			tools.Capabilities = feats
+			tools.Capabilities = common.ExpandEnumList[config.NgtCapability](capabilities)
 		}
 		return tools
 	}
@@ -2499,21 +2472,7 @@ func expandOneOfVMBootConfig(pr interface{}) *config.OneOfVmBootConfig {
 				legacyBootInput.BootDevice = expandOneOfLegacyBootBootDevice(bootDevice)
 			}
 			if bootOrder, ok := val["boot_order"]; ok && len(bootOrder.([]interface{})) > 0 {
-				bootOrderLen := len(bootOrder.([]interface{}))
-				orders := make([]config.BootDeviceType, bootOrderLen)
-
-				for k, v := range bootOrder.([]interface{}) {
-					const two, three, four = 2, 3, 4
-					subMap := map[string]interface{}{
-						"CDROM":   two,
-						"DISK":    three,
-						"NETWORK": four,
-					}
-					pVal := subMap[v.(string)]
-					p := config.BootDeviceType(pVal.(int))
-					orders[k] = p
-				}
-				legacyBootInput.BootOrder = orders
+				legacyBootInput.BootOrder = common.ExpandEnumList[config.BootDeviceType](bootOrder)
 			}
 			vmBootConfig.SetValue(*legacyBootInput)
 		}
@@ -2533,21 +2492,7 @@ func expandOneOfVMBootConfig(pr interface{}) *config.OneOfVmBootConfig {
 				uefiBootInput.BootDevice = expandOneOfUefiBootBootDevice(bootDevice)
 			}
 			if bootOrder, ok := val["boot_order"]; ok && len(bootOrder.([]interface{})) > 0 {
-				bootOrderLen := len(bootOrder.([]interface{}))
-				orders := make([]config.BootDeviceType, bootOrderLen)
-
-				for k, v := range bootOrder.([]interface{}) {
-					const two, three, four = 2, 3, 4
-					subMap := map[string]interface{}{
-						"CDROM":   two,
-						"DISK":    three,
-						"NETWORK": four,
-					}
-					pVal := subMap[v.(string)]
-					p := config.BootDeviceType(pVal.(int))
-					orders[k] = p
-				}
-				uefiBootInput.BootOrder = orders
+				uefiBootInput.BootOrder = common.ExpandEnumList[config.BootDeviceType](bootOrder)
 			}
 			vmBootConfig.SetValue(*uefiBootInput)
 		}
@@ -2571,17 +2516,7 @@ func expandOneOfUefiBootBootDevice(bootDevice interface{}) *config.OneOfUefiBoot
 				diskVal := daI[0].(map[string]interface{})
 				diskAddOut := config.NewDiskAddress()
 				if busType, ok := diskVal["bus_type"]; ok {
-					const two, three, four, five, six = 2, 3, 4, 5, 6
-					subMap := map[string]interface{}{
-						"SCSI":  two,
-						"IDE":   three,
-						"PCI":   four,
-						"SATA":  five,
-						"SPAPR": six,
-					}
-					pVal := subMap[busType.(string)]
-					p := config.DiskBusType(pVal.(int))
-					diskAddOut.BusType = &p
+					diskAddOut.BusType = common.ExpandEnum[config.DiskBusType](busType.(string))
 				}
 				if index, ok := diskVal["index"]; ok {
 					diskAddOut.Index = utils.IntPtr(index.(int))
@@ -2621,17 +2556,7 @@ func expandOneOfLegacyBootBootDevice(pr interface{}) *config.OneOfLegacyBootBoot
 				diskVal := daI[0].(map[string]interface{})
 				diskAddOut := config.NewDiskAddress()
 				if busType, ok := diskVal["bus_type"]; ok {
-					const two, three, four, five, six = 2, 3, 4, 5, 6
-					subMap := map[string]interface{}{
-						"SCSI":  two,
-						"IDE":   three,
-						"PCI":   four,
-						"SATA":  five,
-						"SPAPR": six,
-					}
-					pVal := subMap[busType.(string)]
-					p := config.DiskBusType(pVal.(int))
-					diskAddOut.BusType = &p
+					diskAddOut.BusType = common.ExpandEnum[config.DiskBusType](busType.(string))
 				}
 				if index, ok := diskVal["index"]; ok {
 					diskAddOut.Index = utils.IntPtr(index.(int))
@@ -2757,17 +2682,7 @@ func expandOneOfDataSourceReference(pr interface{}) *config.OneOfDataSourceRefer
 				diskVal := daI[0].(map[string]interface{})
 				diskAddOut := config.DiskAddress{}
 				if busType, ok := diskVal["bus_type"]; ok {
-					const two, three, four, five, six = 2, 3, 4, 5, 6
-					subMap := map[string]interface{}{
-						"SCSI":  two,
-						"IDE":   three,
-						"PCI":   four,
-						"SATA":  five,
-						"SPAPR": six,
-					}
-					pVal := subMap[busType.(string)]
-					p := config.DiskBusType(pVal.(int))
-					diskAddOut.BusType = &p
+					diskAddOut.BusType = common.ExpandEnum[config.DiskBusType](busType.(string))
 				}
 				if index, ok := diskVal["index"]; ok {
 					diskAddOut.Index = utils.IntPtr(index.(int))
@@ -2925,17 +2840,7 @@ func expandDiskAddress(disk interface{}) *config.DiskAddress {
 		diskVal := daI[0].(map[string]interface{})
 		diskAddOut := config.DiskAddress{}
 		if busType, ok := diskVal["bus_type"]; ok {
-			const two, three, four, five, six = 2, 3, 4, 5, 6
-			subMap := map[string]interface{}{
-				"SCSI":  two,
-				"IDE":   three,
-				"PCI":   four,
-				"SATA":  five,
-				"SPAPR": six,
-			}
-			pVal := subMap[busType.(string)]
-			p := config.DiskBusType(pVal.(int))
-			diskAddOut.BusType = &p
+			diskAddOut.BusType = common.ExpandEnum[config.DiskBusType](busType.(string))
 		}
 		if index, ok := diskVal["index"]; ok {
 			diskAddOut.Index = utils.IntPtr(index.(int))
@@ -3005,15 +2910,7 @@ func expandCdRom(cd []interface{}) []config.CdRom {
 				cds.BackingInfo = expandVMDisk(backingInfo)
 			}
 			if isoType, ok := val["iso_type"]; ok && len(isoType.(string)) > 0 {
-				const two, three, four = 2, 3, 4
-				subMap := map[string]interface{}{
-					"OTHER":               two,
-					"GUEST_TOOLS":         three,
-					"GUEST_CUSTOMIZATION": four,
-				}
-				pVal := subMap[isoType.(string)]
-				p := config.IsoType(pVal.(int))
-				cds.IsoType = &p
+				cds.IsoType = common.ExpandEnum[config.IsoType](isoType.(string))
 			}
 
 			cdList[k] = cds
@@ -3030,14 +2927,7 @@ func expandCdRomAddress(disk interface{}) *config.CdRomAddress {
 		adVal := adI[0].(map[string]interface{})
 
 		if busType, ok := adVal["bus_type"]; ok {
-			const two, three = 2, 3
-			subMap := map[string]interface{}{
-				"IDE":  two,
-				"SATA": three,
-			}
-			pVal := subMap[busType.(string)]
-			p := config.CdRomBusType(pVal.(int))
-			cdRomAdd.BusType = &p
+			cdRomAdd.BusType = common.ExpandEnum[config.CdRomBusType](busType.(string))
 		}
 		if index, ok := adVal["index"]; ok {
 			cdRomAdd.Index = utils.IntPtr(index.(int))
@@ -3059,29 +2949,13 @@ func expandGpu(gpu []interface{}) []config.Gpu {
 				gpus.ExtId = utils.StringPtr(extID.(string))
 			}
 			if mode, ok := val["mode"]; ok {
-				const two, three, four = 2, 3, 4
-				subMap := map[string]interface{}{
-					"PASSTHROUGH_GRAPHICS": two,
-					"PASSTHROUGH_COMPUTE":  three,
-					"VIRTUAL":              four,
-				}
-				pVal := subMap[mode.(string)]
-				p := config.GpuMode(pVal.(int))
-				gpus.Mode = &p
+				gpus.Mode = common.ExpandEnum[config.GpuMode](mode.(string))
 			}
 			if deviceID, ok := val["device_id"]; ok {
 				gpus.DeviceId = utils.IntPtr(deviceID.(int))
 			}
 			if vendor, ok := val["vendor"]; ok {
-				const two, three, four = 2, 3, 4
-				subMap := map[string]interface{}{
-					"NVIDIA": two,
-					"INTEL":  three,
-					"AMD":    four,
-				}
-				pVal := subMap[vendor.(string)]
-				p := config.GpuVendor(pVal.(int))
-				gpus.Vendor = &p
+				gpus.Vendor = common.ExpandEnum[config.GpuVendor](vendor.(string))
 			}
 			if pciAddress, ok := val["pci_address"]; ok && len(pciAddress.([]interface{})) > 0 {
 				pciObj := config.SBDF{}
@@ -3281,18 +3155,9 @@ func powerOffVMWithRetry(ctx context.Context, conn *vmm.Client, vmID *string) (i
 
 func flattenPowerState(pr *config.PowerState) string {
 	if pr != nil {
-		const two, three, four, five = 2, 3, 4, 5
-		if *pr == config.PowerState(two) {
-			return "ON"
-		}
-		if *pr == config.PowerState(three) {
-			return "OFF"
-		}
-		if *pr == config.PowerState(four) {
-			return "PAUSED"
-		}
-		if *pr == config.PowerState(five) {
-			return "UNDETERMINED"
+		switch *pr {
+		case config.POWERSTATE_ON, config.POWERSTATE_OFF, config.POWERSTATE_PAUSED, config.POWERSTATE_UNDETERMINED:
+			return pr.GetName()
 		}
 	}
 	return "UNKNOWN"
@@ -3321,19 +3186,171 @@ func callForPowerOffVM(ctx context.Context, conn *vmm.Client, d *schema.Resource
 	prismConn := meta.(*conns.Client).PrismAPI
 
 	// Wait for the task to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, prismConn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
+	if _, errWaitTask := common.WaitForTask(ctx, prismConn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for virtual machine (%s) to power off: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
+	return nil
+}
 
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
-		return diag.Errorf("error waiting for virtual machine (%s) to power off: %s", utils.StringValue(taskUUID), errWaitTask)
+// callForGuestShutdownVM asks the guest OS to shut down and waits for the VM to be powered off,
+// so that the update does not have to pull the power of a running guest
+func callForGuestShutdownVM(ctx context.Context, conn *vmm.Client, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	readResp, errR := conn.VMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
+	if errR != nil {
+		return diag.Errorf("error while reading vm : %v", errR)
+	}
+
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	resp, err := conn.VMAPIInstance.ShutdownGuestVm(utils.StringPtr(d.Id()), &config.GuestPowerOptions{}, args)
+	if err != nil {
+		return diag.Errorf("error while shutting down the guest of vm %s : %v", d.Id(), err)
+	}
+	TaskRef := resp.Data.GetValue().(import1.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	prismConn := meta.(*conns.Client).PrismAPI
+	// Wait for the task to complete
+	if _, errWaitTask := common.WaitForTask(ctx, prismConn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for the guest of vm (%s) to shut down: %s", d.Id(), errWaitTask)
+	}
+
+	// the guest powers the VM off once it has shut down
+	powerOffConf := &resource.StateChangeConf{
+		Pending:    []string{"ON"},
+		Target:     []string{"OFF"},
+		Refresh:    powerStateRefreshFunc(conn, d.Id()),
+		Timeout:    d.Timeout(schema.TimeoutUpdate),
+		MinTimeout: delay,
+	}
+	if _, err := powerOffConf.WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for vm (%s) to power off after the guest shutdown: %s", d.Id(), err)
 	}
 	return nil
 }
 
+func powerStateRefreshFunc(client *vmm.Client, vmUUID string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		resp, err := client.VMAPIInstance.GetVmById(utils.StringPtr(vmUUID))
+		if err != nil {
+			return nil, "", err
+		}
+		vm := resp.Data.GetValue().(config.Vm)
+		if vm.PowerState != nil && *vm.PowerState == config.POWERSTATE_OFF {
+			return resp, "OFF", nil
+		}
+		return resp, "ON", nil
+	}
+}
+
+// migrateVirtualMachineV2 migrates the VM to the configured cluster and host, live when the VM
+// is running. It returns whether the VM was migrated to the configured host: a powered off VM
+// is placed on its host by the VM update instead.
+func migrateVirtualMachineV2(ctx context.Context, conn *vmm.Client, d *schema.ResourceData, meta interface{}) (bool, diag.Diagnostics) {
+	if d.HasChange("cluster") {
+		cluster := expandClusterReference(d.Get("cluster"))
+		if cluster != nil && cluster.ExtId != nil {
+			zone := expandAvailabilityZoneReference(d.Get("availability_zone"))
+			if diags := crossClusterMigrateVM(ctx, conn, d, meta, cluster, zone); diags.HasError() {
+				return false, diags
+			}
+		}
+	}
+
+	if d.HasChange("host") {
+		host := expandHostReference(d.Get("host"))
+		if host != nil && utils.StringValue(host.ExtId) != "" {
+			return migrateVMToHost(ctx, conn, d, meta, host)
+		}
+	}
+	return false, nil
+}
+
+// crossClusterMigrateVM moves the VM to another cluster. The migration is validated with a dry
+// run first, so that a migration which cannot be done fails without touching the VM.
+func crossClusterMigrateVM(ctx context.Context, conn *vmm.Client, d *schema.ResourceData, meta interface{},
+	cluster *config.ClusterReference, zone *config.AvailabilityZoneReference,
+) diag.Diagnostics {
+	readResp, err := conn.VMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while reading vm : %v", err)
+	}
+	vm := readResp.Data.GetValue().(config.Vm)
+
+	if vm.Cluster != nil && utils.StringValue(vm.Cluster.ExtId) == utils.StringValue(cluster.ExtId) {
+		log.Printf("[DEBUG] vm %s is already on cluster %s", d.Id(), utils.StringValue(cluster.ExtId))
+		return nil
+	}
+	if vm.AvailabilityZone != nil {
+		zone = vm.AvailabilityZone
+	}
+
+	body := &config.VmCrossClusterMigrateParams{
+		IsLiveMigration:        utils.BoolPtr(vm.PowerState != nil && *vm.PowerState == config.POWERSTATE_ON),
+		TargetAvailabilityZone: zone,
+		TargetCluster:          cluster,
+	}
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	for _, dryRun := range []bool{true, false} {
+		args := make(map[string]interface{})
+		args["If-Match"] = getEtagHeader(readResp, conn)
+
+		resp, err := conn.VMAPIInstance.CrossClusterMigrateVm(utils.StringPtr(d.Id()), body, utils.BoolPtr(dryRun), args)
+		if err != nil {
+			return diag.Errorf("error while migrating vm %s to cluster %s : %v", d.Id(), utils.StringValue(cluster.ExtId), err)
+		}
+		TaskRef := resp.Data.GetValue().(import1.TaskReference)
+		taskUUID := TaskRef.ExtId
+
+		// Wait for the task to complete
+		if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+			if dryRun {
+				return diag.Errorf("vm %s cannot be migrated to cluster %s: %s", d.Id(), utils.StringValue(cluster.ExtId), errWaitTask)
+			}
+			return diag.Errorf("error waiting for vm (%s) to migrate to cluster %s: %s", d.Id(), utils.StringValue(cluster.ExtId), errWaitTask)
+		}
+	}
+	return nil
+}
+
+// migrateVMToHost live migrates a running VM to another host of its cluster, and reports
+// whether it did
+func migrateVMToHost(ctx context.Context, conn *vmm.Client, d *schema.ResourceData, meta interface{}, host *config.HostReference) (bool, diag.Diagnostics) {
+	readResp, err := conn.VMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return false, diag.Errorf("error while reading vm : %v", err)
+	}
+	vm := readResp.Data.GetValue().(config.Vm)
+
+	if vm.Host != nil && utils.StringValue(vm.Host.ExtId) == utils.StringValue(host.ExtId) {
+		log.Printf("[DEBUG] vm %s is already on host %s", d.Id(), utils.StringValue(host.ExtId))
+		return true, nil
+	}
+	if vm.PowerState == nil || *vm.PowerState != config.POWERSTATE_ON {
+		log.Printf("[DEBUG] vm %s is not running, it is placed on host %s by the update", d.Id(), utils.StringValue(host.ExtId))
+		return false, nil
+	}
+
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	resp, err := conn.VMAPIInstance.MigrateVmToHost(utils.StringPtr(d.Id()), &config.VmMigrateToHostParams{Host: host}, args)
+	if err != nil {
+		return false, diag.Errorf("error while migrating vm %s to host %s : %v", d.Id(), utils.StringValue(host.ExtId), err)
+	}
+	TaskRef := resp.Data.GetValue().(import1.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	// Wait for the task to complete
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return false, diag.Errorf("error waiting for vm (%s) to migrate to host %s: %s", d.Id(), utils.StringValue(host.ExtId), errWaitTask)
+	}
+	return true, nil
+}
+
 func callForPowerOnVM(ctx context.Context, conn *vmm.Client, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	readResp, errR := conn.VMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
 	if errR != nil {
@@ -3359,14 +3376,7 @@ func callForPowerOnVM(ctx context.Context, conn *vmm.Client, d *schema.ResourceD
 	prismConn := meta.(*conns.Client).PrismAPI
 
 	// Wait for the task to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, prismConn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutUpdate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	if _, errWaitTask := common.WaitForTask(ctx, prismConn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
 		return diag.Errorf("error waiting for virtual machine (%s) to power on: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 	return nil
@@ -3445,16 +3455,143 @@ func diffConfig(oldValue []interface{}, newValue []interface{}) ([]interface{},
 	return newlyAdded, removed, updated
 }
 
+// diskStorageMigrations returns the disks moved to another storage container, by target
+// storage container extId, and the updated disks which have other changes to apply
+func diskStorageMigrations(oldDisks, updatedDisks []interface{}) (map[string][]string, []interface{}) {
+	migrations := make(map[string][]string)
+	remaining := make([]interface{}, 0, len(updatedDisks))
+
+	for _, disk := range updatedDisks {
+		newMap := disk.(map[string]interface{})
+		var oldMap map[string]interface{}
+		for _, oldDisk := range oldDisks {
+			if m := oldDisk.(map[string]interface{}); m["ext_id"] == newMap["ext_id"] {
+				oldMap = m
+				break
+			}
+		}
+
+		newContainer, oldContainer := diskStorageContainer(newMap), diskStorageContainer(oldMap)
+		if oldMap == nil || newContainer == nil || oldContainer == nil || newContainer["ext_id"] == oldContainer["ext_id"] {
+			remaining = append(remaining, disk)
+			continue
+		}
+		target := newContainer["ext_id"].(string)
+		migrations[target] = append(migrations[target], newMap["ext_id"].(string))
+
+		// compare the disks without the storage container, to find the other changes
+		newContainer["ext_id"] = oldContainer["ext_id"]
+		otherChanges := !reflect.DeepEqual(newMap, oldMap)
+		newContainer["ext_id"] = target
+		if otherChanges {
+			remaining = append(remaining, disk)
+		}
+	}
+	return migrations, remaining
+}
+
+// diskStorageContainer returns the storage container reference of a vm disk in the disks list,
+// nil if it has none
+func diskStorageContainer(disk map[string]interface{}) map[string]interface{} {
+	path := []string{"backing_info", "vm_disk", "storage_container"}
+	current := disk
+	for _, key := range path {
+		list, ok := current[key].([]interface{})
+		if !ok || len(list) == 0 {
+			return nil
+		}
+		if current, ok = list[0].(map[string]interface{}); !ok {
+			return nil
+		}
+	}
+	if extID, ok := current["ext_id"].(string); !ok || extID == "" {
+		return nil
+	}
+	return current
+}
+
+// migrateVMDisks moves the disks of the VM to their target storage container, without powering
+// off the VM. A single plan is used when all the disks move to the same storage container.
+func migrateVMDisks(ctx context.Context, conn *vmm.Client, d *schema.ResourceData, meta interface{}, migrations map[string][]string) diag.Diagnostics {
+	readResp, err := conn.VMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while fetching vm : %v", err)
+	}
+	vm := readResp.Data.GetValue().(config.Vm)
+
+	containers := make([]string, 0, len(migrations))
+	migrated := 0
+	for container, disks := range migrations {
+		containers = append(containers, container)
+		migrated += len(disks)
+	}
+	sort.Strings(containers)
+
+	migrateDisks := config.NewOneOfDiskMigrationParamsMigrateDisks()
+	if len(containers) == 1 && migrated == len(vm.Disks) {
+		plan := config.NewAllDisksMigrationPlan()
+		plan.StorageContainer = &config.VmDiskContainerReference{ExtId: utils.StringPtr(containers[0])}
+		if err := migrateDisks.SetValue(*plan); err != nil {
+			return diag.Errorf("error while building the disks migration : %v", err)
+		}
+	} else {
+		plans := config.NewMigrationPlans()
+		for _, container := range containers {
+			plan := config.NewADSFDiskMigrationPlan()
+			plan.StorageContainer = &config.VmDiskContainerReference{ExtId: utils.StringPtr(container)}
+			for _, diskExtID := range migrations[container] {
+				diskRef := config.NewMigrateDiskReference()
+				diskRef.DiskExtId = utils.StringPtr(diskExtID)
+				plan.VmDisks = append(plan.VmDisks, *diskRef)
+			}
+			plans.Plans = append(plans.Plans, *plan)
+		}
+		if err := migrateDisks.SetValue(*plans); err != nil {
+			return diag.Errorf("error while building the disks migration : %v", err)
+		}
+	}
+	body := config.NewDiskMigrationParams()
+	body.MigrateDisks = migrateDisks
+
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	log.Printf("[DEBUG] migrating %d disks of vm %s to storage containers %v", migrated, d.Id(), containers)
+	resp, err := conn.VMAPIInstance.MigrateVmDisks(utils.StringPtr(d.Id()), body, args)
+	if err != nil {
+		return diag.Errorf("error while migrating vm disks : %v", err)
+	}
+	TaskRef := resp.Data.GetValue().(import1.TaskReference)
+	taskUUID := TaskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	// Wait for the task to complete, its progress is logged while the disks are copied
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutUpdate)); errWaitTask != nil {
+		return diag.Errorf("error waiting for vm (%s) disks to migrate: %s", d.Id(), errWaitTask)
+	}
+	return nil
+}
+
 // Check if VM is in power off state to perform update operations
 func checkForHotPlugChanges(d *schema.ResourceData) bool {
-	if d.HasChange("num_sockets") || d.HasChange("num_cores_per_socket") || d.HasChange("memory_size_bytes") ||
-		d.HasChange("num_threads_per_core") || d.HasChange("cd_rom") || d.HasChange("num_numa_nodes") ||
-		d.HasChange("cluster") || d.HasChange("is_cpu_passthrough_enabled") || d.HasChange("enabled_cpu_features") ||
-		d.HasChange("is_vcpu_hard_pinning_enabled") || d.HasChange("guest_customization") || d.HasChange("guest_tools") ||
-		d.HasChange("serial_ports") || d.HasChange("gpus") || d.HasChange("boot_config") {
-		return true
+	return len(common.PowerCycleReasons(d.HasChange, powerCycleAttributes)) > 0
+}
+
+// resourceNutanixVirtualMachineV2Diff shows in the plan the changes which power cycle a running VM
+func resourceNutanixVirtualMachineV2Diff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
 	}
-	return false
+	// a VM which is not kept running is not powered back on, so it is not power cycled
+	oldPowerState, newPowerState := d.GetChange("power_state")
+	if oldPowerState.(string) != "ON" || newPowerState.(string) != "ON" {
+		return nil
+	}
+	reasons := common.PowerCycleReasons(d.HasChange, powerCycleAttributes)
+	if len(reasons) == 0 {
+		return nil
+	}
+	return d.SetNew("pending_power_cycle_reasons", reasons)
 }
 
 func isVMPowerOff(d *schema.ResourceData, conn *vmm.Client) bool {
@@ -3718,6 +3855,9 @@ func prepareVMConfigFromMap(m map[string]interface{}) *config.Vm {
 	if gpus, ok := m["gpus"]; ok {
 		body.Gpus = expandGpu(gpus.([]interface{}))
 	}
+	if pcieDevices, ok := m["pcie_devices"]; ok {
+		body.PcieDevices = expandPcieDevices(pcieDevices.([]interface{}))
+	}
 	if serialPorts, ok := m["serial_ports"]; ok {
 		body.SerialPorts = expandSerialPort(serialPorts.([]interface{}))
 	}
@@ -3791,6 +3931,7 @@ func extractVMConfigFields(getResp config.Vm) (map[string]interface{}, diag.Diag
 	fields["cd_roms"] = flattenCdRom(getResp.CdRoms)
 	fields["nics"] = flattenNic(getResp.Nics)
 	fields["gpus"] = flattenGpu(getResp.Gpus)
+	fields["pcie_devices"] = flattenPcieDevices(getResp.PcieDevices)
 	fields["serial_ports"] = flattenSerialPort(getResp.SerialPorts)
 	fields["protection_type"] = flattenProtectionType(getResp.ProtectionType)
 	fields["protection_policy_state"] = flattenProtectionPolicyState(getResp.ProtectionPolicyState)
@@ -3872,7 +4013,7 @@ func setVMConfig(d *schema.ResourceData, getResp config.Vm) diag.Diagnostics {
 	if err := d.Set("availability_zone", flattenAvailabilityZoneReference(getResp.AvailabilityZone)); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("guest_customization", flattenGuestCustomizationParams(getResp.GuestCustomization)); err != nil {
+	if err := d.Set("guest_customization", flattenGuestCustomizationWithStructuredInputs(d, getResp.GuestCustomization)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("guest_tools", flattenGuestTools(getResp.GuestTools)); err != nil {
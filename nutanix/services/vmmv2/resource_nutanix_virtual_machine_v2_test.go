@@ -1,13 +1,21 @@
 package vmmv2_test
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"net/http"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	acc "github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
 )
 
 const resourceNameVms = "nutanix_virtual_machine_v2.test"
@@ -39,6 +47,361 @@ func TestAccV2NutanixVmsResource_Basic(t *testing.T) {
 	})
 }
 
+func TestUnitV2NutanixVmsResource_LiveMigration(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	const vmsPath = "vmm/ahv/config/vms"
+	extID := pc.Add(vmsPath, map[string]interface{}{
+		"name":             "tf-test-vm",
+		"powerState":       "ON",
+		"cluster":          map[string]interface{}{"extId": "cluster-1"},
+		"host":             map[string]interface{}{"extId": "host-1"},
+		"availabilityZone": map[string]interface{}{"extId": "local-az"},
+	})
+
+	var dryRuns []string
+	pc.Handle(http.MethodPost, vmsPath+"/{extId}/$actions/migrate", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"isLiveMigration":true`) || !strings.Contains(string(body), `"local-az"`) {
+			t.Errorf("unexpected cross cluster migration request: %s", body)
+		}
+		dryRun := r.URL.Query().Get("$dryrun")
+		dryRuns = append(dryRuns, dryRun)
+		if dryRun != "true" {
+			vm, _ := pc.Get(vmsPath, extID)
+			vm["cluster"] = map[string]interface{}{"extId": "cluster-2"}
+			vm["host"] = map[string]interface{}{"extId": "host-3"}
+			pc.Add(vmsPath, vm)
+		}
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("migrate", mockpc.DefaultCollections[0], extID))
+	})
+	pc.Handle(http.MethodPost, vmsPath+"/{extId}/$actions/migrate-to-host", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Match") == "" {
+			t.Error("If-Match header not sent")
+		}
+		vm, _ := pc.Get(vmsPath, extID)
+		vm["host"] = map[string]interface{}{"extId": "host-2"}
+		pc.Add(vmsPath, vm)
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("migrate-to-host", mockpc.DefaultCollections[0], extID))
+	})
+
+	r := vmmv2.ResourceNutanixVirtualMachineV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":    "tf-test-vm",
+		"cluster": []interface{}{map[string]interface{}{"ext_id": "cluster-2"}},
+		"host":    []interface{}{map[string]interface{}{"ext_id": "host-2"}},
+	})
+	d.SetId(extID)
+
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+
+	if strings.Join(dryRuns, ",") != "true,false" {
+		t.Errorf("cross cluster migrations = %v, expected a dry run then the migration", dryRuns)
+	}
+	vm, _ := pc.Get(vmsPath, extID)
+	if vm["powerState"] != "ON" {
+		t.Errorf("vm power state = %v, expected the vm to keep running", vm["powerState"])
+	}
+	migrated := false
+	for _, req := range pc.Requests() {
+		if strings.HasSuffix(req.Path, "/$actions/power-off") || strings.HasSuffix(req.Path, "/$actions/shutdown") {
+			t.Errorf("vm powered off during the migration: %s", req.Path)
+		}
+		if strings.HasSuffix(req.Path, "/$actions/migrate-to-host") {
+			migrated = true
+		}
+		// the vm update only sends the other changes, once the vm is migrated
+		if req.Method == http.MethodPut && !migrated {
+			t.Errorf("vm updated before the migration: %s", req.Body)
+		}
+	}
+	if got := d.Get("cluster.0.ext_id"); got != "cluster-2" {
+		t.Errorf("cluster = %v, expected cluster-2", got)
+	}
+	if got := d.Get("host.0.ext_id"); got != "host-2" {
+		t.Errorf("host = %v, expected host-2", got)
+	}
+}
+
+func TestUnitV2NutanixVmsResource_DiskStorageMigration(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	const vmsPath = "vmm/ahv/config/vms"
+	vmDisk := func(extID, container string) map[string]interface{} {
+		return map[string]interface{}{
+			"extId": extID,
+			"backingInfo": map[string]interface{}{
+				"$objectType":      "vmm.v4.ahv.config.VmDisk",
+				"diskSizeBytes":    1073741824,
+				"storageContainer": map[string]interface{}{"extId": container},
+			},
+		}
+	}
+	extID := pc.Add(vmsPath, map[string]interface{}{
+		"name":       "tf-test-vm",
+		"powerState": "ON",
+		"disks":      []interface{}{vmDisk("disk-1", "container-1"), vmDisk("disk-2", "container-1")},
+	})
+
+	var migrations []string
+	pc.Handle(http.MethodPost, vmsPath+"/{extId}/$actions/migrate-vm-disks", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		migrations = append(migrations, string(body))
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("migrate-vm-disks", mockpc.DefaultCollections[0], extID))
+	})
+	var diskUpdates []string
+	pc.Handle(http.MethodPut, vmsPath+"/{extId}/disks/{diskExtId}", func(w http.ResponseWriter, r *http.Request) {
+		diskUpdates = append(diskUpdates, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("update-disk", mockpc.DefaultCollections[0], extID))
+	})
+
+	disk := func(extID, container string, size int) map[string]interface{} {
+		return map[string]interface{}{
+			"ext_id": extID,
+			"backing_info": []interface{}{map[string]interface{}{
+				"vm_disk": []interface{}{map[string]interface{}{
+					"disk_size_bytes":   size,
+					"storage_container": []interface{}{map[string]interface{}{"ext_id": container}},
+				}},
+			}},
+		}
+	}
+	update := func(oldDisks, newDisks []interface{}) {
+		t.Helper()
+		r := vmmv2.ResourceNutanixVirtualMachineV2()
+		old := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "tf-test-vm", "disks": oldDisks})
+		old.SetId(extID)
+
+		newConfig := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "tf-test-vm", "disks": newDisks})
+		diff, err := r.Diff(ctx, old.State(), newConfig, meta)
+		if err != nil {
+			t.Fatalf("diff: %v", err)
+		}
+		d, err := schema.InternalMap(r.Schema).Data(old.State(), diff)
+		if err != nil {
+			t.Fatalf("data: %v", err)
+		}
+		if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+			t.Fatalf("update: %v", diags)
+		}
+	}
+
+	// all the disks moved to the same storage container
+	gib := 1073741824
+	update(
+		[]interface{}{disk("disk-1", "container-1", gib), disk("disk-2", "container-1", gib)},
+		[]interface{}{disk("disk-1", "container-2", gib), disk("disk-2", "container-2", gib)},
+	)
+	if len(migrations) != 1 || !strings.Contains(migrations[0], "vmm.v4.ahv.config.AllDisksMigrationPlan") || !strings.Contains(migrations[0], "container-2") {
+		t.Fatalf("unexpected disks migrations: %v", migrations)
+	}
+	if len(diskUpdates) != 0 {
+		t.Errorf("disks updated instead of migrated: %v", diskUpdates)
+	}
+
+	// a single disk moved and resized
+	migrations = nil
+	update(
+		[]interface{}{disk("disk-1", "container-2", gib), disk("disk-2", "container-2", gib)},
+		[]interface{}{disk("disk-1", "container-3", 2*gib), disk("disk-2", "container-2", gib)},
+	)
+	if len(migrations) != 1 || !strings.Contains(migrations[0], "vmm.v4.ahv.config.MigrationPlans") ||
+		!strings.Contains(migrations[0], `"diskExtId":"disk-1"`) || strings.Contains(migrations[0], "disk-2") {
+		t.Fatalf("unexpected disks migrations: %v", migrations)
+	}
+	if strings.Join(diskUpdates, ",") != "disk-1" {
+		t.Errorf("updated disks = %v, expected disk-1 to be resized", diskUpdates)
+	}
+	for _, req := range pc.Requests() {
+		if strings.HasSuffix(req.Path, "/$actions/power-off") {
+			t.Errorf("vm powered off during the disks migration")
+		}
+	}
+}
+
+func TestUnitV2NutanixVmsResource_PowerCyclePolicy(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	const vmsPath = "vmm/ahv/config/vms"
+	extID := pc.Add(vmsPath, map[string]interface{}{"name": "tf-test-vm", "powerState": "ON", "numSockets": 1})
+
+	setPowerState := func(action, powerState string) {
+		pc.Handle(http.MethodPost, vmsPath+"/{extId}/$actions/"+action, func(w http.ResponseWriter, r *http.Request) {
+			vm, _ := pc.Get(vmsPath, extID)
+			vm["powerState"] = powerState
+			pc.Add(vmsPath, vm)
+			mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask(action, mockpc.DefaultCollections[0], extID))
+		})
+	}
+	setPowerState("guest-shutdown", "OFF")
+	setPowerState("power-off", "OFF")
+	setPowerState("power-on", "ON")
+
+	r := vmmv2.ResourceNutanixVirtualMachineV2()
+	plan := func(policy string) (*terraform.InstanceDiff, *schema.ResourceData) {
+		t.Helper()
+		old := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"name": "tf-test-vm", "num_sockets": 1, "power_cycle_policy": policy,
+		})
+		old.SetId(extID)
+
+		newConfig := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "tf-test-vm", "num_sockets": 2, "power_cycle_policy": policy,
+		})
+		diff, err := r.Diff(ctx, old.State(), newConfig, meta)
+		if err != nil {
+			t.Fatalf("diff: %v", err)
+		}
+		d, err := schema.InternalMap(r.Schema).Data(old.State(), diff)
+		if err != nil {
+			t.Fatalf("data: %v", err)
+		}
+		return diff, d
+	}
+	actions := func() []string {
+		var paths []string
+		for _, req := range pc.Requests() {
+			if i := strings.Index(req.Path, "/$actions/"); i >= 0 {
+				paths = append(paths, req.Path[i+len("/$actions/"):])
+			}
+			if req.Method == http.MethodPut {
+				paths = append(paths, "update")
+			}
+		}
+		return paths
+	}
+
+	// the plan shows the power cycle, and deny fails the apply without touching the vm
+	diff, d := plan("deny")
+	if attr := diff.Attributes["pending_power_cycle_reasons.0"]; attr == nil || attr.New != "num_sockets" {
+		t.Fatalf("pending_power_cycle_reasons = %v, expected num_sockets", attr)
+	}
+	diags := r.UpdateContext(ctx, d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "num_sockets") {
+		t.Fatalf("update: %v, expected the power cycle to be denied", diags)
+	}
+	if got := actions(); len(got) != 0 {
+		t.Errorf("vm changed by a denied update: %v", got)
+	}
+
+	// the guest is shut down, so the vm does not need to be powered off
+	_, d = plan("guest_shutdown_then_allow")
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if got := strings.Join(actions(), ","); got != "guest-shutdown,update,power-on" {
+		t.Errorf("vm actions = %s, expected a guest shutdown before the update", got)
+	}
+	if reasons := d.Get("pending_power_cycle_reasons").([]interface{}); len(reasons) != 0 {
+		t.Errorf("pending_power_cycle_reasons = %v, expected none after the update", reasons)
+	}
+
+	// a failed power off fails the update, the power cycle is still pending
+	_, d = plan("allow")
+	applied := len(actions())
+	pc.FailNextTask("vm power off failed")
+	diags = r.UpdateContext(ctx, d, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "vm power off failed") {
+		t.Fatalf("update: %v, expected the power off error", diags)
+	}
+	if got := actions()[applied:]; strings.Join(got, ",") != "power-off" {
+		t.Errorf("vm actions = %v, expected the update to stop after the failed power off", got)
+	}
+	if reasons := d.Get("pending_power_cycle_reasons").([]interface{}); len(reasons) != 1 || reasons[0] != "num_sockets" {
+		t.Errorf("pending_power_cycle_reasons = %v, expected num_sockets", reasons)
+	}
+}
+
+func TestUnitV2NutanixVmsResource_PcieDevices(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	const vmsPath = "vmm/ahv/config/vms"
+	extID := pc.Add(vmsPath, map[string]interface{}{"name": "tf-test-vm", "powerState": "OFF", "numSockets": 1})
+
+	var calls []string
+	pc.Handle(http.MethodDelete, vmsPath+"/{vmExtId}/pcie-devices/{extId}", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "delete:"+r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("delete-pcie-device", mockpc.DefaultCollections[0], extID))
+	})
+	pc.Handle(http.MethodPost, vmsPath+"/{vmExtId}/pcie-devices", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, "create:"+string(body))
+		mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask("create-pcie-device", mockpc.DefaultCollections[0], extID))
+	})
+
+	pcieDevice := func(deviceExtID string) map[string]interface{} {
+		return map[string]interface{}{
+			"backing_info": []interface{}{map[string]interface{}{
+				"pcie_device_reference": []interface{}{map[string]interface{}{"device_ext_id": deviceExtID}},
+			}},
+		}
+	}
+
+	r := vmmv2.ResourceNutanixVirtualMachineV2()
+	old := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "tf-test-vm", "num_sockets": 1,
+		"pcie_devices": []interface{}{pcieDevice("fpga-1"), pcieDevice("nvme-1")},
+	})
+	old.SetId(extID)
+	kept := pcieDevice("fpga-1")
+	kept["ext_id"] = "pcie-1"
+	removed := pcieDevice("nvme-1")
+	removed["ext_id"] = "pcie-2"
+	if err := old.Set("pcie_devices", []interface{}{kept, removed}); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+
+	// nvme-1 is replaced by nvme-2, fpga-1 is left attached
+	diff, err := r.Diff(ctx, old.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "tf-test-vm", "num_sockets": 1,
+		"pcie_devices": []interface{}{pcieDevice("fpga-1"), pcieDevice("nvme-2")},
+	}), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(old.State(), diff)
+	if err != nil {
+		t.Fatalf("data: %v", err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+
+	if len(calls) != 2 || calls[0] != "delete:pcie-2" || !strings.Contains(calls[1], `"deviceExtId":"nvme-2"`) {
+		t.Errorf("pcie device calls = %v, expected pcie-2 deleted then nvme-2 attached", calls)
+	}
+}
+
 func TestAccV2NutanixVmsResource_BasicUpdate(t *testing.T) {
 	r := acctest.RandInt()
 	desc := "test vm description"
@@ -0,0 +1,181 @@
+package vmmv2
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
+	esxiConfig "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/esxi/config"
+	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
+	"github.com/terraform-providers/terraform-provider-nutanix/utils"
+)
+
+// ResourceNutanixVMCategoriesEsxiV2 associates categories to an ESXi VM.
+// Only the categories listed in the configuration are managed, the others associated to the VM are left untouched.
+func ResourceNutanixVMCategoriesEsxiV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: ResourceNutanixVMCategoriesEsxiV2Create,
+		ReadContext:   ResourceNutanixVMCategoriesEsxiV2Read,
+		UpdateContext: ResourceNutanixVMCategoriesEsxiV2Update,
+		DeleteContext: ResourceNutanixVMCategoriesEsxiV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importNutanixVMCategoriesEsxiV2,
+		},
+		Schema: map[string]*schema.Schema{
+			"ext_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"categories": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ext_id": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func ResourceNutanixVMCategoriesEsxiV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vmExtID := d.Get("ext_id").(string)
+
+	categories := common.InterfaceToSlice(d.Get("categories"))
+	if diags := associateEsxiVMCategories(ctx, d, meta, vmExtID, categories, schema.TimeoutCreate); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(vmExtID)
+	return ResourceNutanixVMCategoriesEsxiV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixVMCategoriesEsxiV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	conn := meta.(*conns.Client).VmmAPI
+
+	resp, err := conn.EsxiVMAPIInstance.GetVmById(utils.StringPtr(d.Id()))
+	if err != nil {
+		return diag.Errorf("error while reading esxi vm : %v", err)
+	}
+	vm := resp.Data.GetValue().(esxiConfig.Vm)
+
+	associated := make(map[string]bool, len(vm.Categories))
+	for _, c := range vm.Categories {
+		associated[utils.StringValue(c.ExtId)] = true
+	}
+
+	// keep the managed categories that are still associated to the VM, so that a removal out of band shows up as a diff
+	managed := make([]interface{}, 0)
+	for _, c := range common.InterfaceToSlice(d.Get("categories")) {
+		if associated[c.(map[string]interface{})["ext_id"].(string)] {
+			managed = append(managed, c)
+		}
+	}
+
+	if err := d.Set("ext_id", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("categories", managed); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func ResourceNutanixVMCategoriesEsxiV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("categories") {
+		oldCategories, newCategories := d.GetChange("categories")
+		added, removed, _ := diffConfig(common.InterfaceToSlice(oldCategories), common.InterfaceToSlice(newCategories))
+
+		if diags := disassociateEsxiVMCategories(ctx, d, meta, d.Id(), removed, schema.TimeoutUpdate); diags.HasError() {
+			return diags
+		}
+		if diags := associateEsxiVMCategories(ctx, d, meta, d.Id(), added, schema.TimeoutUpdate); diags.HasError() {
+			return diags
+		}
+	}
+	return ResourceNutanixVMCategoriesEsxiV2Read(ctx, d, meta)
+}
+
+func ResourceNutanixVMCategoriesEsxiV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	categories := common.InterfaceToSlice(d.Get("categories"))
+	return disassociateEsxiVMCategories(ctx, d, meta, d.Id(), categories, schema.TimeoutDelete)
+}
+
+func associateEsxiVMCategories(ctx context.Context, d *schema.ResourceData, meta interface{}, vmExtID string, categories []interface{}, timeout string) diag.Diagnostics {
+	if len(categories) == 0 {
+		return nil
+	}
+	conn := meta.(*conns.Client).VmmAPI
+
+	readResp, err := conn.EsxiVMAPIInstance.GetVmById(utils.StringPtr(vmExtID))
+	if err != nil {
+		return diag.Errorf("error while reading esxi vm : %v", err)
+	}
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	body := &esxiConfig.AssociateVmCategoriesParams{
+		Categories: expandEsxiCategoryReference(categories),
+	}
+	resp, err := conn.EsxiVMAPIInstance.AssociateCategories(utils.StringPtr(vmExtID), body, args)
+	if err != nil {
+		return diag.Errorf("error while associating categories to esxi vm : %v", err)
+	}
+
+	taskRef := resp.Data.GetValue().(import1.TaskReference)
+	taskUUID := taskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(timeout)); errWaitTask != nil {
+		return diag.Errorf("error waiting for categories (%s) to attach: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func disassociateEsxiVMCategories(ctx context.Context, d *schema.ResourceData, meta interface{}, vmExtID string, categories []interface{}, timeout string) diag.Diagnostics {
+	if len(categories) == 0 {
+		return nil
+	}
+	conn := meta.(*conns.Client).VmmAPI
+
+	readResp, err := conn.EsxiVMAPIInstance.GetVmById(utils.StringPtr(vmExtID))
+	if err != nil {
+		return diag.Errorf("error while reading esxi vm : %v", err)
+	}
+	args := make(map[string]interface{})
+	args["If-Match"] = getEtagHeader(readResp, conn)
+
+	body := &esxiConfig.DisassociateVmCategoriesParams{
+		Categories: expandEsxiCategoryReference(categories),
+	}
+	resp, err := conn.EsxiVMAPIInstance.DisassociateCategories(utils.StringPtr(vmExtID), body, args)
+	if err != nil {
+		return diag.Errorf("error while disassociating categories from esxi vm : %v", err)
+	}
+
+	taskRef := resp.Data.GetValue().(import1.TaskReference)
+	taskUUID := taskRef.ExtId
+
+	taskconn := meta.(*conns.Client).PrismAPI
+	if _, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(timeout)); errWaitTask != nil {
+		return diag.Errorf("error waiting for categories (%s) to detach: %s", utils.StringValue(taskUUID), errWaitTask)
+	}
+	return nil
+}
+
+func expandEsxiCategoryReference(pr []interface{}) []esxiConfig.CategoryReference {
+	catsRef := make([]esxiConfig.CategoryReference, len(pr))
+	for k, v := range pr {
+		catsRef[k] = esxiConfig.CategoryReference{
+			ExtId: utils.StringPtr(v.(map[string]interface{})["ext_id"].(string)),
+		}
+	}
+	return catsRef
+}
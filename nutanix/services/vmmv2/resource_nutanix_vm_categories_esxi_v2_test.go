@@ -0,0 +1,114 @@
+package vmmv2_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
+)
+
+func TestUnitV2NutanixVMCategoriesEsxiResource_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(esxiVMs)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	extID := pc.Add(esxiVMs.Path, map[string]interface{}{
+		"name":       "esxi-vm-1",
+		"categories": []interface{}{map[string]interface{}{"extId": "unmanaged"}},
+	})
+
+	var calls []string
+	for action, associate := range map[string]bool{"associate-categories": true, "disassociate-categories": false} {
+		action, associate := action, associate
+		pc.Handle(http.MethodPost, esxiVMs.Path+"/{extId}/$actions/"+action, func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Categories []map[string]interface{} `json:"categories"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+
+			vm, _ := pc.Get(esxiVMs.Path, extID)
+			categories := make([]interface{}, 0)
+			for _, c := range vm["categories"].([]interface{}) {
+				keep := true
+				for _, b := range body.Categories {
+					if c.(map[string]interface{})["extId"] == b["extId"] {
+						keep = false
+					}
+				}
+				if keep {
+					categories = append(categories, c)
+				}
+			}
+			for _, b := range body.Categories {
+				calls = append(calls, action+":"+b["extId"].(string))
+				if associate {
+					categories = append(categories, b)
+				}
+			}
+			vm["categories"] = categories
+			pc.Add(esxiVMs.Path, vm)
+			mockpc.WriteJSON(w, http.StatusAccepted, pc.StartTask(action, esxiVMs, extID))
+		})
+	}
+
+	r := vmmv2.ResourceNutanixVMCategoriesEsxiV2()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"ext_id":     extID,
+		"categories": []interface{}{map[string]interface{}{"ext_id": "category-1"}},
+	})
+	if diags := r.CreateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Get("categories.#") != 1 {
+		t.Errorf("categories = %v, expected only the managed category", d.Get("categories"))
+	}
+
+	diff, err := r.Diff(ctx, d.State(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"ext_id":     extID,
+		"categories": []interface{}{map[string]interface{}{"ext_id": "category-2"}},
+	}), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	d, err = schema.InternalMap(r.Schema).Data(d.State(), diff)
+	if err != nil {
+		t.Fatalf("data: %v", err)
+	}
+	if diags := r.UpdateContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if diags := r.DeleteContext(ctx, d, meta); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+
+	expected := []string{
+		"associate-categories:category-1",
+		"disassociate-categories:category-1",
+		"associate-categories:category-2",
+		"disassociate-categories:category-2",
+	}
+	if len(calls) != len(expected) {
+		t.Fatalf("calls = %v, expected %v", calls, expected)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("calls = %v, expected %v", calls, expected)
+			break
+		}
+	}
+	vm, _ := pc.Get(esxiVMs.Path, extID)
+	if categories := vm["categories"].([]interface{}); len(categories) != 1 || categories[0].(map[string]interface{})["extId"] != "unmanaged" {
+		t.Errorf("vm categories = %v, expected the unmanaged category to be left untouched", categories)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
@@ -144,8 +143,9 @@ func ResourceNutanixVmsCdRomsInsertEjectV2() *schema.Resource {
 																Elem: &schema.Resource{
 																	Schema: map[string]*schema.Schema{
 																		"bus_type": {
-																			Type:     schema.TypeString,
-																			Optional: true,
+																			Type:         schema.TypeString,
+																			Optional:     true,
+																			ValidateFunc: common.ValidateEnum[config.DiskBusType](),
 																		},
 																		"index": {
 																			Type:     schema.TypeInt,
@@ -227,23 +227,11 @@ func ResourceNutanixVmsCdRomsInsertEjectV2Create(ctx context.Context, d *schema.
 
 		taskconn := meta.(*conns.Client).PrismAPI
 		// Wait for the CD-ROM to be inserted
-		stateConf := &resource.StateChangeConf{
-			Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-			Target:  []string{"SUCCEEDED"},
-			Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-			Timeout: d.Timeout(schema.TimeoutCreate),
-		}
-
-		if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+		taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+		if errWaitTask != nil {
 			return diag.Errorf("error waiting for CD-ROM insert (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 		}
 
-		// Get UUID from TASK API
-		taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-		if err != nil {
-			return diag.Errorf("error while fetching CD-ROM insert task (%s): %v", utils.StringValue(taskUUID), err)
-		}
-		taskDetails := taskResp.Data.GetValue().(import2.Task)
 		aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 		log.Printf("[DEBUG] CD-ROM Insert Task Details: %s", string(aJSON))
 
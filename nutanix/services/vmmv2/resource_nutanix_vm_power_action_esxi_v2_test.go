@@ -0,0 +1,59 @@
+package vmmv2_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/acctest/mockpc"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/services/vmmv2"
+)
+
+func TestUnitV2NutanixVMPowerActionEsxiResource_Actions(t *testing.T) {
+	pc := mockpc.NewServer()
+	defer pc.Close()
+	pc.Register(esxiVMs)
+
+	meta, err := pc.Client()
+	if err != nil {
+		t.Fatalf("error creating client: %v", err)
+	}
+
+	extID := pc.Add(esxiVMs.Path, map[string]interface{}{"name": "esxi-vm-1", "powerState": "ON"})
+
+	r := vmmv2.ResourceNutanixVMPowerActionEsxiV2()
+	for action, path := range map[string]string{
+		"power_on":       "power-on",
+		"power_off":      "power-off",
+		"reset":          "reset",
+		"suspend":        "suspend",
+		"guest_shutdown": "guest-shutdown",
+		"guest_reboot":   "guest-reboot",
+	} {
+		before := len(pc.Requests())
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+			"ext_id": extID,
+			"action": action,
+		})
+		if diags := r.CreateContext(context.Background(), d, meta); diags.HasError() {
+			t.Fatalf("%s: %v", action, diags)
+		}
+		if d.Id() == "" {
+			t.Errorf("%s: id not set", action)
+		}
+
+		found := false
+		for _, req := range pc.Requests()[before:] {
+			if req.Method == http.MethodPost && req.Path == esxiVMs.Path+"/"+extID+"/$actions/"+path {
+				found = true
+				if req.Header.Get("If-Match") == "" {
+					t.Errorf("%s: sent without If-Match", action)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: %s not invoked", action, path)
+		}
+	}
+}
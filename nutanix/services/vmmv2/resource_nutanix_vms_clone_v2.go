@@ -6,10 +6,8 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
@@ -23,6 +21,10 @@ func ResourceNutanixVMCloneV2() *schema.Resource {
 		ReadContext:   ResourceNutanixVMCloneV2Read,
 		UpdateContext: ResourceNutanixVMCloneV2Update,
 		DeleteContext: ResourceNutanixVMCloneV2Delete,
+		CustomizeDiff: validateStructuredGuestCustomization("guest_customization"),
+		Importer: &schema.ResourceImporter{
+			StateContext: importNutanixVMCloneV2,
+		},
 		Schema: map[string]*schema.Schema{
 			"vm_ext_id": {
 				Type:     schema.TypeString,
@@ -132,7 +134,8 @@ func ResourceNutanixVMCloneV2() *schema.Resource {
 										Optional: true,
 										Computed: true,
 										Elem: &schema.Schema{
-											Type: schema.TypeString,
+											Type:         schema.TypeString,
+											ValidateFunc: common.ValidateEnum[config.BootDeviceType](),
 										},
 									},
 								},
@@ -348,7 +351,8 @@ func ResourceNutanixVMCloneV2() *schema.Resource {
 										Optional: true,
 										Computed: true,
 										Elem: &schema.Schema{
-											Type: schema.TypeString,
+											Type:         schema.TypeString,
+											ValidateFunc: common.ValidateEnum[config.BootDeviceType](),
 										},
 									},
 								},
@@ -376,9 +380,10 @@ func ResourceNutanixVMCloneV2() *schema.Resource {
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"install_type": {
-													Type:     schema.TypeString,
-													Optional: true,
-													Computed: true,
+													Type:         schema.TypeString,
+													Optional:     true,
+													Computed:     true,
+													ValidateFunc: common.ValidateEnum[config.InstallType](),
 												},
 												"sysprep_script": {
 													Type:     schema.TypeList,
@@ -386,6 +391,7 @@ func ResourceNutanixVMCloneV2() *schema.Resource {
 													Computed: true,
 													Elem: &schema.Resource{
 														Schema: map[string]*schema.Schema{
+															"unattend": schemaForSysprepUnattend(),
 															"unattend_xml": {
 																Type:     schema.TypeList,
 																Optional: true,
@@ -393,9 +399,10 @@ func ResourceNutanixVMCloneV2() *schema.Resource {
 																Elem: &schema.Resource{
 																	Schema: map[string]*schema.Schema{
 																		"value": {
-																			Type:     schema.TypeString,
-																			Optional: true,
-																			Computed: true,
+																			Type:      schema.TypeString,
+																			Optional:  true,
+																			Computed:  true,
+																			Sensitive: true,
 																		},
 																	},
 																},
@@ -441,21 +448,24 @@ func ResourceNutanixVMCloneV2() *schema.Resource {
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
 												"datasource_type": {
-													Type:     schema.TypeString,
-													Optional: true,
-													Default:  "CONFIG_DRIVE_V2",
+													Type:         schema.TypeString,
+													Optional:     true,
+													Default:      "CONFIG_DRIVE_V2",
+													ValidateFunc: common.ValidateEnum[config.CloudInitDataSourceType](),
 												},
 												"metadata": {
 													Type:     schema.TypeString,
 													Optional: true,
 													Computed: true,
 												},
+												"network_config": schemaForCloudInitNetworkConfig(),
 												"cloud_init_script": {
 													Type:     schema.TypeList,
 													Optional: true,
 													Computed: true,
 													Elem: &schema.Resource{
 														Schema: map[string]*schema.Schema{
+															"cloud_config": schemaForCloudConfig(),
 															"user_data": {
 																Type:     schema.TypeList,
 																Optional: true,
@@ -463,9 +473,10 @@ func ResourceNutanixVMCloneV2() *schema.Resource {
 																Elem: &schema.Resource{
 																	Schema: map[string]*schema.Schema{
 																		"value": {
-																			Type:     schema.TypeString,
-																			Optional: true,
-																			Computed: true,
+																			Type:      schema.TypeString,
+																			Optional:  true,
+																			Computed:  true,
+																			Sensitive: true,
 																		},
 																	},
 																},
@@ -1271,24 +1282,11 @@ func ResourceNutanixVMCloneV2Create(ctx context.Context, d *schema.ResourceData,
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the VM to be cloned
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for VM clone (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching VM clone task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
-
 	aJSON, _ := json.MarshalIndent(taskDetails, "", " ")
 	log.Printf("[DEBUG] Clone VM Task Details: %s", string(aJSON))
 
@@ -1335,7 +1333,7 @@ func ResourceNutanixVMCloneV2Read(ctx context.Context, d *schema.ResourceData, m
 	if err := d.Set("memory_size_bytes", getResp.MemorySizeBytes); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("guest_customization", flattenGuestCustomizationParams(getResp.GuestCustomization)); err != nil {
+	if err := d.Set("guest_customization", flattenGuestCustomizationWithStructuredInputs(d, getResp.GuestCustomization)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("boot_config", flattenOneOfVMBootConfig(getResp.BootConfig)); err != nil {
@@ -1404,7 +1402,7 @@ func ResourceNutanixVMCloneV2Read(ctx context.Context, d *schema.ResourceData, m
 	if err := d.Set("cluster", flattenClusterReference(getResp.Cluster)); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("guest_customization", flattenGuestCustomizationParams(getResp.GuestCustomization)); err != nil {
+	if err := d.Set("guest_customization", flattenGuestCustomizationWithStructuredInputs(d, getResp.GuestCustomization)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("guest_tools", flattenGuestTools(getResp.GuestTools)); err != nil {
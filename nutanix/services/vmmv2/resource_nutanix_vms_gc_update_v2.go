@@ -6,10 +6,8 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	import2 "github.com/nutanix/ntnx-api-golang-clients/prism-go-client/v4/models/prism/v4/config"
 	import1 "github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/prism/v4/config"
 	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
@@ -23,6 +21,7 @@ func ResourceNutanixVMGCUpdateV2() *schema.Resource {
 		ReadContext:   ResourceNutanixVMGCUpdateV2Read,
 		UpdateContext: ResourceNutanixVMGCUpdateV2Update,
 		DeleteContext: ResourceNutanixVMGCUpdateV2Delete,
+		CustomizeDiff: validateStructuredGuestCustomization("config"),
 		Schema: map[string]*schema.Schema{
 			"ext_id": {
 				Type:     schema.TypeString,
@@ -52,6 +51,7 @@ func ResourceNutanixVMGCUpdateV2() *schema.Resource {
 										Computed: true,
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
+												"unattend": schemaForSysprepUnattend(),
 												"unattend_xml": {
 													Type:     schema.TypeList,
 													Optional: true,
@@ -59,9 +59,10 @@ func ResourceNutanixVMGCUpdateV2() *schema.Resource {
 													Elem: &schema.Resource{
 														Schema: map[string]*schema.Schema{
 															"value": {
-																Type:     schema.TypeString,
-																Optional: true,
-																Computed: true,
+																Type:      schema.TypeString,
+																Optional:  true,
+																Computed:  true,
+																Sensitive: true,
 															},
 														},
 													},
@@ -107,21 +108,24 @@ func ResourceNutanixVMGCUpdateV2() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"datasource_type": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Default:  "CONFIG_DRIVE_V2",
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "CONFIG_DRIVE_V2",
+										ValidateFunc: common.ValidateEnum[config.CloudInitDataSourceType](),
 									},
 									"metadata": {
 										Type:     schema.TypeString,
 										Optional: true,
 										Computed: true,
 									},
+									"network_config": schemaForCloudInitNetworkConfig(),
 									"cloud_init_script": {
 										Type:     schema.TypeList,
 										Optional: true,
 										Computed: true,
 										Elem: &schema.Resource{
 											Schema: map[string]*schema.Schema{
+												"cloud_config": schemaForCloudConfig(),
 												"user_data": {
 													Type:     schema.TypeList,
 													Optional: true,
@@ -129,9 +133,10 @@ func ResourceNutanixVMGCUpdateV2() *schema.Resource {
 													Elem: &schema.Resource{
 														Schema: map[string]*schema.Schema{
 															"value": {
-																Type:     schema.TypeString,
-																Optional: true,
-																Computed: true,
+																Type:      schema.TypeString,
+																Optional:  true,
+																Computed:  true,
+																Sensitive: true,
 															},
 														},
 													},
@@ -205,25 +210,11 @@ func ResourceNutanixVMGCUpdateV2Create(ctx context.Context, d *schema.ResourceDa
 
 	taskconn := meta.(*conns.Client).PrismAPI
 	// Wait for the guest customization to complete
-	stateConf := &resource.StateChangeConf{
-		Pending: []string{"PENDING", "RUNNING", "QUEUED"},
-		Target:  []string{"SUCCEEDED"},
-		Refresh: common.TaskStateRefreshPrismTaskGroupFunc(ctx, taskconn, utils.StringValue(taskUUID)),
-		Timeout: d.Timeout(schema.TimeoutCreate),
-	}
-
-	if _, errWaitTask := stateConf.WaitForStateContext(ctx); errWaitTask != nil {
+	taskDetails, errWaitTask := common.WaitForTask(ctx, taskconn, utils.StringValue(taskUUID), d.Timeout(schema.TimeoutCreate))
+	if errWaitTask != nil {
 		return diag.Errorf("error waiting for guest customization (%s) to complete: %s", utils.StringValue(taskUUID), errWaitTask)
 	}
 
-	// Get UUID from TASK API
-	taskResp, err := taskconn.TaskRefAPI.GetTaskById(taskUUID, nil)
-	if err != nil {
-		return diag.Errorf("error while fetching guest customization task (%s): %v", utils.StringValue(taskUUID), err)
-	}
-
-	taskDetails := taskResp.Data.GetValue().(import2.Task)
-
 	aJSON, _ := json.MarshalIndent(taskDetails, "", "  ")
 	log.Printf("[DEBUG] Update GC Task Details: %s", string(aJSON))
 
@@ -381,3 +381,101 @@ func flattenNicProfileReference(ref *config.NicProfileReference) []map[string]in
 		},
 	}
 }
+
+// expandPcieDevices expands the pcie devices blocks of VMs and template VM specs, only the backing device can
+// be set by the user.
+func expandPcieDevices(pr []interface{}) []config.PcieDevice {
+	if len(pr) == 0 {
+		return nil
+	}
+	devices := make([]config.PcieDevice, 0, len(pr))
+	for _, v := range pr {
+		val, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		device := config.NewPcieDevice()
+		if extID, ok := val["ext_id"]; ok && extID != nil && extID.(string) != "" {
+			device.ExtId = utils.StringPtr(extID.(string))
+		}
+		if deviceExtID := pcieDeviceBackingExtID(val); deviceExtID != "" {
+			ref := config.NewPcieDeviceReference()
+			ref.DeviceExtId = utils.StringPtr(deviceExtID)
+			device.BackingInfo = config.NewOneOfPcieDeviceBackingInfo()
+			if err := device.BackingInfo.SetValue(*ref); err != nil {
+				continue
+			}
+		}
+		devices = append(devices, *device)
+	}
+	return devices
+}
+
+// pcieDeviceBackingExtID returns the backing_info.pcie_device_reference.device_ext_id of a pcie_devices block
+func pcieDeviceBackingExtID(val map[string]interface{}) string {
+	backingInfo, ok := val["backing_info"].([]interface{})
+	if !ok || len(backingInfo) == 0 || backingInfo[0] == nil {
+		return ""
+	}
+	ref, ok := backingInfo[0].(map[string]interface{})["pcie_device_reference"].([]interface{})
+	if !ok || len(ref) == 0 || ref[0] == nil {
+		return ""
+	}
+	deviceExtID, _ := ref[0].(map[string]interface{})["device_ext_id"].(string)
+	return deviceExtID
+}
+
+// diffPcieDevices matches the old and new pcie_devices blocks on their backing device, as the computed
+// attributes of a block shift with its index when another block is removed.
+func diffPcieDevices(oldValue, newValue []interface{}) (added, removed []interface{}) {
+	oldDevices := make(map[string]bool, len(oldValue))
+	for _, v := range oldValue {
+		oldDevices[pcieDeviceBackingExtID(v.(map[string]interface{}))] = true
+	}
+	newDevices := make(map[string]bool, len(newValue))
+	for _, v := range newValue {
+		deviceExtID := pcieDeviceBackingExtID(v.(map[string]interface{}))
+		newDevices[deviceExtID] = true
+		if !oldDevices[deviceExtID] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range oldValue {
+		if !newDevices[pcieDeviceBackingExtID(v.(map[string]interface{}))] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+func flattenPcieDevices(pr []config.PcieDevice) []interface{} {
+	if len(pr) == 0 {
+		return nil
+	}
+	devices := make([]interface{}, len(pr))
+	for k, v := range pr {
+		device := map[string]interface{}{
+			"ext_id":    utils.StringValue(v.ExtId),
+			"tenant_id": utils.StringValue(v.TenantId),
+			"links":     flattenAPILink(v.Links),
+		}
+		if v.BackingInfo != nil {
+			if ref, ok := v.BackingInfo.GetValue().(config.PcieDeviceReference); ok {
+				device["backing_info"] = []map[string]interface{}{{
+					"pcie_device_reference": []map[string]interface{}{{
+						"device_ext_id": utils.StringValue(ref.DeviceExtId),
+					}},
+				}}
+			}
+		}
+		if v.AssignedDeviceInfo != nil && v.AssignedDeviceInfo.Device != nil {
+			device["assigned_device_info"] = []map[string]interface{}{{
+				"device": []map[string]interface{}{{
+					"device_ext_id": utils.StringValue(v.AssignedDeviceInfo.Device.DeviceExtId),
+				}},
+			}}
+		}
+		devices[k] = device
+	}
+	return devices
+}
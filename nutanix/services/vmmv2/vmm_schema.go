@@ -2,7 +2,8 @@ package vmmv2
 
 import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/nutanix/ntnx-api-golang-clients/vmm-go-client/v4/models/vmm/v4/ahv/config"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 )
 
 func nicsElemSchemaV2() *schema.Resource {
@@ -24,7 +25,7 @@ func nicsElemSchemaV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice([]string{"VIRTIO", "E1000"}, false),
+							ValidateFunc: common.ValidateEnum[config.VirtualEthernetNicModel](),
 						},
 						"mac_address": {
 							Type:     schema.TypeString,
@@ -52,13 +53,10 @@ func nicsElemSchemaV2() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"nic_type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								"SPAN_DESTINATION_NIC",
-								"NORMAL_NIC", "DIRECT_NIC", "NETWORK_FUNCTION_NIC",
-							}, false),
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: common.ValidateEnum[config.NicType](),
 						},
 						"network_function_chain": {
 							Type:     schema.TypeList,
@@ -75,13 +73,10 @@ func nicsElemSchemaV2() *schema.Resource {
 							},
 						},
 						"network_function_nic_type": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Computed: true,
-							ValidateFunc: validation.StringInSlice([]string{
-								"TAP", "EGRESS",
-								"INGRESS",
-							}, false),
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: common.ValidateEnum[config.NetworkFunctionNicType](),
 						},
 						"subnet": {
 							Type:     schema.TypeList,
@@ -101,7 +96,7 @@ func nicsElemSchemaV2() *schema.Resource {
 							Type:         schema.TypeString,
 							Optional:     true,
 							Computed:     true,
-							ValidateFunc: validation.StringInSlice([]string{"TRUNK", "ACCESS"}, false),
+							ValidateFunc: common.ValidateEnum[config.VlanMode](),
 						},
 						"trunked_vlans": {
 							Type:     schema.TypeList,
@@ -218,7 +213,7 @@ func nicsElemSchemaV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice([]string{"VIRTIO", "E1000"}, false),
+										ValidateFunc: common.ValidateEnum[config.VirtualEthernetNicModel](),
 									},
 									"mac_address": {
 										Type:     schema.TypeString,
@@ -352,13 +347,10 @@ func nicsElemSchemaV2() *schema.Resource {
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"nic_type": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Computed: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											"SPAN_DESTINATION_NIC",
-											"NORMAL_NIC", "DIRECT_NIC", "NETWORK_FUNCTION_NIC",
-										}, false),
+										Type:         schema.TypeString,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: common.ValidateEnum[config.NicType](),
 									},
 									"network_function_chain": {
 										Type:     schema.TypeList,
@@ -375,13 +367,10 @@ func nicsElemSchemaV2() *schema.Resource {
 										},
 									},
 									"network_function_nic_type": {
-										Type:     schema.TypeString,
-										Optional: true,
-										Computed: true,
-										ValidateFunc: validation.StringInSlice([]string{
-											"TAP", "EGRESS",
-											"INGRESS",
-										}, false),
+										Type:         schema.TypeString,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: common.ValidateEnum[config.NetworkFunctionNicType](),
 									},
 									"subnet": {
 										Type:     schema.TypeList,
@@ -401,7 +390,7 @@ func nicsElemSchemaV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice([]string{"TRUNK", "ACCESS"}, false),
+										ValidateFunc: common.ValidateEnum[config.VlanMode](),
 									},
 									"trunked_vlans": {
 										Type:     schema.TypeList,
@@ -567,7 +556,7 @@ func nicsElemSchemaV2() *schema.Resource {
 										Type:         schema.TypeString,
 										Optional:     true,
 										Computed:     true,
-										ValidateFunc: validation.StringInSlice([]string{"TRUNK", "ACCESS"}, false),
+										ValidateFunc: common.ValidateEnum[config.VlanMode](),
 									},
 									"trunked_vlans": {
 										Type:     schema.TypeList,
@@ -9,6 +9,7 @@ import (
 	volumesClientResponse "github.com/nutanix/ntnx-api-golang-clients/volumes-go-client/v4/models/common/v1/response"
 	volumesClient "github.com/nutanix/ntnx-api-golang-clients/volumes-go-client/v4/models/volumes/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -28,6 +29,11 @@ func DatasourceNutanixVolumeGroupsV2() *schema.Resource {
 				Type:        schema.TypeInt,
 				Optional:    true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -214,7 +220,9 @@ func DatasourceNutanixVolumeGroupsV2Read(ctx context.Context, d *schema.Resource
 	}
 
 	// get the volume groups response
-	resp, err := conn.VolumeAPIInstance.ListVolumeGroups(page, limit, filter, orderBy, expand, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*volumesClient.ListVolumeGroupsApiResponse, error) {
+		return conn.VolumeAPIInstance.ListVolumeGroups(page, limit, filter, orderBy, expand, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching volumes : %v", err)
 	}
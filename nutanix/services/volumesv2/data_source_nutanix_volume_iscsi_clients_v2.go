@@ -9,6 +9,7 @@ import (
 	"github.com/nutanix/ntnx-api-golang-clients/volumes-go-client/v4/models/common/v1/config"
 	volumesClient "github.com/nutanix/ntnx-api-golang-clients/volumes-go-client/v4/models/volumes/v4/config"
 	conns "github.com/terraform-providers/terraform-provider-nutanix/nutanix"
+	"github.com/terraform-providers/terraform-provider-nutanix/nutanix/common"
 	"github.com/terraform-providers/terraform-provider-nutanix/utils"
 )
 
@@ -28,6 +29,11 @@ func DatasourceNutanixVolumeIscsiClientsV2() *schema.Resource {
 				Type:        schema.TypeInt,
 				Optional:    true,
 			},
+			"fetch_all": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"page"},
+			},
 			"filter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -193,7 +199,9 @@ func DatasourceNutanixVolumeIscsiClientsV2Read(ctx context.Context, d *schema.Re
 	}
 
 	// get the volume group iscsi clients
-	resp, err := conn.IscsiClientAPIInstance.ListIscsiClients(page, limit, filter, orderBy, expand, selects)
+	resp, err := common.ListAllPages(d.Get("fetch_all").(bool), page, limit, func(page, limit *int) (*volumesClient.ListIscsiClientsApiResponse, error) {
+		return conn.IscsiClientAPIInstance.ListIscsiClients(page, limit, filter, orderBy, expand, selects)
+	})
 	if err != nil {
 		return diag.Errorf("error while fetching Iscsi Clients : %v", err)
 	}
@@ -7,6 +7,7 @@ const (
 	RelEntityTypeImages                 = "vmm:content:image"
 	RelEntityTypeImagePlacementPolicy   = "vmm:images:config:placement-policy"
 	RelEntityTypeTemplates              = "vmm:content:template"
+	RelEntityTypeTemplateVersion        = "vmm:content:template-version"
 	RelEntityTypeVolumeGroup            = "volumes:config:volume-group"
 	RelEntityTypeVolumeGroupDisk        = "volumes:config:volume-group:disk"
 	RelEntityTypeIscsiClient            = "volumes:config:iscsi-client"
@@ -25,6 +26,12 @@ const (
 	RelEntityTypeVMRecoveryPoint        = "dataprotection:config:vm-recovery-point"
 	RelEntityTypeStorageContainer       = "clustermgmt:config:storage-containers"
 	RelEntityTypeRoute                  = "networking:config:route"
+	RelEntityTypeGateway                = "networking:config:gateway"
+	RelEntityTypeVpnConnection          = "networking:config:vpn-connection"
+	RelEntityTypeBgpSession             = "networking:config:bgp-session"
+	RelEntityTypeLayer2Stretch          = "networking:config:layer2-stretch"
+	RelEntityTypeVirtualSwitch          = "networking:config:virtual-switch"
+	RelEntityTypeTrafficMirror          = "networking:config:traffic-mirror"
 	RelEntityTypeObjects                = "objects:config:object-store"
 	RelEntityTypeObjectStoreCertificate = "objects:config:object-store:certificate"
 	RelEntityTypeOVA                    = "vmm:content:ova"